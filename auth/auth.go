@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+/*
+Requests may carry an OAuth2-style bearer token in the Authorization header (or the "authorization"
+gRPC metadata key). A token that is present must be an RS256 or ES256 JWT signed by a key in the configured JWKS,
+issued by the configured issuer for the configured audience, and not expired. The verified Claims
+are put into the request context, where server methods read them with FromContext. Which methods need
+a token, and with which scopes, is decided by a Policy (see policy.go).
+*/
+
+// Claims are the verified parts of a token the service relies on
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	// Scopes come from the space separated "scope" claim, or the "permissions" array some issuers use instead
+	Scopes []string
+}
+
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Scope       string   `json:"scope,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type Verifier struct {
+	keys     KeySource
+	issuer   string
+	audience string
+	parser   *jwt.Parser
+}
+
+func NewVerifier(keys KeySource, issuer, audience string) *Verifier {
+	return &Verifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		// pinning the algorithms rules out "none" and HS256 signed with the public key as the secret
+		parser: jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "ES256"})),
+	}
+}
+
+// Verify checks the token's signature, issuer, audience and expiry and returns its claims
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var claims tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// jwt only checks exp when present, but a token that never expires is not accepted here
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token has no expiry")
+	}
+	if !claims.VerifyIssuer(v.issuer, true) {
+		return nil, fmt.Errorf("token issuer %q is not %q", claims.Issuer, v.issuer)
+	}
+	if !claims.VerifyAudience(v.audience, true) {
+		return nil, fmt.Errorf("token audience %v does not include %q", claims.Audience, v.audience)
+	}
+
+	scopes := strings.Fields(claims.Scope)
+	if len(scopes) == 0 {
+		scopes = claims.Permissions
+	}
+	return &Claims{
+		Subject:   claims.Subject,
+		Issuer:    claims.Issuer,
+		Audience:  claims.Audience,
+		ExpiresAt: claims.ExpiresAt.Time,
+		Scopes:    scopes,
+	}, nil
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header value
+func bearerToken(header string) (string, error) {
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") || strings.TrimSpace(parts[1]) == "" {
+		return "", errors.New("authorization header is not a bearer token")
+	}
+	return strings.TrimSpace(parts[1]), nil
+}
+
+// authenticate resolves an authorization header to claims, returning a twirp Unauthenticated error otherwise.
+// A request without the header is anonymous (nil claims), and the Policy decides whether that is enough.
+// Verification details are not sent back, so callers cannot probe which check failed
+func (v *Verifier) authenticate(header string) (*Claims, error) {
+	if header == "" {
+		return nil, nil
+	}
+	token, err := bearerToken(header)
+	if err != nil {
+		return nil, twirp.NewError(twirp.Unauthenticated, err.Error())
+	}
+	claims, err := v.Verify(token)
+	if err != nil {
+		return nil, twirp.NewError(twirp.Unauthenticated, "invalid bearer token")
+	}
+	return claims, nil
+}
+
+type claimsKey struct{}
+
+type enabledKey struct{}
+
+// Enabled reports whether the request went through a Verifier, so a request without claims is anonymous
+// rather than served with authentication disabled
+func Enabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(enabledKey{}).(bool)
+	return enabled
+}
+
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims of the authenticated caller, or false when auth is disabled
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+// Middleware rejects Twirp requests with an invalid bearer token before they reach the service
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := v.authenticate(r.Header.Get("Authorization"))
+		if err != nil {
+			twirp.WriteError(w, err)
+			return
+		}
+		ctx := context.WithValue(r.Context(), enabledKey{}, true)
+		if claims != nil {
+			ctx = NewContext(ctx, claims)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// UnaryInterceptor is the gRPC equivalent of Middleware
+func (v *Verifier) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		header := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("authorization"); len(values) > 0 {
+				header = values[0]
+			}
+		}
+		claims, err := v.authenticate(header)
+		if err != nil {
+			return nil, status.Error(grpcCode(twirp.Unauthenticated), err.(twirp.Error).Msg())
+		}
+		ctx = context.WithValue(ctx, enabledKey{}, true)
+		if claims != nil {
+			ctx = NewContext(ctx, claims)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// grpcCode maps the twirp codes returned by this package to their gRPC equivalents
+func grpcCode(code twirp.ErrorCode) codes.Code {
+	switch code {
+	case twirp.Unauthenticated:
+		return codes.Unauthenticated
+	case twirp.PermissionDenied:
+		return codes.PermissionDenied
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,242 @@
+package auth_test
+
+import (
+	"blog-service/auth"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	issuer   = "https://issuer.example/"
+	audience = "blog-service"
+)
+
+var (
+	rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _  = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+)
+
+func b64(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func jwks(t *testing.T) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kid": "rsa-1", "kty": "RSA", "use": "sig", "n": b64(rsaKey.N), "e": b64(big.NewInt(int64(rsaKey.E)))},
+			{"kid": "ec-1", "kty": "EC", "crv": "P-256", "x": b64(ecKey.X), "y": b64(ecKey.Y)},
+			// skipped: not a signing key
+			{"kid": "enc-1", "kty": "RSA", "use": "enc", "n": b64(rsaKey.N), "e": "AQAB"},
+		},
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"iss":   issuer,
+		"aud":   audience,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "blog:read blog:write",
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func fileVerifier(t *testing.T) *auth.Verifier {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwks(t), 0600))
+	keys, err := auth.LoadJWKSFile(path)
+	require.NoError(t, err)
+	return auth.NewVerifier(keys, issuer, audience)
+}
+
+func TestVerify_Valid(t *testing.T) {
+	verifier := fileVerifier(t)
+
+	claims, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims()))
+	require.NoError(t, err)
+	require.Equal(t, "user-1", claims.Subject)
+	require.Equal(t, []string{"blog:read", "blog:write"}, claims.Scopes)
+	require.True(t, claims.HasScope("blog:write"))
+	require.False(t, claims.HasScope("blog:admin"))
+
+	withPermissions := validClaims()
+	delete(withPermissions, "scope")
+	withPermissions["permissions"] = []string{"blog:read"}
+	withPermissions["aud"] = []string{"other", audience}
+	claims, err = verifier.Verify(sign(t, jwt.SigningMethodES256, "ec-1", ecKey, withPermissions))
+	require.NoError(t, err)
+	require.Equal(t, []string{"blog:read"}, claims.Scopes)
+}
+
+func TestVerify_Rejected(t *testing.T) {
+	verifier := fileVerifier(t)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	with := func(key string, value interface{}) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+
+	cases := map[string]string{
+		"wrong issuer":     sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, with("iss", "https://evil.example/")),
+		"missing issuer":   sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, with("iss", nil)),
+		"wrong audience":   sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, with("aud", "other")),
+		"expired":          sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, with("exp", time.Now().Add(-time.Minute).Unix())),
+		"no expiry":        sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, with("exp", nil)),
+		"unknown kid":      sign(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, validClaims()),
+		"wrong key":        sign(t, jwt.SigningMethodRS256, "rsa-1", otherKey, validClaims()),
+		"encryption key":   sign(t, jwt.SigningMethodRS256, "enc-1", rsaKey, validClaims()),
+		"HS256":            sign(t, jwt.SigningMethodHS256, "rsa-1", []byte("secret"), validClaims()),
+		"none":             sign(t, jwt.SigningMethodNone, "rsa-1", jwt.UnsafeAllowNoneSignatureType, validClaims()),
+		"key type mixup":   sign(t, jwt.SigningMethodES256, "rsa-1", ecKey, validClaims()),
+		"not a token":      "not-a-token",
+		"tampered payload": sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims()) + "x",
+	}
+
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.Verify(token)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRemoteKeySource_RefetchesOnUnknownKid(t *testing.T) {
+	var fetches int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Write(jwks(t))
+	}))
+	defer ts.Close()
+
+	keys := auth.NewRemoteKeySource(ts.URL)
+	require.NoError(t, keys.Refresh())
+	verifier := auth.NewVerifier(keys, issuer, audience)
+
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims()))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+
+	// a just-fetched JWKS is not refetched for an unknown kid, so bad tokens cannot hammer the issuer
+	_, err = verifier.Verify(sign(t, jwt.SigningMethodRS256, "rotated", rsaKey, validClaims()))
+	require.Error(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&fetches))
+}
+
+func TestRemoteKeySource_BadURL(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+	require.Error(t, auth.NewRemoteKeySource(ts.URL).Refresh())
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":      "{",
+		"no keys":       `{"keys": []}`,
+		"only enc keys": `{"keys": [{"kid": "a", "kty": "RSA", "use": "enc", "n": "AQAB", "e": "AQAB"}]}`,
+		"bad modulus":   `{"keys": [{"kid": "a", "kty": "RSA", "n": "!!", "e": "AQAB"}]}`,
+		"off curve":     `{"keys": [{"kid": "a", "kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.ParseJWKS([]byte(data))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	verifier := fileVerifier(t)
+	handler := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := auth.FromContext(r.Context()); ok {
+			w.Write([]byte(claims.Subject))
+		}
+	}))
+
+	request := func(header string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/twirp/service.BlogService/ListBlog", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+		return res
+	}
+
+	res := request("Bearer " + sign(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, validClaims()))
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, "user-1", res.Body.String())
+
+	// anonymous requests pass through without claims, the Policy decides what they may call
+	res = request("")
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Empty(t, res.Body.String())
+
+	for _, header := range []string{"Basic dXNlcjpwYXNz", "Bearer ", "Bearer not-a-token"} {
+		res := request(header)
+		require.Equal(t, http.StatusUnauthorized, res.Code, header)
+		require.Contains(t, res.Body.String(), `"code":"unauthenticated"`)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	interceptor := fileVerifier(t).UnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if claims, ok := auth.FromContext(ctx); ok {
+			return claims.Subject, nil
+		}
+		return "anonymous", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/service.BlogService/ListBlog"}
+
+	token := sign(t, jwt.SigningMethodES256, "ec-1", ecKey, validClaims())
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	res, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", res)
+
+	res, err = interceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "anonymous", res)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer not-a-token"))
+	_, err = interceptor(ctx, nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+/*
+for reference:
+https://datatracker.ietf.org/doc/html/rfc7517 (JSON Web Key)
+https://datatracker.ietf.org/doc/html/rfc7518#section-6 (RSA and EC key parameters)
+*/
+
+// KeySource returns the public key a token's "kid" header refers to
+type KeySource interface {
+	Key(kid string) (crypto.PublicKey, error)
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS returns the RSA and P-256 EC signing keys of a JWKS document by kid, other keys are skipped
+func ParseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS key %q: %w", jwk.Kid, err)
+		}
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS has no RSA or P-256 signing keys")
+	}
+	return keys, nil
+}
+
+// publicKey returns nil for key types that cannot verify RS256 or ES256
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	decode := func(value string) (*big.Int, error) {
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("invalid base64url value %q", value)
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, fmt.Errorf("EC point is not on P-256")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	}
+	return nil, nil
+}
+
+// StaticKeySource serves keys loaded once, e.g. from a local JWKS file
+type StaticKeySource map[string]crypto.PublicKey
+
+func (s StaticKeySource) Key(kid string) (crypto.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+func LoadJWKSFile(path string) (StaticKeySource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read JWKS file: %w", err)
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	return StaticKeySource(keys), nil
+}
+
+// RemoteKeySource fetches a JWKS from a URL and caches it. An unknown kid triggers a refetch, since it
+// usually means the issuer rotated its keys, but fetches start at most once per minRefresh, whether or not the
+// last one succeeded, so bad tokens or an issuer that is down cannot make every request hit the issuer.
+// Fetches run without holding mu, so a slow issuer only delays the lookups waiting for new keys, and concurrent
+// lookups share one fetch
+type RemoteKeySource struct {
+	url        string
+	client     *http.Client
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+	// lastAttempt is when the last fetch ended and fetchErr why it failed, nil when it did not
+	lastAttempt time.Time
+	fetchErr    error
+	// the fetch in flight, nil when there is none
+	inflight *jwksFetch
+}
+
+// jwksFetch is a fetch shared by every lookup that needs it, err is set before done is closed
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+func NewRemoteKeySource(url string) *RemoteKeySource {
+	return &RemoteKeySource{
+		url:        url,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		ttl:        time.Hour,
+		minRefresh: time.Minute,
+	}
+}
+
+func (r *RemoteKeySource) Key(kid string) (crypto.PublicKey, error) {
+	r.mu.Lock()
+	age := time.Since(r.fetchedAt)
+	key, ok := r.keys[kid]
+	refetch := time.Since(r.lastAttempt) >= r.minRefresh
+	noKeys, fetchErr := r.keys == nil, r.fetchErr
+	r.mu.Unlock()
+	if ok && age < r.ttl {
+		return key, nil
+	}
+
+	if refetch {
+		if err := r.refetch(); err != nil {
+			// keep serving cached keys if the issuer is briefly unreachable
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		r.mu.Lock()
+		key, ok = r.keys[kid]
+		r.mu.Unlock()
+	} else if noKeys {
+		// no JWKS was ever fetched, the last failure says why
+		return nil, fetchErr
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// Refresh fetches the JWKS now, so startup fails fast on a wrong URL
+func (r *RemoteKeySource) Refresh() error {
+	return r.refetch()
+}
+
+// refetch fetches the JWKS and replaces the cached keys with it, or waits for the fetch already in flight
+func (r *RemoteKeySource) refetch() error {
+	r.mu.Lock()
+	if call := r.inflight; call != nil {
+		r.mu.Unlock()
+		<-call.done
+		return call.err
+	}
+	call := &jwksFetch{done: make(chan struct{})}
+	r.inflight = call
+	r.mu.Unlock()
+
+	keys, err := r.fetch()
+
+	r.mu.Lock()
+	r.lastAttempt = time.Now()
+	r.fetchErr = err
+	if err == nil {
+		r.keys = keys
+		r.fetchedAt = r.lastAttempt
+	}
+	r.inflight = nil
+	r.mu.Unlock()
+
+	call.err = err
+	close(call.done)
+	return err
+}
+
+func (r *RemoteKeySource) fetch() (map[string]crypto.PublicKey, error) {
+	res, err := r.client.Get(r.url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch JWKS: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch JWKS: %s returned %s", r.url, res.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read JWKS: %w", err)
+	}
+	return ParseJWKS(data)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteKeySource_SlowFetchDoesNotBlockCachedKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	b64 := func(n *big.Int) string { return base64.RawURLEncoding.EncodeToString(n.Bytes()) }
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{"kid": "rsa-1", "kty": "RSA", "n": b64(key.N), "e": b64(big.NewInt(int64(key.E)))}},
+	})
+	require.NoError(t, err)
+
+	// every fetch after the first hangs until release is closed
+	var fetches int32
+	refetching := make(chan struct{})
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&fetches, 1) > 1 {
+			close(refetching)
+			<-release
+		}
+		w.Write(data)
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	keys := NewRemoteKeySource(ts.URL)
+	keys.minRefresh = 0
+	require.NoError(t, keys.Refresh())
+
+	rotated := make(chan error, 1)
+	go func() {
+		_, err := keys.Key("rotated")
+		rotated <- err
+	}()
+	<-refetching
+
+	cached := make(chan error, 1)
+	go func() {
+		_, err := keys.Key("rsa-1")
+		cached <- err
+	}()
+	select {
+	case err := <-cached:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("a cached key waited for the JWKS fetch")
+	}
+	release <- struct{}{}
+	require.Error(t, <-rotated)
+}
+
+func TestRemoteKeySource_FailedFetchesAreThrottled(t *testing.T) {
+	var fetches int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		http.Error(w, "down", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	// within minRefresh of a failed fetch, lookups fail without fetching again, with or without cached keys
+	keys := NewRemoteKeySource(ts.URL)
+	for i := 0; i < 5; i++ {
+		_, err := keys.Key("rsa-1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "500")
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	keys.mu.Lock()
+	keys.keys = map[string]crypto.PublicKey{}
+	keys.mu.Unlock()
+	for i := 0; i < 5; i++ {
+		_, err := keys.Key("unknown")
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
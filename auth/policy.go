@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Policy maps a method name, e.g. "CreateBlog", to the scopes a caller needs for it. A method listed
+// with no scopes is public and may be called without a token, a method that is not listed is denied to everyone,
+// so a new RPC stays closed until it is added to the table
+type Policy map[string][]string
+
+// Authorize checks the claims in ctx against the policy of method
+func (p Policy) Authorize(ctx context.Context, method string) error {
+	scopes, ok := p[method]
+	if !ok {
+		return twirp.NewError(twirp.PermissionDenied, fmt.Sprintf("%s is not allowed by the access policy", method))
+	}
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return twirp.NewError(twirp.Unauthenticated, fmt.Sprintf("%s requires a bearer token", method))
+	}
+	missing := []string{}
+	for _, scope := range scopes {
+		if !claims.HasScope(scope) {
+			missing = append(missing, scope)
+		}
+	}
+	if len(missing) > 0 {
+		return twirp.NewError(twirp.PermissionDenied, fmt.Sprintf("%s requires scope %s", method, strings.Join(missing, " ")))
+	}
+	return nil
+}
+
+// TwirpInterceptor applies the policy to the method name twirp puts in the request context
+func (p Policy) TwirpInterceptor() twirp.Interceptor {
+	return func(next twirp.Method) twirp.Method {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			method, _ := twirp.MethodName(ctx)
+			if err := p.Authorize(ctx, method); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// UnaryInterceptor applies the policy to gRPC calls, whose full method is "/<package>.<Service>/<Method>"
+func (p Policy) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		if err := p.Authorize(ctx, method); err != nil {
+			twerr := err.(twirp.Error)
+			return nil, status.Error(grpcCode(twerr.Code()), twerr.Msg())
+		}
+		return handler(ctx, req)
+	}
+}
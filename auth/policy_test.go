@@ -0,0 +1,68 @@
+package auth_test
+
+import (
+	"blog-service/auth"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var policy = auth.Policy{
+	"GetBlog":    {},
+	"CreateBlog": {"blog:write"},
+	"DeleteBlog": {"blog:write", "blog:delete"},
+}
+
+func requireCode(t *testing.T, code twirp.ErrorCode, err error) {
+	t.Helper()
+	twerr, ok := err.(twirp.Error)
+	require.True(t, ok, "expected a twirp error, got %v", err)
+	require.Equal(t, code, twerr.Code())
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	anonymous := context.Background()
+	writer := auth.NewContext(anonymous, &auth.Claims{Subject: "user-1", Scopes: []string{"blog:write"}})
+	admin := auth.NewContext(anonymous, &auth.Claims{Subject: "user-2", Scopes: []string{"blog:write", "blog:delete"}})
+
+	require.NoError(t, policy.Authorize(anonymous, "GetBlog"))
+	require.NoError(t, policy.Authorize(writer, "GetBlog"))
+
+	requireCode(t, twirp.Unauthenticated, policy.Authorize(anonymous, "CreateBlog"))
+	require.NoError(t, policy.Authorize(writer, "CreateBlog"))
+
+	// every listed scope is required
+	requireCode(t, twirp.PermissionDenied, policy.Authorize(writer, "DeleteBlog"))
+	require.NoError(t, policy.Authorize(admin, "DeleteBlog"))
+
+	// methods missing from the table are denied even to authenticated callers
+	requireCode(t, twirp.PermissionDenied, policy.Authorize(admin, "PurgeBlogs"))
+}
+
+func TestPolicy_Interceptors(t *testing.T) {
+	called := false
+	next := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+
+	ctx := ctxsetters.WithMethodName(context.Background(), "CreateBlog")
+	_, err := policy.TwirpInterceptor()(next)(ctx, nil)
+	requireCode(t, twirp.Unauthenticated, err)
+	require.False(t, called)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/service.BlogService/GetBlog"}
+	_, err = policy.UnaryInterceptor()(context.Background(), nil, info, next)
+	require.NoError(t, err)
+	require.True(t, called)
+
+	info = &grpc.UnaryServerInfo{FullMethod: "/service.BlogService/CreateBlog"}
+	_, err = policy.UnaryInterceptor()(context.Background(), nil, info, next)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
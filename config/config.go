@@ -2,27 +2,78 @@ package config
 
 import (
 	db "blog-service/db"
+	"blog-service/render"
 	blogProto "blog-service/rpc/blog"
+	"context"
 	"log"
+	"time"
 )
 
 var DB DBClient
-var Port = 5050
+
+// Comments is set by SetDB along with DB, on the same database
+var Comments CommentStore
+
+// RenderCache holds the rendered HTML of blogs, main sizes it from RenderSettings
+var RenderCache = render.NewCache(1000)
 
 type DBClient interface {
 	Connect() error
-	CreateBlog(*blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error)
-	GetBlog(*blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error)
-	UpdateBlog(*blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error)
-	DeleteBlog(*blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error)
-	ListBlog(*blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error)
+	CreateBlog(context.Context, *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error)
+	GetBlog(context.Context, *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error)
+	GetBlogBySlug(context.Context, *blogProto.GetBlogBySlugRequest) (*blogProto.GetBlogResponse, error)
+	UpdateBlog(context.Context, *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error)
+	DeleteBlog(context.Context, *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error)
+	UndeleteBlog(context.Context, *blogProto.UndeleteBlogRequest) (*blogProto.UndeleteBlogResponse, error)
+	ListBlog(context.Context, *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error)
+	ListDeletedBlogs(context.Context, *blogProto.ListDeletedBlogsRequest) (*blogProto.ListDeletedBlogsResponse, error)
+	SearchBlogs(context.Context, *blogProto.SearchBlogsRequest) (*blogProto.SearchBlogsResponse, error)
+	PublishBlog(context.Context, *blogProto.PublishBlogRequest) (*blogProto.UpdateBlogResponse, error)
+	UnpublishBlog(context.Context, *blogProto.UnpublishBlogRequest) (*blogProto.UpdateBlogResponse, error)
+	// PublishScheduledBlogs publishes the scheduled blogs whose publish_time is not after now and returns how many it published
+	PublishScheduledBlogs(ctx context.Context, now time.Time) (int64, error)
+	ListTags(context.Context, *blogProto.ListTagsRequest) (*blogProto.ListTagsResponse, error)
+	// CountPublishedBlogs and WalkPublishedBlogs read the published blogs for the sitemap, the walk calls fn in id
+	// order for at most limit blogs, or all when limit is 0, with ids from from up to but excluding to, an empty
+	// bound leaving that side open, without loading them all at once
+	CountPublishedBlogs(ctx context.Context) (int64, error)
+	WalkPublishedBlogs(ctx context.Context, from string, to string, limit int64, fn db.SitemapFunc) error
+	// UpdateBlog saves the replaced title and content as a revision, these read them back
+	ListBlogRevisions(context.Context, *blogProto.ListBlogRevisionsRequest) (*blogProto.ListBlogRevisionsResponse, error)
+	GetBlogRevision(context.Context, *blogProto.GetBlogRevisionRequest) (*blogProto.BlogRevision, error)
+	// PurgeDeletedBlogs permanently removes blogs deleted before the given time and returns how many it removed
+	PurgeDeletedBlogs(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// the batch methods report each item in its own result unless all_or_nothing is set, see db/batch.go
+	BatchCreateBlogs(context.Context, *blogProto.BatchCreateBlogsRequest) (*blogProto.BatchCreateBlogsResponse, error)
+	BatchGetBlogs(context.Context, *blogProto.BatchGetBlogsRequest) (*blogProto.BatchGetBlogsResponse, error)
+	BatchDeleteBlogs(context.Context, *blogProto.BatchDeleteBlogsRequest) (*blogProto.BatchDeleteBlogsResponse, error)
+}
+
+// CommentStore is implemented for each backend in the db package, mirroring DBClient. It uses the connection
+// DBClient.Connect opens, so it has no Connect of its own
+type CommentStore interface {
+	CreateComment(context.Context, *blogProto.CreateCommentRequest) (*blogProto.Comment, error)
+	GetComment(context.Context, *blogProto.GetCommentRequest) (*blogProto.Comment, error)
+	ListComments(context.Context, *blogProto.ListCommentsRequest) (*blogProto.ListCommentsResponse, error)
+	UpdateComment(context.Context, *blogProto.UpdateCommentRequest) (*blogProto.Comment, error)
+	DeleteComment(context.Context, *blogProto.DeleteCommentRequest) (*blogProto.DeleteCommentResponse, error)
+	// OrphanComments hides the comments of a blog moved to the trash, or shows them again when orphaned is false
+	OrphanComments(ctx context.Context, blogId string, orphaned bool) error
+	// PurgeOrphanedComments permanently removes comments orphaned before the given time and returns how many it removed
+	PurgeOrphanedComments(ctx context.Context, orphanedBefore time.Time) (int64, error)
 }
 
-func SetDB(dbToUse string) {
-	if dbToUse == "postgres" {
-		DB = db.NewPostgresClient()
-	} else {
-		DB = db.NewMongoClient()
+func SetDB(settings Settings) {
+	switch settings.Database {
+	case "postgres":
+		DB = db.NewPostgresClient(settings.Postgres.DSN(), settings.Postgres.AutoMigrate)
+		Comments = db.NewPostgresCommentStore()
+	case "memory":
+		DB = db.NewMemoryClient()
+		Comments = db.NewMemoryCommentStore()
+	default:
+		DB = db.NewMongoClient(settings.Mongo.URI, settings.Mongo.Database, settings.Mongo.Collection)
+		Comments = db.NewMongoCommentStore()
 	}
 	err := DB.Connect()
 	if err != nil {
@@ -0,0 +1,431 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+/*
+Settings are resolved from lowest to highest precedence:
+ 1. built-in defaults (a local Mongo/Postgres, matching the README docker instructions)
+ 2. a YAML or JSON config file, given with -config or BLOG_CONFIG
+ 3. BLOG_* environment variables
+ 4. command-line flags (and the database name as the first positional argument, e.g. `go run main.go postgres`)
+*/
+
+type Settings struct {
+	Database string           `yaml:"database" json:"database"`
+	Port     int              `yaml:"port" json:"port"`
+	GrpcPort int              `yaml:"grpc_port" json:"grpc_port"`
+	Mongo    MongoSettings    `yaml:"mongo" json:"mongo"`
+	Postgres PostgresSettings `yaml:"postgres" json:"postgres"`
+	Auth     AuthSettings     `yaml:"auth" json:"auth"`
+	Trash    TrashSettings    `yaml:"trash" json:"trash"`
+	Publish  PublishSettings  `yaml:"publish" json:"publish"`
+	Render   RenderSettings   `yaml:"render" json:"render"`
+	Site     SiteSettings     `yaml:"site" json:"site"`
+	Feed     FeedSettings     `yaml:"feed" json:"feed"`
+}
+
+type MongoSettings struct {
+	URI        string `yaml:"uri" json:"uri"`
+	Database   string `yaml:"database" json:"database"`
+	Collection string `yaml:"collection" json:"collection"`
+}
+
+type PostgresSettings struct {
+	Host     string `yaml:"host" json:"host"`
+	Port     int    `yaml:"port" json:"port"`
+	User     string `yaml:"user" json:"user"`
+	Password string `yaml:"password" json:"password"`
+	DBName   string `yaml:"dbname" json:"dbname"`
+	SSLMode  string `yaml:"sslmode" json:"sslmode"`
+	// AutoMigrate applies pending schema migrations when the server connects, see `go run main.go migrate -h`
+	AutoMigrate bool `yaml:"auto_migrate" json:"auto_migrate"`
+}
+
+// AuthSettings configure bearer JWT validation. Auth is off unless a JWKS URL or file is set
+type AuthSettings struct {
+	JWKSURL  string `yaml:"jwks_url" json:"jwks_url"`
+	JWKSFile string `yaml:"jwks_file" json:"jwks_file"`
+	Issuer   string `yaml:"issuer" json:"issuer"`
+	Audience string `yaml:"audience" json:"audience"`
+}
+
+func (a AuthSettings) Enabled() bool {
+	return a.JWKSURL != "" || a.JWKSFile != ""
+}
+
+// TrashSettings configure the job that permanently removes deleted blogs
+type TrashSettings struct {
+	// Retention is how long deleted blogs can be restored, 0 keeps them forever
+	Retention Duration `yaml:"retention" json:"retention"`
+	// PurgeInterval is how often blogs past the retention are removed
+	PurgeInterval Duration `yaml:"purge_interval" json:"purge_interval"`
+}
+
+// PublishSettings configure the scheduler that publishes blogs scheduled by PublishBlog
+type PublishSettings struct {
+	// SchedulerInterval is how often blogs whose publish_time has arrived are published
+	SchedulerInterval Duration `yaml:"scheduler_interval" json:"scheduler_interval"`
+}
+
+// RenderSettings configure the rendering of blog content to HTML
+type RenderSettings struct {
+	// CacheSize is how many rendered blogs are kept in memory, 0 renders every read
+	CacheSize int `yaml:"cache_size" json:"cache_size"`
+}
+
+// SiteSettings describe the public site showing the blogs, which the feeds and sitemap link to
+type SiteSettings struct {
+	// URL is the home page of the site, a blog is at URL/posts/<slug>
+	URL   string `yaml:"url" json:"url"`
+	Title string `yaml:"title" json:"title"`
+}
+
+// maxFeedSize keeps feeds small enough for readers that poll them often
+const maxFeedSize = 100
+
+// FeedSettings configure the RSS and Atom feeds served at /feed.rss and /feed.atom
+type FeedSettings struct {
+	// Size is how many of the most recent published blogs the feeds hold
+	Size int `yaml:"size" json:"size"`
+}
+
+// Duration reads durations like "720h" or "90m" from config files, where time.Duration would need nanoseconds
+type Duration time.Duration
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Database: "mongo",
+		Port:     5050,
+		GrpcPort: 5051,
+		Mongo: MongoSettings{
+			URI:        "mongodb://localhost:27017",
+			Database:   "mydb",
+			Collection: "blog",
+		},
+		Postgres: PostgresSettings{
+			Host:        "localhost",
+			Port:        5432,
+			User:        "root",
+			DBName:      "root",
+			SSLMode:     "disable",
+			AutoMigrate: true,
+		},
+		Trash: TrashSettings{
+			Retention:     Duration(30 * 24 * time.Hour),
+			PurgeInterval: Duration(time.Hour),
+		},
+		Publish: PublishSettings{
+			SchedulerInterval: Duration(time.Minute),
+		},
+		Render: RenderSettings{
+			CacheSize: 1000,
+		},
+		Site: SiteSettings{
+			URL:   "http://localhost:8080",
+			Title: "Blog",
+		},
+		Feed: FeedSettings{
+			Size: 20,
+		},
+	}
+}
+
+// DSN builds a lib/pq connection string, quoting values so passwords may contain spaces or quotes
+func (p PostgresSettings) DSN() string {
+	quote := func(value string) string {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		value = strings.ReplaceAll(value, `'`, `\'`)
+		return "'" + value + "'"
+	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quote(p.Host), p.Port, quote(p.User), quote(p.Password), quote(p.DBName), quote(p.SSLMode))
+}
+
+// setting ties one field of Settings to its flag and environment variable
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	apply func(s *Settings, value string) error
+}
+
+func stringSetting(flag, env, usage string, field func(s *Settings) *string) setting {
+	return setting{flag, env, usage, func(s *Settings, value string) error {
+		*field(s) = value
+		return nil
+	}}
+}
+
+func intSetting(flag, env, usage string, field func(s *Settings) *int) setting {
+	return setting{flag, env, usage, func(s *Settings, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*field(s) = n
+		return nil
+	}}
+}
+
+func boolSetting(flag, env, usage string, field func(s *Settings) *bool) setting {
+	return setting{flag, env, usage, func(s *Settings, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		*field(s) = b
+		return nil
+	}}
+}
+
+func durationSetting(flag, env, usage string, field func(s *Settings) *Duration) setting {
+	return setting{flag, env, usage, func(s *Settings, value string) error {
+		return field(s).UnmarshalText([]byte(value))
+	}}
+}
+
+// the Postgres password deliberately has no flag, since command lines are visible to other users in ps
+var settings = []setting{
+	stringSetting("db", "BLOG_DB", "database backend: mongo, postgres or memory", func(s *Settings) *string { return &s.Database }),
+	intSetting("port", "BLOG_PORT", "port for the Twirp HTTP server", func(s *Settings) *int { return &s.Port }),
+	intSetting("grpc-port", "BLOG_GRPC_PORT", "port for the gRPC server", func(s *Settings) *int { return &s.GrpcPort }),
+	stringSetting("mongo-uri", "BLOG_MONGO_URI", "MongoDB connection URI", func(s *Settings) *string { return &s.Mongo.URI }),
+	stringSetting("mongo-database", "BLOG_MONGO_DATABASE", "MongoDB database name", func(s *Settings) *string { return &s.Mongo.Database }),
+	stringSetting("mongo-collection", "BLOG_MONGO_COLLECTION", "MongoDB collection for blogs", func(s *Settings) *string { return &s.Mongo.Collection }),
+	stringSetting("postgres-host", "BLOG_POSTGRES_HOST", "Postgres host", func(s *Settings) *string { return &s.Postgres.Host }),
+	intSetting("postgres-port", "BLOG_POSTGRES_PORT", "Postgres port", func(s *Settings) *int { return &s.Postgres.Port }),
+	stringSetting("postgres-user", "BLOG_POSTGRES_USER", "Postgres user", func(s *Settings) *string { return &s.Postgres.User }),
+	stringSetting("", "BLOG_POSTGRES_PASSWORD", "Postgres password", func(s *Settings) *string { return &s.Postgres.Password }),
+	stringSetting("postgres-dbname", "BLOG_POSTGRES_DBNAME", "Postgres database name", func(s *Settings) *string { return &s.Postgres.DBName }),
+	stringSetting("postgres-sslmode", "BLOG_POSTGRES_SSLMODE", "Postgres sslmode", func(s *Settings) *string { return &s.Postgres.SSLMode }),
+	boolSetting("postgres-auto-migrate", "BLOG_POSTGRES_AUTO_MIGRATE", "apply pending Postgres migrations on startup", func(s *Settings) *bool { return &s.Postgres.AutoMigrate }),
+	stringSetting("auth-jwks-url", "BLOG_AUTH_JWKS_URL", "URL of the JWKS used to verify bearer tokens", func(s *Settings) *string { return &s.Auth.JWKSURL }),
+	stringSetting("auth-jwks-file", "BLOG_AUTH_JWKS_FILE", "path to a local JWKS used to verify bearer tokens", func(s *Settings) *string { return &s.Auth.JWKSFile }),
+	stringSetting("auth-issuer", "BLOG_AUTH_ISSUER", "required iss claim of bearer tokens", func(s *Settings) *string { return &s.Auth.Issuer }),
+	stringSetting("auth-audience", "BLOG_AUTH_AUDIENCE", "required aud claim of bearer tokens", func(s *Settings) *string { return &s.Auth.Audience }),
+	durationSetting("trash-retention", "BLOG_TRASH_RETENTION", "how long deleted blogs can be restored before they are purged, e.g. 720h, 0 keeps them", func(s *Settings) *Duration { return &s.Trash.Retention }),
+	durationSetting("trash-purge-interval", "BLOG_TRASH_PURGE_INTERVAL", "how often deleted blogs past the retention are purged", func(s *Settings) *Duration { return &s.Trash.PurgeInterval }),
+	durationSetting("publish-scheduler-interval", "BLOG_PUBLISH_SCHEDULER_INTERVAL", "how often scheduled blogs whose publish_time has arrived are published", func(s *Settings) *Duration { return &s.Publish.SchedulerInterval }),
+	intSetting("render-cache-size", "BLOG_RENDER_CACHE_SIZE", "how many rendered blogs are cached, 0 disables the cache", func(s *Settings) *int { return &s.Render.CacheSize }),
+	stringSetting("site-url", "BLOG_SITE_URL", "URL of the public site showing the blogs, which the feeds and sitemap link to", func(s *Settings) *string { return &s.Site.URL }),
+	stringSetting("site-title", "BLOG_SITE_TITLE", "title of the public site, used as the title of the feeds", func(s *Settings) *string { return &s.Site.Title }),
+	intSetting("feed-size", "BLOG_FEED_SIZE", "how many of the most recent published blogs the feeds hold", func(s *Settings) *int { return &s.Feed.Size }),
+}
+
+// Load resolves Settings from args (without the program name), the environment and an optional config file.
+// extraFlags lets subcommands register their own flags alongside the settings flags
+func Load(args []string, getenv func(string) string, extraFlags ...func(fs *flag.FlagSet)) (Settings, error) {
+	// the database may be given positionally before any flags, as in `go run main.go postgres -port 8080`
+	positionalDB := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		positionalDB, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("blog-service", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := fs.String("config", getenv("BLOG_CONFIG"), "path to a YAML or JSON config file (env BLOG_CONFIG)")
+	flagValues := map[string]*string{}
+	for _, st := range settings {
+		if st.flag != "" {
+			flagValues[st.flag] = fs.String(st.flag, "", fmt.Sprintf("%s (env %s)", st.usage, st.env))
+		}
+	}
+	for _, register := range extraFlags {
+		register(fs)
+	}
+	if err := fs.Parse(args); err != nil {
+		return Settings{}, err
+	}
+	if fs.NArg() > 0 {
+		if positionalDB != "" || fs.NArg() > 1 {
+			return Settings{}, fmt.Errorf("unexpected arguments: %v", fs.Args())
+		}
+		positionalDB = fs.Arg(0)
+	}
+
+	s := DefaultSettings()
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, &s); err != nil {
+			return Settings{}, err
+		}
+	}
+
+	var errs []string
+	for _, st := range settings {
+		if value := getenv(st.env); value != "" {
+			if err := st.apply(&s, value); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", st.env, err))
+			}
+		}
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+	for _, st := range settings {
+		if explicit[st.flag] {
+			if err := st.apply(&s, *flagValues[st.flag]); err != nil {
+				errs = append(errs, fmt.Sprintf("-%s: %v", st.flag, err))
+			}
+		}
+	}
+	if positionalDB != "" {
+		s.Database = positionalDB
+	}
+
+	if len(errs) > 0 {
+		return Settings{}, fmt.Errorf("invalid configuration:\n  %s", strings.Join(errs, "\n  "))
+	}
+	if err := s.Validate(); err != nil {
+		return Settings{}, err
+	}
+	return s, nil
+}
+
+func loadFile(path string, s *Settings) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open config file: %w", err)
+	}
+	defer f.Close()
+
+	// unknown keys are rejected so that typos do not silently fall back to defaults
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(f)
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(s)
+	} else {
+		decoder := yaml.NewDecoder(f)
+		decoder.KnownFields(true)
+		err = decoder.Decode(s)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("unable to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once, so startup fails with the full list
+func (s Settings) Validate() error {
+	var errs []string
+
+	validPort := func(name string, port int) {
+		if port < 1 || port > 65535 {
+			errs = append(errs, fmt.Sprintf("%s must be between 1 and 65535, got %d", name, port))
+		}
+	}
+	validPort("port", s.Port)
+	validPort("grpc_port", s.GrpcPort)
+	if s.Port == s.GrpcPort {
+		errs = append(errs, fmt.Sprintf("port and grpc_port must differ, both are %d", s.Port))
+	}
+
+	switch s.Database {
+	case "mongo":
+		if s.Mongo.URI == "" {
+			errs = append(errs, "mongo.uri is required")
+		}
+		if s.Mongo.Database == "" {
+			errs = append(errs, "mongo.database is required")
+		}
+		if s.Mongo.Collection == "" {
+			errs = append(errs, "mongo.collection is required")
+		}
+	case "postgres":
+		if s.Postgres.Host == "" {
+			errs = append(errs, "postgres.host is required")
+		}
+		validPort("postgres.port", s.Postgres.Port)
+		if s.Postgres.User == "" {
+			errs = append(errs, "postgres.user is required")
+		}
+		if s.Postgres.DBName == "" {
+			errs = append(errs, "postgres.dbname is required")
+		}
+		switch s.Postgres.SSLMode {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		default:
+			errs = append(errs, fmt.Sprintf("postgres.sslmode %q is not a valid sslmode", s.Postgres.SSLMode))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Sprintf("database must be one of mongo, postgres or memory, got %q", s.Database))
+	}
+
+	if s.Trash.Retention < 0 {
+		errs = append(errs, "trash.retention must not be negative")
+	}
+	if s.Trash.Retention > 0 && s.Trash.PurgeInterval <= 0 {
+		errs = append(errs, "trash.purge_interval must be positive")
+	}
+
+	if s.Publish.SchedulerInterval <= 0 {
+		errs = append(errs, "publish.scheduler_interval must be positive")
+	}
+	if s.Render.CacheSize < 0 {
+		errs = append(errs, "render.cache_size must not be negative")
+	}
+	if site, err := url.Parse(s.Site.URL); err != nil || (site.Scheme != "http" && site.Scheme != "https") || site.Host == "" {
+		errs = append(errs, fmt.Sprintf("site.url must be an absolute http or https URL, got %q", s.Site.URL))
+	}
+	if s.Feed.Size < 1 || s.Feed.Size > maxFeedSize {
+		errs = append(errs, fmt.Sprintf("feed.size must be between 1 and %d", maxFeedSize))
+	}
+
+	if s.Auth.JWKSURL != "" && s.Auth.JWKSFile != "" {
+		errs = append(errs, "auth.jwks_url and auth.jwks_file are mutually exclusive")
+	}
+	if s.Auth.Enabled() {
+		if s.Auth.Issuer == "" {
+			errs = append(errs, "auth.issuer is required when auth is enabled")
+		}
+		if s.Auth.Audience == "" {
+			errs = append(errs, "auth.audience is required when auth is enabled")
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// Usage prints every flag with its environment variable, for -h
+func Usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: blog-service [mongo|postgres|memory] [flags]")
+	fmt.Fprintln(w, "  -config string\n    \tpath to a YAML or JSON config file (env BLOG_CONFIG)")
+	for _, st := range settings {
+		if st.flag != "" {
+			fmt.Fprintf(w, "  -%s string\n    \t%s (env %s)\n", st.flag, st.usage, st.env)
+		} else {
+			fmt.Fprintf(w, "  %s\n    \t%s (environment or config file only)\n", st.env, st.usage)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package config_test
+
+import (
+	config "blog-service/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func writeFile(t *testing.T, name string, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	settings, err := config.Load(nil, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, config.DefaultSettings(), settings)
+}
+
+func TestLoad_PositionalDatabase(t *testing.T) {
+	settings, err := config.Load([]string{"postgres"}, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, "postgres", settings.Database)
+
+	settings, err = config.Load([]string{"memory", "-port", "8080"}, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, "memory", settings.Database)
+	require.Equal(t, 8080, settings.Port)
+
+	settings, err = config.Load([]string{"-port", "8080", "memory"}, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, "memory", settings.Database)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "blog.yaml", `
+database: postgres
+port: 6000
+postgres:
+  host: file-host
+  user: file-user
+  password: file-password
+`)
+
+	// file overrides defaults
+	settings, err := config.Load([]string{"-config", path}, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, "postgres", settings.Database)
+	require.Equal(t, 6000, settings.Port)
+	require.Equal(t, "file-host", settings.Postgres.Host)
+	require.Equal(t, "file-password", settings.Postgres.Password)
+	require.Equal(t, 5432, settings.Postgres.Port)
+
+	// environment overrides file
+	settings, err = config.Load(nil, env(map[string]string{
+		"BLOG_CONFIG":            path,
+		"BLOG_PORT":              "7000",
+		"BLOG_POSTGRES_PASSWORD": "env-password",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 7000, settings.Port)
+	require.Equal(t, "env-password", settings.Postgres.Password)
+	require.Equal(t, "file-user", settings.Postgres.User)
+
+	// flags override environment
+	settings, err = config.Load([]string{"-config", path, "-port", "8000", "-postgres-host", "flag-host"}, env(map[string]string{
+		"BLOG_PORT": "7000",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 8000, settings.Port)
+	require.Equal(t, "flag-host", settings.Postgres.Host)
+}
+
+func TestLoad_JSONFile(t *testing.T) {
+	path := writeFile(t, "blog.json", `{"database": "mongo", "mongo": {"uri": "mongodb://db:27017", "database": "blogs"}}`)
+
+	settings, err := config.Load([]string{"-config", path}, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, "mongodb://db:27017", settings.Mongo.URI)
+	require.Equal(t, "blogs", settings.Mongo.Database)
+	require.Equal(t, "blog", settings.Mongo.Collection)
+}
+
+func TestLoad_Durations(t *testing.T) {
+	yamlPath := writeFile(t, "blog.yaml", "trash:\n  retention: 48h\n")
+	settings, err := config.Load([]string{"-config", yamlPath}, env(nil))
+	require.NoError(t, err)
+	require.Equal(t, config.Duration(48*time.Hour), settings.Trash.Retention)
+	require.Equal(t, config.Duration(time.Hour), settings.Trash.PurgeInterval)
+
+	jsonPath := writeFile(t, "blog.json", `{"trash": {"purge_interval": "15m"}}`)
+	settings, err = config.Load([]string{"-config", jsonPath}, env(map[string]string{"BLOG_TRASH_RETENTION": "0"}))
+	require.NoError(t, err)
+	require.Equal(t, config.Duration(0), settings.Trash.Retention)
+	require.Equal(t, config.Duration(15*time.Minute), settings.Trash.PurgeInterval)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	unknownKey := writeFile(t, "blog.yaml", "prot: 5050\n")
+
+	cases := map[string]struct {
+		args []string
+		env  map[string]string
+	}{
+		"unknown database":        {args: []string{"sqlite"}},
+		"port out of range":       {args: []string{"-port", "70000"}},
+		"same ports":              {args: []string{"-port", "6000", "-grpc-port", "6000"}},
+		"non-numeric env port":    {env: map[string]string{"BLOG_PORT": "http"}},
+		"missing postgres user":   {args: []string{"postgres", "-postgres-user", ""}},
+		"bad sslmode":             {args: []string{"postgres", "-postgres-sslmode", "sometimes"}},
+		"unknown config key":      {args: []string{"-config", unknownKey}},
+		"missing config file":     {args: []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}},
+		"unknown flag":            {args: []string{"-verbose"}},
+		"extra positional args":   {args: []string{"mongo", "postgres"}},
+		"empty mongo collection":  {args: []string{"-mongo-collection", ""}},
+		"auth without issuer":     {args: []string{"-auth-jwks-file", "jwks.json", "-auth-audience", "blog"}},
+		"auth without audience":   {env: map[string]string{"BLOG_AUTH_JWKS_URL": "https://issuer/jwks", "BLOG_AUTH_ISSUER": "https://issuer/"}},
+		"bad duration":            {args: []string{"-trash-retention", "30 days"}},
+		"negative retention":      {args: []string{"-trash-retention", "-1h"}},
+		"zero purge interval":     {args: []string{"-trash-purge-interval", "0s"}},
+		"zero scheduler interval": {args: []string{"-publish-scheduler-interval", "0s"}},
+		"negative cache size":     {args: []string{"-render-cache-size", "-1"}},
+		"relative site url":       {args: []string{"-site-url", "/blog"}},
+		"zero feed size":          {args: []string{"-feed-size", "0"}},
+		"jwks url and file":       {args: []string{"-auth-jwks-url", "https://issuer/jwks", "-auth-jwks-file", "jwks.json", "-auth-issuer", "i", "-auth-audience", "a"}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := config.Load(c.args, env(c.env))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPostgresSettings_DSN(t *testing.T) {
+	dsn := config.PostgresSettings{
+		Host:     "localhost",
+		Port:     5432,
+		User:     "root",
+		Password: `it's a \secret`,
+		DBName:   "root",
+		SSLMode:  "disable",
+	}.DSN()
+	require.Equal(t, `host='localhost' port=5432 user='root' password='it\'s a \\secret' dbname='root' sslmode='disable'`, dsn)
+}
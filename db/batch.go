@@ -0,0 +1,128 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"fmt"
+	"strconv"
+
+	"github.com/twitchtv/twirp"
+)
+
+/*
+Batch requests report the outcome of each item in its own result, the errors being those the single-item
+method would return, unless all_or_nothing is set. Then the first failure fails the whole call and nothing
+is written: the memory client checks every item before writing any, Postgres runs the batch in a single
+transaction and Mongo in a session transaction, which needs a replica set or sharded cluster.
+*/
+
+// MaxBatchSize bounds the items of a batch request, so one call cannot hold a transaction open for long
+const MaxBatchSize = 100
+
+// CheckBatchSize rejects batches of n items that are empty or larger than MaxBatchSize
+func CheckBatchSize(n int, field string) error {
+	if n == 0 {
+		return twirp.RequiredArgumentError(field)
+	}
+	if n > MaxBatchSize {
+		return twirp.InvalidArgumentError(field, fmt.Sprintf("must have at most %d items", MaxBatchSize))
+	}
+	return nil
+}
+
+// BatchError converts the error of one item of a batch to its result
+func BatchError(err error) *blogProto.BatchError {
+	twerr, ok := err.(twirp.Error)
+	if !ok {
+		twerr = twirp.InternalErrorWith(err)
+	}
+	return &blogProto.BatchError{
+		Code: string(twerr.Code()),
+		Msg:  twerr.Msg(),
+		Meta: twerr.MetaMap(),
+	}
+}
+
+// BatchFailure is returned instead of the results of an all_or_nothing batch whose item index failed with err
+func BatchFailure(index int, err error) error {
+	twerr, ok := err.(twirp.Error)
+	if !ok {
+		twerr = twirp.InternalErrorWith(err)
+	}
+	failure := twirp.NewError(twerr.Code(), fmt.Sprintf("Item %d: %v", index, twerr.Msg()))
+	for key, value := range twerr.MetaMap() {
+		failure = failure.WithMeta(key, value)
+	}
+	return failure.WithMeta("index", strconv.Itoa(index))
+}
+
+// firstFailure returns BatchFailure for the first item of errs that failed, or nil when none did
+func firstFailure(errs []error) error {
+	for i, err := range errs {
+		if err != nil {
+			return BatchFailure(i, err)
+		}
+	}
+	return nil
+}
+
+// repeatedIds fails every delete of a blog already deleted earlier in the same batch, which would otherwise
+// fail or succeed depending on the backend's order of writes. Ids are compared as the backend reads them, with
+// canonical returning the same string for every spelling of one id
+func repeatedIds(requests []*blogProto.DeleteBlogRequest, canonical func(id string) string) []error {
+	errs := make([]error, len(requests))
+	seen := map[string]bool{}
+	for i, request := range requests {
+		id := canonical(request.Id)
+		if seen[id] {
+			errs[i] = twirp.InvalidArgumentError("id", fmt.Sprintf("%v is already deleted by an earlier item", request.Id))
+		}
+		seen[id] = true
+	}
+	return errs
+}
+
+// canonicalNumericId is the canonical form of the ids of the memory and Postgres backends, e.g. "7" for "007",
+// ids that are not numbers are kept as they are and fail later
+func canonicalNumericId(id string) string {
+	parsed, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return id
+	}
+	return strconv.FormatInt(parsed, 10)
+}
+
+func createResults(blogs []*blogProto.CreateBlogResponse, errs []error) *blogProto.BatchCreateBlogsResponse {
+	results := make([]*blogProto.BatchCreateBlogResult, len(blogs))
+	for i := range blogs {
+		if errs[i] != nil {
+			results[i] = &blogProto.BatchCreateBlogResult{Error: BatchError(errs[i])}
+		} else {
+			results[i] = &blogProto.BatchCreateBlogResult{Blog: blogs[i]}
+		}
+	}
+	return &blogProto.BatchCreateBlogsResponse{Results: results}
+}
+
+func getResults(blogs []*blogProto.GetBlogResponse, errs []error) *blogProto.BatchGetBlogsResponse {
+	results := make([]*blogProto.BatchGetBlogResult, len(blogs))
+	for i := range blogs {
+		if errs[i] != nil {
+			results[i] = &blogProto.BatchGetBlogResult{Error: BatchError(errs[i])}
+		} else {
+			results[i] = &blogProto.BatchGetBlogResult{Blog: blogs[i]}
+		}
+	}
+	return &blogProto.BatchGetBlogsResponse{Results: results}
+}
+
+func deleteResults(requests []*blogProto.DeleteBlogRequest, errs []error) *blogProto.BatchDeleteBlogsResponse {
+	results := make([]*blogProto.BatchDeleteBlogResult, len(requests))
+	for i, request := range requests {
+		if errs[i] != nil {
+			results[i] = &blogProto.BatchDeleteBlogResult{Error: BatchError(errs[i])}
+		} else {
+			results[i] = &blogProto.BatchDeleteBlogResult{Blog: &blogProto.DeleteBlogResponse{Id: request.Id}}
+		}
+	}
+	return &blogProto.BatchDeleteBlogsResponse{Results: results}
+}
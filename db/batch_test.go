@@ -0,0 +1,31 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeatedIds(t *testing.T) {
+	requests := func(ids ...string) []*blogProto.DeleteBlogRequest {
+		out := []*blogProto.DeleteBlogRequest{}
+		for _, id := range ids {
+			out = append(out, &blogProto.DeleteBlogRequest{Id: id})
+		}
+		return out
+	}
+
+	errs := repeatedIds(requests("7", "07", "+7", "8", "x", "x"), canonicalNumericId)
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.Error(t, errs[2])
+	require.NoError(t, errs[3])
+	require.NoError(t, errs[4])
+	require.Error(t, errs[5])
+
+	errs = repeatedIds(requests("65ab00000000000000000001", "65AB00000000000000000001", "65ab00000000000000000002"), canonicalMongoId)
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.NoError(t, errs[2])
+}
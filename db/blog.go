@@ -0,0 +1,125 @@
+package db
+
+import (
+	"blog-service/auth"
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// blogRecord is a blog as read from any backend, converted to the response messages in one place
+// so every backend returns the same fields
+type blogRecord struct {
+	Id         string
+	Title      string
+	Content    string
+	CreateTime time.Time
+	UpdateTime time.Time
+	AuthorId   string
+	Version    int64
+	// zero unless the blog is in the trash
+	DeleteTime time.Time
+	// sorted, see normalizeTags
+	Tags     []string
+	Category string
+	Status   blogProto.BlogStatus
+	// zero on blogs that were never published or scheduled
+	PublishTime time.Time
+	// the current slug, see slug.go
+	Slug          string
+	ContentFormat blogProto.ContentFormat
+}
+
+// authorOf returns the subject of the authenticated caller, which every backend records as the author
+// of the blogs it creates, or "" when authentication is disabled
+func authorOf(ctx context.Context) string {
+	if claims, ok := auth.FromContext(ctx); ok {
+		return claims.Subject
+	}
+	return ""
+}
+
+// optionalTimestamp leaves optional timestamps unset when zero, e.g. delete_time for blogs that are not in the trash
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func (b blogRecord) createResponse() *blogProto.CreateBlogResponse {
+	return &blogProto.CreateBlogResponse{
+		Id:            b.Id,
+		Title:         b.Title,
+		Content:       b.Content,
+		CreateTime:    timestamppb.New(b.CreateTime),
+		UpdateTime:    timestamppb.New(b.UpdateTime),
+		AuthorId:      b.AuthorId,
+		Version:       b.Version,
+		DeleteTime:    optionalTimestamp(b.DeleteTime),
+		Tags:          b.Tags,
+		Category:      b.Category,
+		Status:        b.Status,
+		PublishTime:   optionalTimestamp(b.PublishTime),
+		Slug:          b.Slug,
+		ContentFormat: b.ContentFormat,
+	}
+}
+
+func (b blogRecord) getResponse() *blogProto.GetBlogResponse {
+	return &blogProto.GetBlogResponse{
+		Id:            b.Id,
+		Title:         b.Title,
+		Content:       b.Content,
+		CreateTime:    timestamppb.New(b.CreateTime),
+		UpdateTime:    timestamppb.New(b.UpdateTime),
+		AuthorId:      b.AuthorId,
+		Version:       b.Version,
+		DeleteTime:    optionalTimestamp(b.DeleteTime),
+		Tags:          b.Tags,
+		Category:      b.Category,
+		Status:        b.Status,
+		PublishTime:   optionalTimestamp(b.PublishTime),
+		Slug:          b.Slug,
+		ContentFormat: b.ContentFormat,
+	}
+}
+
+func (b blogRecord) updateResponse() *blogProto.UpdateBlogResponse {
+	return &blogProto.UpdateBlogResponse{
+		Id:            b.Id,
+		Title:         b.Title,
+		Content:       b.Content,
+		CreateTime:    timestamppb.New(b.CreateTime),
+		UpdateTime:    timestamppb.New(b.UpdateTime),
+		AuthorId:      b.AuthorId,
+		Version:       b.Version,
+		DeleteTime:    optionalTimestamp(b.DeleteTime),
+		Tags:          b.Tags,
+		Category:      b.Category,
+		Status:        b.Status,
+		PublishTime:   optionalTimestamp(b.PublishTime),
+		Slug:          b.Slug,
+		ContentFormat: b.ContentFormat,
+	}
+}
+
+func (b blogRecord) undeleteResponse() *blogProto.UndeleteBlogResponse {
+	return &blogProto.UndeleteBlogResponse{
+		Id:            b.Id,
+		Title:         b.Title,
+		Content:       b.Content,
+		CreateTime:    timestamppb.New(b.CreateTime),
+		UpdateTime:    timestamppb.New(b.UpdateTime),
+		AuthorId:      b.AuthorId,
+		Version:       b.Version,
+		Tags:          b.Tags,
+		Category:      b.Category,
+		Status:        b.Status,
+		PublishTime:   optionalTimestamp(b.PublishTime),
+		Slug:          b.Slug,
+		ContentFormat: b.ContentFormat,
+	}
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/*
+Comments belong to a blog and are threaded through parent_id. Deleting a comment keeps it as a tombstone
+without content, so replies to it stay in their thread. Deleting a blog orphan-marks its comments: they are
+hidden with the blog, restored with it by UndeleteBlog, and purged along with it once the trash retention is over.
+*/
+
+// maxCommentLength is the longest comment content accepted, in characters
+const maxCommentLength = 10000
+
+// commentRecord is a comment as read from any CommentStore, converted to the response message in one place
+type commentRecord struct {
+	Id         string
+	BlogId     string
+	ParentId   string
+	AuthorId   string
+	Content    string
+	CreateTime time.Time
+	UpdateTime time.Time
+	// zero unless the comment is deleted
+	DeleteTime time.Time
+}
+
+func (c commentRecord) response() *blogProto.Comment {
+	return &blogProto.Comment{
+		Id:         c.Id,
+		BlogId:     c.BlogId,
+		ParentId:   c.ParentId,
+		AuthorId:   c.AuthorId,
+		Content:    c.Content,
+		CreateTime: timestamppb.New(c.CreateTime),
+		UpdateTime: timestamppb.New(c.UpdateTime),
+		DeleteTime: optionalTimestamp(c.DeleteTime),
+	}
+}
+
+// validateCommentContent rejects empty and overlong comments, and returns the content trimmed
+func validateCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", twirp.RequiredArgumentError("content")
+	}
+	if len([]rune(content)) > maxCommentLength {
+		return "", twirp.InvalidArgumentError("content", fmt.Sprintf("must be at most %d characters", maxCommentLength))
+	}
+	return content, nil
+}
+
+func commentNotFound(id string) twirp.Error {
+	return twirp.NewError(twirp.NotFound, fmt.Sprintf("No comment was found for id: %v", id))
+}
+
+func parentNotFound(parentId string, blogId string) twirp.Error {
+	return twirp.InvalidArgumentError("parent_id", fmt.Sprintf("%v is not a comment on blog %v", parentId, blogId))
+}
+
+// commentQuery is a validated ListCommentsRequest, comments are always listed oldest first
+type commentQuery struct {
+	BlogId   string
+	ParentId string
+	Limit    int64
+	// After is the id of the last comment on the previous page, empty on the first page
+	After string
+}
+
+const commentOrder = "comments"
+
+func newCommentQuery(data *blogProto.ListCommentsRequest) (commentQuery, error) {
+	token, ok := decodePageToken(data.PageToken)
+	if !ok || (data.PageToken != "" && token.Order != commentOrder) {
+		return commentQuery{}, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+	}
+	return commentQuery{
+		BlogId:   data.BlogId,
+		ParentId: data.ParentId,
+		Limit:    data.Limit,
+		After:    token.After,
+	}, nil
+}
+
+// page trims comments, fetched with one extra to detect a next page, to the limit and returns the response
+func (q commentQuery) page(comments []commentRecord) *blogProto.ListCommentsResponse {
+	nextPageToken := ""
+	if q.Limit > 0 && int64(len(comments)) > q.Limit {
+		comments = comments[:q.Limit]
+		nextPageToken = encodePageToken(pageToken{Order: commentOrder, After: comments[len(comments)-1].Id})
+	}
+
+	results := []*blogProto.Comment{}
+	for _, comment := range comments {
+		results = append(results, comment.response())
+	}
+	return &blogProto.ListCommentsResponse{
+		Comments:      results,
+		NextPageToken: nextPageToken,
+	}
+}
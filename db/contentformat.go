@@ -0,0 +1,20 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"fmt"
+
+	"github.com/twitchtv/twirp"
+)
+
+// contentFormat returns the format to store for one a request asks for, content is plain text unless set
+func contentFormat(format blogProto.ContentFormat) (blogProto.ContentFormat, error) {
+	switch format {
+	case blogProto.ContentFormat_CONTENT_FORMAT_UNSPECIFIED:
+		return blogProto.ContentFormat_CONTENT_FORMAT_PLAIN, nil
+	case blogProto.ContentFormat_CONTENT_FORMAT_PLAIN, blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN, blogProto.ContentFormat_CONTENT_FORMAT_HTML:
+		return format, nil
+	default:
+		return 0, twirp.InvalidArgumentError("content_format", fmt.Sprintf("unknown format %v", format))
+	}
+}
@@ -0,0 +1,33 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/twitchtv/twirp"
+)
+
+// newError builds a twirp error with the given code, unless the request context has already ended,
+// in which case the database error was caused by the cancellation or timeout and is reported as such
+func newError(ctx context.Context, code twirp.ErrorCode, msg string) twirp.Error {
+	switch ctx.Err() {
+	case context.Canceled:
+		return twirp.NewError(twirp.Canceled, msg)
+	case context.DeadlineExceeded:
+		return twirp.NewError(twirp.DeadlineExceeded, msg)
+	}
+	return twirp.NewError(code, msg)
+}
+
+// versionConflict is returned when an update or delete expected a different version than the blog is at.
+// The current version is in the "version" meta, so a client can refetch and retry
+func versionConflict(id string, expected int64, current int64) twirp.Error {
+	return twirp.NewError(twirp.Aborted, fmt.Sprintf("Blog id: %v is at version %d, not %d", id, current, expected)).
+		WithMeta("version", strconv.FormatInt(current, 10))
+}
+
+// notDeleted is returned when restoring a blog that is not in the trash
+func notDeleted(id string) twirp.Error {
+	return twirp.NewError(twirp.FailedPrecondition, fmt.Sprintf("Blog id: %v is not deleted", id))
+}
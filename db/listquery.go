@@ -0,0 +1,152 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// listQuery is a validated ListBlogRequest, shared by every backend so they accept the same requests
+type listQuery struct {
+	Limit int64
+	Order listOrder
+	// Token is the zero pageToken on the first page
+	Token pageToken
+
+	TitlePrefix   string
+	TitleContains string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	AuthorId      string
+	// Tags are normalized, a blog must have all of them when AllTags is set and any of them otherwise
+	Tags     []string
+	AllTags  bool
+	Category string
+	// Status is BLOG_STATUS_UNSPECIFIED to list blogs of any status
+	Status blogProto.BlogStatus
+
+	// Deleted lists the blogs in the trash instead of the others
+	Deleted bool
+}
+
+type listOrder struct {
+	// Field is "create_time", "publish_time" or "title", every backend breaks ties on the id. Blogs that were
+	// never published have no publish_time and sort before the others, as nulls do in Mongo
+	Field string
+	Desc  bool
+}
+
+func (o listOrder) String() string {
+	if o.Desc {
+		return o.Field + " desc"
+	}
+	return o.Field + " asc"
+}
+
+// ByCreateTime is true when blogs are sorted by id alone, since ids grow with creation time
+func (o listOrder) ByCreateTime() bool {
+	return o.Field == "create_time"
+}
+
+func parseOrderBy(orderBy string) (listOrder, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	order := listOrder{Field: "create_time"}
+	if len(parts) == 0 {
+		return order, nil
+	}
+	if len(parts) > 2 {
+		return order, fmt.Errorf("order_by must be a field optionally followed by asc or desc, got %q", orderBy)
+	}
+
+	switch parts[0] {
+	case "create_time", "publish_time", "title":
+		order.Field = parts[0]
+	default:
+		return order, fmt.Errorf("cannot order by %q, use create_time, publish_time or title", parts[0])
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			order.Desc = true
+		default:
+			return order, fmt.Errorf("order_by direction must be asc or desc, got %q", parts[1])
+		}
+	}
+	return order, nil
+}
+
+// newListQuery validates a ListBlogRequest, or a ListDeletedBlogsRequest converted by newDeletedListQuery when deleted is set
+func newListQuery(data *blogProto.ListBlogRequest, deleted bool) (listQuery, error) {
+	order, err := parseOrderBy(data.OrderBy)
+	if err != nil {
+		return listQuery{}, twirp.NewError(twirp.InvalidArgument, err.Error())
+	}
+
+	token, ok := decodePageToken(data.PageToken)
+	if !ok || (data.PageToken != "" && (token.Order != order.String() || token.Deleted != deleted)) {
+		return listQuery{}, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+	}
+
+	query := listQuery{
+		Limit:   data.Limit,
+		Order:   order,
+		Token:   token,
+		Deleted: deleted,
+	}
+
+	filter := data.GetFilter()
+	query.TitlePrefix = filter.GetTitlePrefix()
+	query.TitleContains = filter.GetTitleContains()
+	query.AuthorId = filter.GetAuthorId()
+	query.Tags, err = normalizeTags(filter.GetTags())
+	if err != nil {
+		return listQuery{}, err
+	}
+	query.AllTags = filter.GetTagMatch() == blogProto.TagMatch_TAG_MATCH_ALL
+	query.Category = strings.TrimSpace(filter.GetCategory())
+	query.Status = filter.GetStatus()
+	if after := filter.GetCreateTimeAfter(); after != nil {
+		if err := after.CheckValid(); err != nil {
+			return listQuery{}, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Invalid create_time_after: %v", err))
+		}
+		t := after.AsTime()
+		query.CreatedAfter = &t
+	}
+	if before := filter.GetCreateTimeBefore(); before != nil {
+		if err := before.CheckValid(); err != nil {
+			return listQuery{}, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Invalid create_time_before: %v", err))
+		}
+		t := before.AsTime()
+		query.CreatedBefore = &t
+	}
+
+	return query, nil
+}
+
+// nextPageToken returns the token continuing after blog, the last one on the current page
+func (q listQuery) nextPageToken(blog *blogProto.CreateBlogResponse) string {
+	token := pageToken{Order: q.Order.String(), After: blog.Id, Deleted: q.Deleted}
+	switch q.Order.Field {
+	case "title":
+		token.Title = blog.Title
+	case "publish_time":
+		if blog.PublishTime != nil {
+			published := blog.PublishTime.AsTime()
+			token.Published = &published
+		}
+	}
+	return encodePageToken(token)
+}
+
+func newDeletedListQuery(data *blogProto.ListDeletedBlogsRequest) (listQuery, error) {
+	return newListQuery(&blogProto.ListBlogRequest{
+		Limit:     data.Limit,
+		PageToken: data.PageToken,
+		Filter:    data.Filter,
+		OrderBy:   data.OrderBy,
+	}, true)
+}
@@ -0,0 +1,773 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+/*
+MemoryClient keeps blogs in a map guarded by a mutex, so the service can run
+without Docker and tests can run hermetically. Data is lost when the process exits.
+*/
+
+type MemoryClient struct {
+	mu     sync.RWMutex
+	nextId int
+	blogs  map[int]*memoryBlog
+	// revisions of each blog, oldest first
+	revisions map[int][]revisionRecord
+	// every slug a blog has had, to the id of that blog
+	slugs map[string]int
+}
+
+type memoryBlog struct {
+	Id            int
+	Title         string
+	Content       string
+	CreateTime    time.Time
+	UpdateTime    time.Time
+	AuthorId      string
+	Version       int64
+	DeleteTime    time.Time
+	Tags          []string
+	Category      string
+	Status        blogProto.BlogStatus
+	PublishTime   time.Time
+	Slug          string
+	ContentFormat blogProto.ContentFormat
+}
+
+func (b *memoryBlog) record() blogRecord {
+	return blogRecord{
+		Id:            strconv.Itoa(b.Id),
+		Title:         b.Title,
+		Content:       b.Content,
+		CreateTime:    b.CreateTime,
+		UpdateTime:    b.UpdateTime,
+		AuthorId:      b.AuthorId,
+		Version:       b.Version,
+		DeleteTime:    b.DeleteTime,
+		Tags:          b.Tags,
+		Category:      b.Category,
+		Status:        b.Status,
+		PublishTime:   b.PublishTime,
+		Slug:          b.Slug,
+		ContentFormat: b.ContentFormat,
+	}
+}
+
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		nextId:    1,
+		blogs:     map[int]*memoryBlog{},
+		revisions: map[int][]revisionRecord{},
+		slugs:     map[string]int{},
+	}
+}
+
+func (m *MemoryClient) Connect() error {
+	fmt.Println("Using in-memory store, data will not be persisted")
+	return nil
+}
+
+func (m *MemoryClient) CreateBlog(ctx context.Context, data *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
+	blog, err := newMemoryBlog(ctx, data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.insert(blog)
+	return blog.record().createResponse(), nil
+}
+
+// newMemoryBlog checks data and builds the blog CreateBlog inserts, without the id and slug insert assigns
+func newMemoryBlog(ctx context.Context, data *blogProto.CreateBlogRequest, now time.Time) (*memoryBlog, error) {
+	labels, err := newBlogLabels(data.Tags, data.Category)
+	if err != nil {
+		return nil, err
+	}
+	status, publishTime, err := createStatus(data.Status, now)
+	if err != nil {
+		return nil, err
+	}
+	format, err := contentFormat(data.ContentFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memoryBlog{
+		Title:         data.Title,
+		Content:       data.Content,
+		CreateTime:    now,
+		UpdateTime:    now,
+		AuthorId:      authorOf(ctx),
+		Version:       1,
+		Tags:          labels.Tags,
+		Category:      labels.Category,
+		Status:        status,
+		PublishTime:   publishTime,
+		ContentFormat: format,
+	}, nil
+}
+
+// insert adds blog with the next id and a slug generated from its title, m.mu must be held
+func (m *MemoryClient) insert(blog *memoryBlog) {
+	blog.Id = m.nextId
+	blog.Slug, _ = m.claimSlug(blog.Id, "", blog.Title)
+	m.blogs[blog.Id] = blog
+	m.nextId++
+}
+
+func (m *MemoryClient) GetBlog(ctx context.Context, data *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.get(data.Id, data.ShowDeleted)
+}
+
+// get returns blog id, m.mu must be held
+func (m *MemoryClient) get(idString string, showDeleted bool) (*blogProto.GetBlogResponse, error) {
+	id, err := strconv.Atoi(idString)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	blog, ok := m.blogs[id]
+	if !ok || (!blog.DeleteTime.IsZero() && !showDeleted) {
+		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", idString))
+	}
+
+	return blog.record().getResponse(), nil
+}
+
+func (m *MemoryClient) GetBlogBySlug(ctx context.Context, data *blogProto.GetBlogBySlugRequest) (*blogProto.GetBlogResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	blog, ok := m.blogs[m.slugs[data.Slug]]
+	if !ok || !blog.DeleteTime.IsZero() {
+		return nil, slugNotFound(data.Slug)
+	}
+
+	return blog.record().getResponse(), nil
+}
+
+// claimSlug reserves a slug for blog id and returns it: requested, unless another blog has it, or when requested
+// is empty the first free variant of title. Slugs the blog already had are free to it
+func (m *MemoryClient) claimSlug(id int, requested string, title string) (string, error) {
+	if requested != "" {
+		if owner, ok := m.slugs[requested]; ok && owner != id {
+			return "", slugTaken(requested)
+		}
+		m.slugs[requested] = id
+		return requested, nil
+	}
+
+	base := slugify(title)
+	taken := map[string]bool{}
+	for slug, owner := range m.slugs {
+		if owner != id && isSlugVariant(slug, base) {
+			taken[slug] = true
+		}
+	}
+	slug := uniqueSlug(base, taken)
+	m.slugs[slug] = id
+	return slug, nil
+}
+
+func (m *MemoryClient) UpdateBlog(ctx context.Context, data *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	id, err := strconv.Atoi(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+	fields, err := parseUpdateMask(data)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := newBlogLabels(data.Tags, data.Category)
+	if err != nil {
+		return nil, err
+	}
+	requestedSlug, err := normalizeSlug(data.Slug)
+	if err != nil {
+		return nil, err
+	}
+	format, err := contentFormat(data.ContentFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blog, ok := m.blogs[id]
+	if !ok || !blog.DeleteTime.IsZero() {
+		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, no matching rows", data.Id, data))
+	}
+	if data.Version != 0 && data.Version != blog.Version {
+		return nil, versionConflict(data.Id, data.Version, blog.Version)
+	}
+	if fields.Slug {
+		title := blog.Title
+		if fields.Title {
+			title = data.Title
+		}
+		slug, err := m.claimSlug(id, requestedSlug, title)
+		if err != nil {
+			return nil, err
+		}
+		blog.Slug = slug
+	}
+	now := time.Now()
+	m.revisions[id] = append(m.revisions[id], newRevision(ctx, blog.record(), now))
+	if fields.Title {
+		blog.Title = data.Title
+	}
+	if fields.Content {
+		blog.Content = data.Content
+	}
+	if fields.Tags {
+		blog.Tags = labels.Tags
+	}
+	if fields.Category {
+		blog.Category = labels.Category
+	}
+	if fields.ContentFormat {
+		blog.ContentFormat = format
+	}
+	blog.UpdateTime = now
+	blog.Version++
+
+	return blog.record().updateResponse(), nil
+}
+
+func (m *MemoryClient) DeleteBlog(ctx context.Context, data *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blog, err := m.deletable(data)
+	if err != nil {
+		return nil, err
+	}
+	blog.DeleteTime = time.Now()
+	blog.Version++
+
+	return &blogProto.DeleteBlogResponse{
+		Id: data.Id,
+	}, nil
+}
+
+// deletable returns the blog data deletes, or why it cannot be deleted, m.mu must be held
+func (m *MemoryClient) deletable(data *blogProto.DeleteBlogRequest) (*memoryBlog, error) {
+	id, err := strconv.Atoi(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	blog, ok := m.blogs[id]
+	if !ok || !blog.DeleteTime.IsZero() {
+		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v, no matching rows", data.Id))
+	}
+	if data.Version != 0 && data.Version != blog.Version {
+		return nil, versionConflict(data.Id, data.Version, blog.Version)
+	}
+	return blog, nil
+}
+
+func (m *MemoryClient) UndeleteBlog(ctx context.Context, data *blogProto.UndeleteBlogRequest) (*blogProto.UndeleteBlogResponse, error) {
+	id, err := strconv.Atoi(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blog, ok := m.blogs[id]
+	if !ok {
+		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", data.Id))
+	}
+	if blog.DeleteTime.IsZero() {
+		return nil, notDeleted(data.Id)
+	}
+	if data.Version != 0 && data.Version != blog.Version {
+		return nil, versionConflict(data.Id, data.Version, blog.Version)
+	}
+	blog.DeleteTime = time.Time{}
+	blog.Version++
+
+	return blog.record().undeleteResponse(), nil
+}
+
+func (m *MemoryClient) BatchCreateBlogs(ctx context.Context, data *blogProto.BatchCreateBlogsRequest) (*blogProto.BatchCreateBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Requests), "requests"); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	blogs := make([]*memoryBlog, len(data.Requests))
+	errs := make([]error, len(data.Requests))
+	for i, request := range data.Requests {
+		blogs[i], errs[i] = newMemoryBlog(ctx, request, now)
+	}
+	// every check happens before the first insert, so failing here writes nothing
+	if data.AllOrNothing {
+		if err := firstFailure(errs); err != nil {
+			return nil, err
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	created := make([]*blogProto.CreateBlogResponse, len(blogs))
+	for i, blog := range blogs {
+		if errs[i] == nil {
+			m.insert(blog)
+			created[i] = blog.record().createResponse()
+		}
+	}
+	return createResults(created, errs), nil
+}
+
+func (m *MemoryClient) BatchGetBlogs(ctx context.Context, data *blogProto.BatchGetBlogsRequest) (*blogProto.BatchGetBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Ids), "ids"); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	blogs := make([]*blogProto.GetBlogResponse, len(data.Ids))
+	errs := make([]error, len(data.Ids))
+	for i, id := range data.Ids {
+		blogs[i], errs[i] = m.get(id, data.ShowDeleted)
+	}
+	if data.AllOrNothing {
+		if err := firstFailure(errs); err != nil {
+			return nil, err
+		}
+	}
+	return getResults(blogs, errs), nil
+}
+
+func (m *MemoryClient) BatchDeleteBlogs(ctx context.Context, data *blogProto.BatchDeleteBlogsRequest) (*blogProto.BatchDeleteBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Requests), "requests"); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blogs := make([]*memoryBlog, len(data.Requests))
+	errs := repeatedIds(data.Requests, canonicalNumericId)
+	for i, request := range data.Requests {
+		if errs[i] == nil {
+			blogs[i], errs[i] = m.deletable(request)
+		}
+	}
+	if data.AllOrNothing {
+		if err := firstFailure(errs); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	for i, blog := range blogs {
+		if errs[i] == nil {
+			blog.DeleteTime = now
+			blog.Version++
+		}
+	}
+	return deleteResults(data.Requests, errs), nil
+}
+
+func (m *MemoryClient) PublishBlog(ctx context.Context, data *blogProto.PublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	status, publishTime, err := publishStatus(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return m.setStatus(data.Id, data.Version, status, publishTime)
+}
+
+func (m *MemoryClient) UnpublishBlog(ctx context.Context, data *blogProto.UnpublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	return m.setStatus(data.Id, data.Version, unpublishStatus(data), time.Time{})
+}
+
+// setStatus changes the status of blog id, and its publish_time unless publishTime is zero
+func (m *MemoryClient) setStatus(id string, version int64, status blogProto.BlogStatus, publishTime time.Time) (*blogProto.UpdateBlogResponse, error) {
+	key, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	blog, ok := m.blogs[key]
+	if !ok || !blog.DeleteTime.IsZero() {
+		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be changed to %v, no matching rows", id, status))
+	}
+	if version != 0 && version != blog.Version {
+		return nil, versionConflict(id, version, blog.Version)
+	}
+	blog.Status = status
+	if !publishTime.IsZero() {
+		blog.PublishTime = publishTime
+	}
+	blog.Version++
+
+	return blog.record().updateResponse(), nil
+}
+
+func (m *MemoryClient) PublishScheduledBlogs(ctx context.Context, now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	published := int64(0)
+	for _, blog := range m.blogs {
+		// blogs in the trash keep their schedule, and are published by the first run after they are restored
+		if blog.Status == blogProto.BlogStatus_BLOG_STATUS_SCHEDULED && !blog.PublishTime.After(now) && blog.DeleteTime.IsZero() {
+			blog.Status = blogProto.BlogStatus_BLOG_STATUS_PUBLISHED
+			blog.Version++
+			published++
+		}
+	}
+	return published, nil
+}
+
+func (m *MemoryClient) CountPublishedBlogs(ctx context.Context) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	count := int64(0)
+	for _, blog := range m.blogs {
+		if blog.Status == blogProto.BlogStatus_BLOG_STATUS_PUBLISHED && blog.DeleteTime.IsZero() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MemoryClient) WalkPublishedBlogs(ctx context.Context, from string, to string, limit int64, fn SitemapFunc) error {
+	start, end := 0, math.MaxInt
+	var err error
+	if from != "" {
+		if start, err = strconv.Atoi(from); err != nil {
+			return invalidSitemapBound("from", from)
+		}
+	}
+	if to != "" {
+		if end, err = strconv.Atoi(to); err != nil {
+			return invalidSitemapBound("to", to)
+		}
+	}
+
+	type sitemapBlog struct {
+		id           int
+		slug         string
+		lastModified time.Time
+	}
+	// only what the sitemap shows is copied, so fn, which may be slow, runs without holding the lock
+	m.mu.RLock()
+	blogs := []sitemapBlog{}
+	for _, blog := range m.blogs {
+		if blog.Status == blogProto.BlogStatus_BLOG_STATUS_PUBLISHED && blog.DeleteTime.IsZero() && blog.Id >= start && blog.Id < end {
+			blogs = append(blogs, sitemapBlog{id: blog.Id, slug: blog.Slug, lastModified: changedAt(blog.UpdateTime, blog.PublishTime)})
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(blogs, func(i, j int) bool { return blogs[i].id < blogs[j].id })
+	if limit > 0 && int64(len(blogs)) > limit {
+		blogs = blogs[:limit]
+	}
+	for _, blog := range blogs {
+		if err := fn(strconv.Itoa(blog.id), blog.slug, blog.lastModified); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MemoryClient) PurgeDeletedBlogs(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	purged := int64(0)
+	for id, blog := range m.blogs {
+		if !blog.DeleteTime.IsZero() && blog.DeleteTime.Before(deletedBefore) {
+			delete(m.blogs, id)
+			delete(m.revisions, id)
+			purged++
+		}
+	}
+	for slug, id := range m.slugs {
+		if _, ok := m.blogs[id]; !ok {
+			delete(m.slugs, slug)
+		}
+	}
+	return purged, nil
+}
+
+func (m *MemoryClient) ListBlogRevisions(ctx context.Context, data *blogProto.ListBlogRevisionsRequest) (*blogProto.ListBlogRevisionsResponse, error) {
+	id, err := strconv.Atoi(data.BlogId)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+	query, err := newRevisionQuery(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	revisions := []revisionRecord{}
+	stored := m.revisions[id]
+	for i := len(stored) - 1; i >= 0; i-- {
+		if query.Before != 0 && stored[i].Version >= query.Before {
+			continue
+		}
+		revisions = append(revisions, stored[i])
+	}
+	return query.page(revisions), nil
+}
+
+func (m *MemoryClient) GetBlogRevision(ctx context.Context, data *blogProto.GetBlogRevisionRequest) (*blogProto.BlogRevision, error) {
+	id, err := strconv.Atoi(data.BlogId)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, revision := range m.revisions[id] {
+		if revision.Version == data.Version {
+			return revision.response(), nil
+		}
+	}
+	return nil, revisionNotFound(data.BlogId, data.Version)
+}
+
+func (m *MemoryClient) ListBlog(ctx context.Context, data *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
+	query, err := newListQuery(data, false)
+	if err != nil {
+		return nil, err
+	}
+	blogs, nextPageToken, err := m.list(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blogProto.ListBlogResponse{
+		Blogs:         blogs,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (m *MemoryClient) ListDeletedBlogs(ctx context.Context, data *blogProto.ListDeletedBlogsRequest) (*blogProto.ListDeletedBlogsResponse, error) {
+	query, err := newDeletedListQuery(data)
+	if err != nil {
+		return nil, err
+	}
+	blogs, nextPageToken, err := m.list(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blogProto.ListDeletedBlogsResponse{
+		Blogs:         blogs,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// list returns one page of the blogs matching query and the token of the next page
+func (m *MemoryClient) list(query listQuery) ([]*blogProto.CreateBlogResponse, string, error) {
+	after := 0
+	if query.Token.After != "" {
+		var err error
+		after, err = strconv.Atoi(query.Token.After)
+		if err != nil {
+			return nil, "", twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// less reports whether a sorts before b, breaking ties on the id like the other backends
+	less := func(a, b *memoryBlog) bool {
+		if query.Order.Desc {
+			a, b = b, a
+		}
+		switch {
+		case query.Order.Field == "title" && a.Title != b.Title:
+			return a.Title < b.Title
+		case query.Order.Field == "publish_time" && !a.PublishTime.Equal(b.PublishTime):
+			// the zero time of blogs never published sorts first
+			return a.PublishTime.Before(b.PublishTime)
+		}
+		return a.Id < b.Id
+	}
+	last := &memoryBlog{Id: after, Title: query.Token.Title, PublishTime: query.Token.publishTime()}
+
+	matches := []*memoryBlog{}
+	for _, blog := range m.blogs {
+		if query.Deleted == blog.DeleteTime.IsZero() {
+			continue
+		}
+		if query.Token.After != "" && !less(last, blog) {
+			continue
+		}
+		if !strings.HasPrefix(blog.Title, query.TitlePrefix) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(blog.Title), strings.ToLower(query.TitleContains)) {
+			continue
+		}
+		if query.CreatedAfter != nil && blog.CreateTime.Before(*query.CreatedAfter) {
+			continue
+		}
+		if query.CreatedBefore != nil && !blog.CreateTime.Before(*query.CreatedBefore) {
+			continue
+		}
+		if query.AuthorId != "" && blog.AuthorId != query.AuthorId {
+			continue
+		}
+		if query.Category != "" && blog.Category != query.Category {
+			continue
+		}
+		if query.Status != blogProto.BlogStatus_BLOG_STATUS_UNSPECIFIED && blog.Status != query.Status {
+			continue
+		}
+		if len(query.Tags) > 0 && !hasTags(blog.Tags, query.Tags, query.AllTags) {
+			continue
+		}
+		matches = append(matches, blog)
+	}
+	sort.Slice(matches, func(i, j int) bool { return less(matches[i], matches[j]) })
+
+	blogs := []*blogProto.CreateBlogResponse{}
+
+	for _, blog := range matches {
+		blogs = append(blogs, blog.record().createResponse())
+	}
+
+	nextPageToken := ""
+	if query.Limit > 0 && int64(len(blogs)) > query.Limit {
+		blogs = blogs[:query.Limit]
+		nextPageToken = query.nextPageToken(blogs[len(blogs)-1])
+	}
+
+	return blogs, nextPageToken, nil
+}
+
+// hasTags reports whether tags include all of wanted, or any of them unless all is set
+func hasTags(tags []string, wanted []string, all bool) bool {
+	found := 0
+	for _, tag := range wanted {
+		for _, have := range tags {
+			if have == tag {
+				found++
+				break
+			}
+		}
+	}
+	if all {
+		return found == len(wanted)
+	}
+	return found > 0
+}
+
+func (m *MemoryClient) ListTags(ctx context.Context, data *blogProto.ListTagsRequest) (*blogProto.ListTagsResponse, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := map[string]int64{}
+	for _, blog := range m.blogs {
+		if !blog.DeleteTime.IsZero() || blog.Status != blogProto.BlogStatus_BLOG_STATUS_PUBLISHED {
+			continue
+		}
+		for _, tag := range blog.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := []*blogProto.TagCount{}
+	for tag, count := range counts {
+		tags = append(tags, &blogProto.TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	if data.Limit > 0 && int64(len(tags)) > data.Limit {
+		tags = tags[:data.Limit]
+	}
+
+	return &blogProto.ListTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+func (m *MemoryClient) SearchBlogs(ctx context.Context, data *blogProto.SearchBlogsRequest) (*blogProto.SearchBlogsResponse, error) {
+	terms := searchTerms(data.Query)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	type match struct {
+		blog  *memoryBlog
+		score float64
+	}
+	matches := []match{}
+	for _, blog := range m.blogs {
+		if !blog.DeleteTime.IsZero() || blog.Status != blogProto.BlogStatus_BLOG_STATUS_PUBLISHED {
+			continue
+		}
+		// title matches weigh double, like the weights of the Mongo text index and the Postgres tsvector
+		score := float64(2*countMatches(blog.Title, terms) + countMatches(blog.Content, terms))
+		if score > 0 {
+			matches = append(matches, match{blog, score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].blog.Id < matches[j].blog.Id
+	})
+	if data.Limit > 0 && int64(len(matches)) > data.Limit {
+		matches = matches[:data.Limit]
+	}
+
+	results := []*blogProto.SearchBlogResult{}
+	for _, match := range matches {
+		results = append(results, &blogProto.SearchBlogResult{
+			Id:      strconv.Itoa(match.blog.Id),
+			Title:   match.blog.Title,
+			Snippet: buildSnippet(match.blog.Content, terms),
+			Score:   match.score,
+			Slug:    match.blog.Slug,
+		})
+	}
+
+	return &blogProto.SearchBlogsResponse{
+		Results: results,
+	}, nil
+}
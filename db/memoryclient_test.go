@@ -0,0 +1,860 @@
+package db_test
+
+import (
+	"blog-service/auth"
+	"blog-service/db"
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func requireTwirpCode(t *testing.T, err error, code twirp.ErrorCode) {
+	t.Helper()
+	require.Error(t, err)
+	twerr, ok := err.(twirp.Error)
+	require.True(t, ok, "expected a twirp.Error, got %T", err)
+	require.Equal(t, code, twerr.Code())
+}
+
+func TestMemoryClient_CRUD(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+	require.NoError(t, m.Connect())
+
+	created, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Title", Content: "Content"})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Id)
+
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "Title", got.Title)
+	require.Equal(t, "Content", got.Content)
+
+	updated, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "New title", Content: "New content"})
+	require.NoError(t, err)
+	require.Equal(t, "New title", updated.Title)
+
+	got, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "New content", got.Content)
+
+	deleted, err := m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, created.Id, deleted.Id)
+
+	_, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+}
+
+func TestMemoryClient_MissingIds(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	_, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: "not-a-number"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	_, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: "42"})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	_, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: "42", Title: "t"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: "42"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+}
+
+func TestMemoryClient_ListBlogLimit(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c"})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	res, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, res.Blogs, 3)
+	require.Equal(t, []string{"1", "2", "3"}, []string{res.Blogs[0].Id, res.Blogs[1].Id, res.Blogs[2].Id})
+
+	res, err = m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 25})
+	require.NoError(t, err)
+	require.Len(t, res.Blogs, 10)
+}
+
+func TestMemoryClient_ListBlogPagination(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c"})
+		require.NoError(t, err)
+	}
+
+	seen := []string{}
+	pageToken := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 10, "pagination did not terminate")
+		res, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 2, PageToken: pageToken})
+		require.NoError(t, err)
+		for _, blog := range res.Blogs {
+			seen = append(seen, blog.Id)
+		}
+
+		// blogs inserted while paging show up on a later page, without repeating earlier ones
+		if pages == 0 {
+			_, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "late", Content: "c"})
+			require.NoError(t, err)
+		}
+
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	require.Equal(t, []string{"1", "2", "3", "4", "5", "6"}, seen)
+
+	_, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 2, PageToken: "not a token"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+}
+
+func TestMemoryClient_ListBlogFilterAndOrder(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	for _, title := range []string{"Go tips", "Rust tips", "Go generics", "go modules", "Postgres"} {
+		_, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: title, Content: "c"})
+		require.NoError(t, err)
+	}
+
+	titles := func(res *blogProto.ListBlogResponse) []string {
+		out := []string{}
+		for _, blog := range res.Blogs {
+			out = append(out, blog.Title)
+		}
+		return out
+	}
+
+	res, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{TitlePrefix: "Go"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go tips", "Go generics"}, titles(res))
+
+	res, err = m.ListBlog(ctx, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{TitleContains: "TIPS"}, OrderBy: "create_time desc"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Rust tips", "Go tips"}, titles(res))
+
+	res, err = m.ListBlog(ctx, &blogProto.ListBlogRequest{OrderBy: "title"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go generics", "Go tips", "Postgres", "Rust tips", "go modules"}, titles(res))
+
+	future := timestamppb.New(time.Now().Add(time.Hour))
+	res, err = m.ListBlog(ctx, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{CreateTimeAfter: future}})
+	require.NoError(t, err)
+	require.Empty(t, res.Blogs)
+
+	res, err = m.ListBlog(ctx, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{CreateTimeBefore: future}})
+	require.NoError(t, err)
+	require.Len(t, res.Blogs, 5)
+
+	// paging through a title order visits every blog once, in order
+	seen := []string{}
+	pageToken := ""
+	for {
+		res, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 2, OrderBy: "title desc", PageToken: pageToken})
+		require.NoError(t, err)
+		seen = append(seen, titles(res)...)
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	require.Equal(t, []string{"go modules", "Rust tips", "Postgres", "Go tips", "Go generics"}, seen)
+
+	// blogs never published come first by publish_time, the others in the order they were published
+	listed, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{OrderBy: "create_time"})
+	require.NoError(t, err)
+	for _, i := range []int{3, 0} {
+		_, err := m.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: listed.Blogs[i].Id, Version: listed.Blogs[i].Version})
+		require.NoError(t, err)
+	}
+	seen = []string{}
+	pageToken = ""
+	for {
+		res, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 2, OrderBy: "publish_time desc", PageToken: pageToken})
+		require.NoError(t, err)
+		seen = append(seen, titles(res)...)
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	require.Equal(t, []string{"Go tips", "go modules", "Postgres", "Go generics", "Rust tips"}, seen)
+
+	first, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Limit: 2, OrderBy: "title"})
+	require.NoError(t, err)
+
+	for name, req := range map[string]*blogProto.ListBlogRequest{
+		"unknown field":         {OrderBy: "content"},
+		"unknown direction":     {OrderBy: "title up"},
+		"token for other order": {Limit: 2, OrderBy: "create_time", PageToken: first.NextPageToken},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := m.ListBlog(ctx, req)
+			requireTwirpCode(t, err, twirp.InvalidArgument)
+		})
+	}
+}
+
+func TestMemoryClient_SearchBlogs(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	for _, blog := range []*blogProto.CreateBlogRequest{
+		{Title: "Cooking pasta", Content: "Boil water and add salt.", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED},
+		{Title: "Running", Content: "Go running every morning, pasta helps.", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED},
+		{Title: "Pasta sauces", Content: "Tomato, pesto and more pasta.", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED},
+	} {
+		_, err := m.CreateBlog(ctx, blog)
+		require.NoError(t, err)
+	}
+
+	res, err := m.SearchBlogs(ctx, &blogProto.SearchBlogsRequest{Query: "pasta"})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 3)
+	// title matches outrank content matches
+	require.Equal(t, "Pasta sauces", res.Results[0].Title)
+	require.Equal(t, "Running", res.Results[2].Title)
+	require.Contains(t, res.Results[2].Snippet, "<mark>pasta</mark>")
+
+	res, err = m.SearchBlogs(ctx, &blogProto.SearchBlogsRequest{Query: "run", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Results, 1)
+	require.Equal(t, "Running", res.Results[0].Title)
+
+	res, err = m.SearchBlogs(ctx, &blogProto.SearchBlogsRequest{Query: "sushi"})
+	require.NoError(t, err)
+	require.Empty(t, res.Results)
+}
+
+func TestMemoryClient_Timestamps(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	created, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c"})
+	require.NoError(t, err)
+	require.NotNil(t, created.CreateTime)
+	require.Equal(t, created.CreateTime.AsTime(), created.UpdateTime.AsTime())
+
+	time.Sleep(time.Millisecond)
+	updated, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "t2", Content: "c2"})
+	require.NoError(t, err)
+	require.Equal(t, created.CreateTime.AsTime(), updated.CreateTime.AsTime())
+	require.True(t, updated.UpdateTime.AsTime().After(created.UpdateTime.AsTime()))
+
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, updated.UpdateTime.AsTime(), got.UpdateTime.AsTime())
+
+	list, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{})
+	require.NoError(t, err)
+	require.Equal(t, updated.UpdateTime.AsTime(), list.Blogs[0].UpdateTime.AsTime())
+}
+
+func TestMemoryClient_Authors(t *testing.T) {
+	m := db.NewMemoryClient()
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "alice"})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "bob"})
+
+	created, err := m.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "a", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, "alice", created.AuthorId)
+	_, err = m.CreateBlog(bob, &blogProto.CreateBlogRequest{Title: "b", Content: "c"})
+	require.NoError(t, err)
+	// without authentication there is no author
+	anonymous, err := m.CreateBlog(context.Background(), &blogProto.CreateBlogRequest{Title: "c", Content: "c"})
+	require.NoError(t, err)
+	require.Empty(t, anonymous.AuthorId)
+
+	// updates keep the original author, whoever makes them
+	updated, err := m.UpdateBlog(bob, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "a2", Content: "c2"})
+	require.NoError(t, err)
+	require.Equal(t, "alice", updated.AuthorId)
+
+	list, err := m.ListBlog(context.Background(), &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{AuthorId: "alice"}})
+	require.NoError(t, err)
+	require.Len(t, list.Blogs, 1)
+	require.Equal(t, created.Id, list.Blogs[0].Id)
+}
+
+func TestMemoryClient_Versions(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	created, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), created.Version)
+
+	updated, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "t2", Content: "c2", Version: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Version)
+
+	// a second editor still holding version 1 must not overwrite the first one's change
+	_, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "t3", Content: "c3", Version: 1})
+	requireTwirpCode(t, err, twirp.Aborted)
+	require.Equal(t, "2", err.(twirp.Error).Meta("version"))
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: created.Id, Version: 1})
+	requireTwirpCode(t, err, twirp.Aborted)
+
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "t2", got.Title)
+	require.Equal(t, int64(2), got.Version)
+
+	// without a version the write is unconditional
+	updated, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "t4", Content: "c4"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), updated.Version)
+
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: created.Id, Version: 3})
+	require.NoError(t, err)
+	// a missing blog keeps its usual error, whatever the version
+	_, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "t5", Content: "c5", Version: 3})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+}
+
+func TestMemoryClient_ConcurrentVersionedUpdates(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+	created, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c"})
+	require.NoError(t, err)
+
+	// every editor read version 1, exactly one of them may win
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "t", Content: "c", Version: 1})
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, succeeded)
+}
+
+func TestMemoryClient_UpdateMask(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+	created, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Title", Content: "Body"})
+	require.NoError(t, err)
+
+	// only the title is in the mask, so the content is kept
+	updated, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "New title", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}})
+	require.NoError(t, err)
+	require.Equal(t, "New title", updated.Title)
+	require.Equal(t, "Body", updated.Content)
+
+	// without a mask only non-empty fields change
+	updated, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Content: "New body"})
+	require.NoError(t, err)
+	require.Equal(t, "New title", updated.Title)
+	require.Equal(t, "New body", updated.Content)
+
+	// a masked field may be cleared on purpose
+	updated, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "Only title", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}}})
+	require.NoError(t, err)
+	require.Equal(t, "Only title", updated.Title)
+	require.Empty(t, updated.Content)
+
+	for name, data := range map[string]*blogProto.UpdateBlogRequest{
+		"unknown path":  {Id: created.Id, Title: "t", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "author_id"}}},
+		"empty mask":    {Id: created.Id, Title: "t", UpdateMask: &fieldmaskpb.FieldMask{}},
+		"nothing to do": {Id: created.Id},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := m.UpdateBlog(ctx, data)
+			requireTwirpCode(t, err, twirp.InvalidArgument)
+		})
+	}
+
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "Only title", got.Title)
+	require.Equal(t, int64(4), got.Version)
+}
+
+func TestMemoryClient_SoftDelete(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	kept, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Kept", Content: "findme", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	deleted, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Deleted", Content: "findme", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: deleted.Id})
+	require.NoError(t, err)
+
+	// deleted blogs are hidden everywhere but the trash
+	_, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: deleted.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: deleted.Id, ShowDeleted: true})
+	require.NoError(t, err)
+	require.NotNil(t, got.DeleteTime)
+	require.Equal(t, int64(2), got.Version)
+
+	list, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Blogs, 1)
+	require.Equal(t, kept.Id, list.Blogs[0].Id)
+	require.Nil(t, list.Blogs[0].DeleteTime)
+
+	search, err := m.SearchBlogs(ctx, &blogProto.SearchBlogsRequest{Query: "findme"})
+	require.NoError(t, err)
+	require.Len(t, search.Results, 1)
+
+	trash, err := m.ListDeletedBlogs(ctx, &blogProto.ListDeletedBlogsRequest{})
+	require.NoError(t, err)
+	require.Len(t, trash.Blogs, 1)
+	require.Equal(t, deleted.Id, trash.Blogs[0].Id)
+
+	_, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: deleted.Id, Title: "t"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: deleted.Id})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	_, err = m.UndeleteBlog(ctx, &blogProto.UndeleteBlogRequest{Id: kept.Id})
+	requireTwirpCode(t, err, twirp.FailedPrecondition)
+	_, err = m.UndeleteBlog(ctx, &blogProto.UndeleteBlogRequest{Id: deleted.Id, Version: 1})
+	requireTwirpCode(t, err, twirp.Aborted)
+	restored, err := m.UndeleteBlog(ctx, &blogProto.UndeleteBlogRequest{Id: deleted.Id, Version: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), restored.Version)
+
+	list, err = m.ListBlog(ctx, &blogProto.ListBlogRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Blogs, 2)
+}
+
+func TestMemoryClient_PurgeDeletedBlogs(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	old, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Old", Content: "c"})
+	require.NoError(t, err)
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: old.Id})
+	require.NoError(t, err)
+	cutoff := time.Now()
+	time.Sleep(time.Millisecond)
+	recent, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Recent", Content: "c"})
+	require.NoError(t, err)
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: recent.Id})
+	require.NoError(t, err)
+	_, err = m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Live", Content: "c"})
+	require.NoError(t, err)
+
+	purged, err := m.PurgeDeletedBlogs(ctx, cutoff)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	_, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: old.Id, ShowDeleted: true})
+	requireTwirpCode(t, err, twirp.NotFound)
+	trash, err := m.ListDeletedBlogs(ctx, &blogProto.ListDeletedBlogsRequest{})
+	require.NoError(t, err)
+	require.Len(t, trash.Blogs, 1)
+	require.Equal(t, recent.Id, trash.Blogs[0].Id)
+}
+
+func TestMemoryClient_Revisions(t *testing.T) {
+	m := db.NewMemoryClient()
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "alice"})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "bob"})
+
+	created, err := m.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "v1", Content: "one"})
+	require.NoError(t, err)
+	_, err = m.UpdateBlog(alice, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "v2", Content: "two"})
+	require.NoError(t, err)
+	_, err = m.UpdateBlog(bob, &blogProto.UpdateBlogRequest{Id: created.Id, Content: "three"})
+	require.NoError(t, err)
+
+	// each update saved the version it replaced, newest first
+	list, err := m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id})
+	require.NoError(t, err)
+	require.Len(t, list.Revisions, 2)
+	require.Empty(t, list.NextPageToken)
+	require.Equal(t, int64(2), list.Revisions[0].Version)
+	require.Equal(t, "v2", list.Revisions[0].Title)
+	require.Equal(t, "two", list.Revisions[0].Content)
+	require.Equal(t, "bob", list.Revisions[0].RevisedBy)
+	require.Equal(t, int64(1), list.Revisions[1].Version)
+	require.Equal(t, "alice", list.Revisions[1].RevisedBy)
+	require.Equal(t, created.UpdateTime.AsTime(), list.Revisions[1].CreateTime.AsTime())
+
+	page, err := m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Revisions, 1)
+	require.NotEmpty(t, page.NextPageToken)
+	page, err = m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id, Limit: 1, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, page.Revisions, 1)
+	require.Equal(t, int64(1), page.Revisions[0].Version)
+	require.Empty(t, page.NextPageToken)
+	_, err = m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id, PageToken: "bad"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	revision, err := m.GetBlogRevision(alice, &blogProto.GetBlogRevisionRequest{BlogId: created.Id, Version: 1})
+	require.NoError(t, err)
+	require.Equal(t, "one", revision.Content)
+	// the current version is the blog itself, not a revision
+	_, err = m.GetBlogRevision(alice, &blogProto.GetBlogRevisionRequest{BlogId: created.Id, Version: 3})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	// failed updates save nothing, and purged blogs take their revisions with them
+	_, err = m.UpdateBlog(alice, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "stale", Version: 1})
+	requireTwirpCode(t, err, twirp.Aborted)
+	other, err := m.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Taken", Content: "c"})
+	require.NoError(t, err)
+	_, err = m.UpdateBlog(alice, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "v4", Slug: other.Slug, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "slug"}}})
+	requireTwirpCode(t, err, twirp.AlreadyExists)
+	list, err = m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id})
+	require.NoError(t, err)
+	require.Len(t, list.Revisions, 2)
+	_, err = m.DeleteBlog(alice, &blogProto.DeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	list, err = m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id})
+	require.NoError(t, err)
+	require.Len(t, list.Revisions, 2)
+	_, err = m.PurgeDeletedBlogs(alice, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	list, err = m.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id})
+	require.NoError(t, err)
+	require.Empty(t, list.Revisions)
+}
+
+func TestMemoryClient_Tags(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	goWeb, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Go web", Content: "c", Tags: []string{"Web", "go"}, Category: " Tutorials ", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "web"}, goWeb.Tags)
+	require.Equal(t, "Tutorials", goWeb.Category)
+	goOnly, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Go", Content: "c", Tags: []string{"go"}, Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	_, err = m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Untagged", Content: "c"})
+	require.NoError(t, err)
+	_, err = m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Bad", Content: "c", Tags: []string{""}})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	titles := func(filter *blogProto.BlogFilter) []string {
+		t.Helper()
+		list, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Filter: filter})
+		require.NoError(t, err)
+		titles := []string{}
+		for _, blog := range list.Blogs {
+			titles = append(titles, blog.Title)
+		}
+		return titles
+	}
+	require.Equal(t, []string{"Go web", "Go"}, titles(&blogProto.BlogFilter{Tags: []string{"GO", "web"}}))
+	require.Equal(t, []string{"Go web"}, titles(&blogProto.BlogFilter{Tags: []string{"go", "web"}, TagMatch: blogProto.TagMatch_TAG_MATCH_ALL}))
+	require.Equal(t, []string{"Go web"}, titles(&blogProto.BlogFilter{Category: "Tutorials"}))
+
+	tags, err := m.ListTags(ctx, &blogProto.ListTagsRequest{})
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 2)
+	require.Equal(t, "go", tags.Tags[0].Tag)
+	require.Equal(t, int64(2), tags.Tags[0].Count)
+	require.Equal(t, "web", tags.Tags[1].Tag)
+	require.Equal(t, int64(1), tags.Tags[1].Count)
+
+	// tags are replaced as a whole, and only when the mask or a non-empty value asks for it
+	updated, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: goWeb.Id, Title: "Renamed"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go", "web"}, updated.Tags)
+	updated, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: goWeb.Id, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tags", "category"}}})
+	require.NoError(t, err)
+	require.Empty(t, updated.Tags)
+	require.Empty(t, updated.Category)
+
+	// blogs in the trash are not counted
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: goOnly.Id})
+	require.NoError(t, err)
+	tags, err = m.ListTags(ctx, &blogProto.ListTagsRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Empty(t, tags.Tags)
+}
+
+func TestMemoryClient_Publish(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	draft, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Draft", Content: "findme"})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_DRAFT, draft.Status)
+	require.Nil(t, draft.PublishTime)
+	live, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Live", Content: "findme", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	require.NotNil(t, live.PublishTime)
+	_, err = m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Scheduled", Content: "c", Status: blogProto.BlogStatus_BLOG_STATUS_SCHEDULED})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	// drafts are left out of search, and of listings filtered by status
+	search, err := m.SearchBlogs(ctx, &blogProto.SearchBlogsRequest{Query: "findme"})
+	require.NoError(t, err)
+	require.Len(t, search.Results, 1)
+	require.Equal(t, live.Id, search.Results[0].Id)
+	drafts, err := m.ListBlog(ctx, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{Status: blogProto.BlogStatus_BLOG_STATUS_DRAFT}})
+	require.NoError(t, err)
+	require.Len(t, drafts.Blogs, 1)
+	require.Equal(t, draft.Id, drafts.Blogs[0].Id)
+
+	// a publish_time in the future schedules the blog until the scheduler reaches it
+	publishAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	scheduled, err := m.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: draft.Id, PublishTime: timestamppb.New(publishAt), Version: draft.Version})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_SCHEDULED, scheduled.Status)
+	require.True(t, publishAt.Equal(scheduled.PublishTime.AsTime()))
+	require.Equal(t, draft.Version+1, scheduled.Version)
+	_, err = m.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: draft.Id, Version: draft.Version})
+	requireTwirpCode(t, err, twirp.Aborted)
+
+	published, err := m.PublishScheduledBlogs(ctx, time.Now())
+	require.NoError(t, err)
+	require.Zero(t, published)
+	published, err = m.PublishScheduledBlogs(ctx, publishAt)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), published)
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: draft.Id})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_PUBLISHED, got.Status)
+	require.Equal(t, scheduled.Version+1, got.Version)
+
+	// unpublishing keeps the publish_time of the last publication
+	archived, err := m.UnpublishBlog(ctx, &blogProto.UnpublishBlogRequest{Id: live.Id, Archive: true})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_ARCHIVED, archived.Status)
+	require.Equal(t, live.PublishTime.AsTime(), archived.PublishTime.AsTime())
+	unpublished, err := m.UnpublishBlog(ctx, &blogProto.UnpublishBlogRequest{Id: draft.Id})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_DRAFT, unpublished.Status)
+
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: live.Id})
+	require.NoError(t, err)
+	_, err = m.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: live.Id})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	// a scheduled blog in the trash is not published, and keeps its schedule for when it is restored
+	trashed, err := m.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: draft.Id, PublishTime: timestamppb.New(publishAt.Add(time.Hour))})
+	require.NoError(t, err)
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: draft.Id})
+	require.NoError(t, err)
+	published, err = m.PublishScheduledBlogs(ctx, publishAt.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, published)
+	got, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: draft.Id, ShowDeleted: true})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_SCHEDULED, got.Status)
+	require.Equal(t, trashed.Version+1, got.Version)
+}
+
+func TestMemoryClient_Slugs(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	first, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Hello, World!", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, "hello-world", first.Slug)
+	second, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Hello world", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, "hello-world-2", second.Slug)
+
+	// a new slug moves the blog, its previous slug keeps resolving and is never given to another blog
+	moved, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: first.Id, Slug: "Greetings"})
+	require.NoError(t, err)
+	require.Equal(t, "greetings", moved.Slug)
+	got, err := m.GetBlogBySlug(ctx, &blogProto.GetBlogBySlugRequest{Slug: "hello-world"})
+	require.NoError(t, err)
+	require.Equal(t, first.Id, got.Id)
+	require.Equal(t, "greetings", got.Slug)
+	third, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Hello world", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, "hello-world-3", third.Slug)
+
+	_, err = m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: second.Id, Slug: "greetings"})
+	requireTwirpCode(t, err, twirp.AlreadyExists)
+	back, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: first.Id, Slug: "hello-world"})
+	require.NoError(t, err)
+	require.Equal(t, "hello-world", back.Slug)
+
+	// an empty slug in the update mask generates one from the title, titles alone never change the slug
+	retitled, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: second.Id, Title: "Goodbye"})
+	require.NoError(t, err)
+	require.Equal(t, "hello-world-2", retitled.Slug)
+	regenerated, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: second.Id, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"slug"}}})
+	require.NoError(t, err)
+	require.Equal(t, "goodbye", regenerated.Slug)
+
+	_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: first.Id})
+	require.NoError(t, err)
+	_, err = m.GetBlogBySlug(ctx, &blogProto.GetBlogBySlugRequest{Slug: "greetings"})
+	requireTwirpCode(t, err, twirp.NotFound)
+	purged, err := m.PurgeDeletedBlogs(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+	reused, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Hello world", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, "hello-world", reused.Slug)
+}
+
+func TestMemoryClient_ContentFormat(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	plain, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c"})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.ContentFormat_CONTENT_FORMAT_PLAIN, plain.ContentFormat)
+	_, err = m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "t", Content: "c", ContentFormat: 42})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	// the format is only changed when set or named in the update mask
+	markdown, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: plain.Id, ContentFormat: blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN, markdown.ContentFormat)
+	retitled, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: plain.Id, Title: "u"})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN, retitled.ContentFormat)
+	reset, err := m.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: plain.Id, UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"content_format"}}})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.ContentFormat_CONTENT_FORMAT_PLAIN, reset.ContentFormat)
+	got, err := m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: plain.Id})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.ContentFormat_CONTENT_FORMAT_PLAIN, got.ContentFormat)
+}
+
+func TestMemoryClient_WalkPublishedBlogs(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	ids, slugs := []string{}, []string{}
+	for _, title := range []string{"one", "two", "three", "draft", "deleted"} {
+		status := blogProto.BlogStatus_BLOG_STATUS_PUBLISHED
+		if title == "draft" {
+			status = blogProto.BlogStatus_BLOG_STATUS_DRAFT
+		}
+		blog, err := m.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: title, Content: "c", Status: status})
+		require.NoError(t, err)
+		if title == "deleted" {
+			_, err = m.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: blog.Id})
+			require.NoError(t, err)
+		}
+		ids, slugs = append(ids, blog.Id), append(slugs, blog.Slug)
+	}
+	count, err := m.CountPublishedBlogs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	walkedIds := []string{}
+	walk := func(from string, to string, limit int64) []string {
+		walked := []string{}
+		walkedIds = []string{}
+		err := m.WalkPublishedBlogs(ctx, from, to, limit, func(id string, slug string, lastModified time.Time) error {
+			require.False(t, lastModified.IsZero())
+			walked, walkedIds = append(walked, slug), append(walkedIds, id)
+			return nil
+		})
+		require.NoError(t, err)
+		return walked
+	}
+	require.Equal(t, slugs[:3], walk("", "", 0))
+	require.Equal(t, ids[:3], walkedIds)
+	require.Equal(t, slugs[:2], walk("", "", 2))
+	require.Equal(t, slugs[1:3], walk(ids[1], "", 10))
+	require.Equal(t, slugs[:2], walk(ids[0], ids[2], 0))
+	require.Empty(t, walk(ids[3], "", 2))
+
+	err = m.WalkPublishedBlogs(ctx, "not-an-id", "", 1, func(id string, slug string, lastModified time.Time) error { return nil })
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+}
+
+func TestMemoryClient_Batch(t *testing.T) {
+	m := db.NewMemoryClient()
+	ctx := context.Background()
+
+	invalid := &blogProto.CreateBlogRequest{Title: "bad", Status: blogProto.BlogStatus_BLOG_STATUS_SCHEDULED}
+	created, err := m.BatchCreateBlogs(ctx, &blogProto.BatchCreateBlogsRequest{Requests: []*blogProto.CreateBlogRequest{
+		{Title: "Same", Content: "a"}, invalid, {Title: "Same", Content: "b"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, created.Results, 3)
+	require.Equal(t, "same", created.Results[0].Blog.Slug)
+	require.Nil(t, created.Results[1].Blog)
+	require.Equal(t, string(twirp.InvalidArgument), created.Results[1].Error.Code)
+	require.Equal(t, "same-2", created.Results[2].Blog.Slug)
+	first, second := created.Results[0].Blog.Id, created.Results[2].Blog.Id
+
+	// a failure creates nothing and reports the failing item
+	_, err = m.BatchCreateBlogs(ctx, &blogProto.BatchCreateBlogsRequest{
+		Requests:     []*blogProto.CreateBlogRequest{{Title: "Dropped"}, invalid},
+		AllOrNothing: true,
+	})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+	require.Equal(t, "1", err.(twirp.Error).Meta("index"))
+	_, err = m.GetBlogBySlug(ctx, &blogProto.GetBlogBySlugRequest{Slug: "dropped"})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	got, err := m.BatchGetBlogs(ctx, &blogProto.BatchGetBlogsRequest{Ids: []string{second, "999", first}})
+	require.NoError(t, err)
+	require.Equal(t, "b", got.Results[0].Blog.Content)
+	require.Equal(t, string(twirp.NotFound), got.Results[1].Error.Code)
+	require.Equal(t, "a", got.Results[2].Blog.Content)
+	_, err = m.BatchGetBlogs(ctx, &blogProto.BatchGetBlogsRequest{Ids: []string{first, "999"}, AllOrNothing: true})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	// a version conflict fails an all-or-nothing batch without deleting its other blogs
+	_, err = m.BatchDeleteBlogs(ctx, &blogProto.BatchDeleteBlogsRequest{
+		Requests:     []*blogProto.DeleteBlogRequest{{Id: first}, {Id: second, Version: 5}},
+		AllOrNothing: true,
+	})
+	requireTwirpCode(t, err, twirp.Aborted)
+	require.Equal(t, "1", err.(twirp.Error).Meta("index"))
+	require.Equal(t, "1", err.(twirp.Error).Meta("version"))
+	_, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: first})
+	require.NoError(t, err)
+
+	deleted, err := m.BatchDeleteBlogs(ctx, &blogProto.BatchDeleteBlogsRequest{Requests: []*blogProto.DeleteBlogRequest{
+		{Id: first}, {Id: "0" + first}, {Id: second, Version: 1},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, first, deleted.Results[0].Blog.Id)
+	// two spellings of one id are the same blog
+	require.Equal(t, string(twirp.InvalidArgument), deleted.Results[1].Error.Code)
+	require.Contains(t, deleted.Results[1].Error.Msg, "already deleted by an earlier item")
+	require.Equal(t, second, deleted.Results[2].Blog.Id)
+	_, err = m.GetBlog(ctx, &blogProto.GetBlogRequest{Id: second})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	_, err = m.BatchGetBlogs(ctx, &blogProto.BatchGetBlogsRequest{})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+	_, err = m.BatchGetBlogs(ctx, &blogProto.BatchGetBlogsRequest{Ids: make([]string, db.MaxBatchSize+1)})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+}
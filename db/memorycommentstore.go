@@ -0,0 +1,211 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// MemoryCommentStore keeps comments in a map guarded by a mutex, like MemoryClient does for blogs
+type MemoryCommentStore struct {
+	mu       sync.RWMutex
+	nextId   int
+	comments map[int]*memoryComment
+}
+
+type memoryComment struct {
+	Id         int
+	BlogId     string
+	ParentId   string
+	AuthorId   string
+	Content    string
+	CreateTime time.Time
+	UpdateTime time.Time
+	DeleteTime time.Time
+	// set while the blog is in the trash
+	OrphanTime time.Time
+}
+
+func (c *memoryComment) record() commentRecord {
+	return commentRecord{
+		Id:         strconv.Itoa(c.Id),
+		BlogId:     c.BlogId,
+		ParentId:   c.ParentId,
+		AuthorId:   c.AuthorId,
+		Content:    c.Content,
+		CreateTime: c.CreateTime,
+		UpdateTime: c.UpdateTime,
+		DeleteTime: c.DeleteTime,
+	}
+}
+
+func NewMemoryCommentStore() *MemoryCommentStore {
+	return &MemoryCommentStore{
+		nextId:   1,
+		comments: map[int]*memoryComment{},
+	}
+}
+
+// find returns the comment with id unless its blog is in the trash, the caller must hold the lock
+func (m *MemoryCommentStore) find(id string) (*memoryComment, error) {
+	key, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid comment ID")
+	}
+	comment, ok := m.comments[key]
+	if !ok || !comment.OrphanTime.IsZero() {
+		return nil, commentNotFound(id)
+	}
+	return comment, nil
+}
+
+func (m *MemoryCommentStore) CreateComment(ctx context.Context, data *blogProto.CreateCommentRequest) (*blogProto.Comment, error) {
+	content, err := validateCommentContent(data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if data.ParentId != "" {
+		parent, err := m.find(data.ParentId)
+		if err != nil || parent.BlogId != data.BlogId {
+			return nil, parentNotFound(data.ParentId, data.BlogId)
+		}
+	}
+
+	now := time.Now()
+	comment := &memoryComment{
+		Id:         m.nextId,
+		BlogId:     data.BlogId,
+		ParentId:   data.ParentId,
+		AuthorId:   authorOf(ctx),
+		Content:    content,
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	m.comments[comment.Id] = comment
+	m.nextId++
+
+	return comment.record().response(), nil
+}
+
+func (m *MemoryCommentStore) GetComment(ctx context.Context, data *blogProto.GetCommentRequest) (*blogProto.Comment, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	comment, err := m.find(data.Id)
+	if err != nil {
+		return nil, err
+	}
+	return comment.record().response(), nil
+}
+
+func (m *MemoryCommentStore) ListComments(ctx context.Context, data *blogProto.ListCommentsRequest) (*blogProto.ListCommentsResponse, error) {
+	query, err := newCommentQuery(data)
+	if err != nil {
+		return nil, err
+	}
+	after := 0
+	if query.After != "" {
+		after, err = strconv.Atoi(query.After)
+		if err != nil {
+			return nil, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	matches := []*memoryComment{}
+	for _, comment := range m.comments {
+		if comment.BlogId == query.BlogId && comment.ParentId == query.ParentId && comment.OrphanTime.IsZero() && comment.Id > after {
+			matches = append(matches, comment)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Id < matches[j].Id })
+
+	comments := []commentRecord{}
+	for _, comment := range matches {
+		comments = append(comments, comment.record())
+	}
+	return query.page(comments), nil
+}
+
+func (m *MemoryCommentStore) UpdateComment(ctx context.Context, data *blogProto.UpdateCommentRequest) (*blogProto.Comment, error) {
+	content, err := validateCommentContent(data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment, err := m.find(data.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !comment.DeleteTime.IsZero() {
+		return nil, twirp.NewError(twirp.FailedPrecondition, "A deleted comment cannot be edited")
+	}
+	comment.Content = content
+	comment.UpdateTime = time.Now()
+
+	return comment.record().response(), nil
+}
+
+func (m *MemoryCommentStore) DeleteComment(ctx context.Context, data *blogProto.DeleteCommentRequest) (*blogProto.DeleteCommentResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	comment, err := m.find(data.Id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.DeleteTime.IsZero() {
+		comment.Content = ""
+		comment.DeleteTime = time.Now()
+	}
+
+	return &blogProto.DeleteCommentResponse{
+		Id: data.Id,
+	}, nil
+}
+
+func (m *MemoryCommentStore) OrphanComments(ctx context.Context, blogId string, orphaned bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, comment := range m.comments {
+		if comment.BlogId != blogId {
+			continue
+		}
+		if orphaned {
+			comment.OrphanTime = now
+		} else {
+			comment.OrphanTime = time.Time{}
+		}
+	}
+	return nil
+}
+
+func (m *MemoryCommentStore) PurgeOrphanedComments(ctx context.Context, orphanedBefore time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	purged := int64(0)
+	for id, comment := range m.comments {
+		if !comment.OrphanTime.IsZero() && comment.OrphanTime.Before(orphanedBefore) {
+			delete(m.comments, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,99 @@
+package db_test
+
+import (
+	"blog-service/auth"
+	"blog-service/db"
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+)
+
+func TestMemoryCommentStore_Threads(t *testing.T) {
+	m := db.NewMemoryCommentStore()
+	ctx := auth.NewContext(context.Background(), &auth.Claims{Subject: "alice"})
+
+	first, err := m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "1", Content: "  first  "})
+	require.NoError(t, err)
+	require.Equal(t, "first", first.Content)
+	require.Equal(t, "alice", first.AuthorId)
+	second, err := m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "1", Content: "second"})
+	require.NoError(t, err)
+	reply, err := m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "1", ParentId: first.Id, Content: "reply"})
+	require.NoError(t, err)
+	require.Equal(t, first.Id, reply.ParentId)
+
+	_, err = m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "1", Content: " "})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+	// a reply must be on the same blog as its parent
+	_, err = m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "2", ParentId: first.Id, Content: "elsewhere"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	page, err := m.ListComments(ctx, &blogProto.ListCommentsRequest{BlogId: "1", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Comments, 1)
+	require.Equal(t, first.Id, page.Comments[0].Id)
+	require.NotEmpty(t, page.NextPageToken)
+	page, err = m.ListComments(ctx, &blogProto.ListCommentsRequest{BlogId: "1", Limit: 1, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, page.Comments, 1)
+	require.Equal(t, second.Id, page.Comments[0].Id)
+	require.Empty(t, page.NextPageToken)
+
+	replies, err := m.ListComments(ctx, &blogProto.ListCommentsRequest{BlogId: "1", ParentId: first.Id})
+	require.NoError(t, err)
+	require.Len(t, replies.Comments, 1)
+	require.Equal(t, reply.Id, replies.Comments[0].Id)
+
+	edited, err := m.UpdateComment(ctx, &blogProto.UpdateCommentRequest{Id: reply.Id, Content: "edited"})
+	require.NoError(t, err)
+	require.Equal(t, "edited", edited.Content)
+
+	// deleted comments stay in their thread without content, and cannot be edited
+	_, err = m.DeleteComment(ctx, &blogProto.DeleteCommentRequest{Id: first.Id})
+	require.NoError(t, err)
+	deleted, err := m.GetComment(ctx, &blogProto.GetCommentRequest{Id: first.Id})
+	require.NoError(t, err)
+	require.Empty(t, deleted.Content)
+	require.NotNil(t, deleted.DeleteTime)
+	_, err = m.UpdateComment(ctx, &blogProto.UpdateCommentRequest{Id: first.Id, Content: "again"})
+	requireTwirpCode(t, err, twirp.FailedPrecondition)
+	replies, err = m.ListComments(ctx, &blogProto.ListCommentsRequest{BlogId: "1", ParentId: first.Id})
+	require.NoError(t, err)
+	require.Len(t, replies.Comments, 1)
+
+	_, err = m.GetComment(ctx, &blogProto.GetCommentRequest{Id: "999"})
+	requireTwirpCode(t, err, twirp.NotFound)
+}
+
+func TestMemoryCommentStore_Orphans(t *testing.T) {
+	m := db.NewMemoryCommentStore()
+	ctx := context.Background()
+
+	comment, err := m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "1", Content: "c"})
+	require.NoError(t, err)
+	_, err = m.CreateComment(ctx, &blogProto.CreateCommentRequest{BlogId: "2", Content: "kept"})
+	require.NoError(t, err)
+
+	require.NoError(t, m.OrphanComments(ctx, "1", true))
+	_, err = m.GetComment(ctx, &blogProto.GetCommentRequest{Id: comment.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	list, err := m.ListComments(ctx, &blogProto.ListCommentsRequest{BlogId: "1"})
+	require.NoError(t, err)
+	require.Empty(t, list.Comments)
+
+	require.NoError(t, m.OrphanComments(ctx, "1", false))
+	_, err = m.GetComment(ctx, &blogProto.GetCommentRequest{Id: comment.Id})
+	require.NoError(t, err)
+
+	require.NoError(t, m.OrphanComments(ctx, "1", true))
+	purged, err := m.PurgeOrphanedComments(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+	list, err = m.ListComments(ctx, &blogProto.ListCommentsRequest{BlogId: "2"})
+	require.NoError(t, err)
+	require.Len(t, list.Comments, 1)
+}
@@ -0,0 +1,247 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+/*
+Postgres schema changes live in migrations/postgres as pairs of files named
+<version>_<name>.up.sql and <version>_<name>.down.sql. They are embedded into the binary,
+applied in version order and recorded in the schema_migrations table, so each one runs once.
+To change the schema, add a new pair with the next version rather than editing an applied one.
+*/
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// LoadMigrations reads every migration in fsys and returns them sorted by version
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s does not match <version>_<name>.(up|down).sql", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		contents, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(contents)
+		} else {
+			migration.Down = string(contents)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func PostgresMigrations() ([]Migration, error) {
+	sub, err := fs.Sub(postgresMigrations, path.Join("migrations", "postgres"))
+	if err != nil {
+		return nil, err
+	}
+	return LoadMigrations(sub)
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	// out receives a line per migration applied, or the SQL that would run in dry-run mode
+	out io.Writer
+}
+
+func NewMigrator(db *sql.DB, out io.Writer) (*Migrator, error) {
+	migrations, err := PostgresMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, out: out}, nil
+}
+
+// arbitrary key for pg_advisory_lock, so several instances starting at once do not migrate concurrently
+const migrationLockKey = 7262837
+
+// withLock runs fn on a single connection holding the migration lock, with schema_migrations in place
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("unable to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("unable to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]bool{}
+	for rows.Next() {
+		var version int64
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// runInTx runs a migration's SQL and its schema_migrations bookkeeping atomically
+func runInTx(ctx context.Context, conn *sql.Conn, statements string, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Pending returns the migrations that have not been applied yet, in the order Up would apply them
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	pending := []Migration{}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if !applied[migration.Version] {
+				pending = append(pending, migration)
+			}
+		}
+		return nil
+	})
+	return pending, err
+}
+
+// Up applies every pending migration, or only prints them when dryRun is set
+func (m *Migrator) Up(ctx context.Context, dryRun bool) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for _, migration := range m.migrations {
+			if applied[migration.Version] {
+				continue
+			}
+			count++
+			if dryRun {
+				fmt.Fprintf(m.out, "-- pending: %d_%s (up)\n%s\n", migration.Version, migration.Name, migration.Up)
+				continue
+			}
+			err := runInTx(ctx, conn, migration.Up, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			fmt.Fprintf(m.out, "Applied migration %d_%s\n", migration.Version, migration.Name)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(m.out, "No pending migrations")
+		}
+		return nil
+	})
+}
+
+// Down reverts the most recently applied steps migrations, or only prints them when dryRun is set
+func (m *Migrator) Down(ctx context.Context, steps int, dryRun bool) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if !applied[migration.Version] {
+				continue
+			}
+			count++
+			if dryRun {
+				fmt.Fprintf(m.out, "-- pending: %d_%s (down)\n%s\n", migration.Version, migration.Name, migration.Down)
+				continue
+			}
+			err := runInTx(ctx, conn, migration.Down, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+			if err != nil {
+				return fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			fmt.Fprintf(m.out, "Reverted migration %d_%s\n", migration.Version, migration.Name)
+		}
+
+		if count == 0 {
+			fmt.Fprintln(m.out, "No migrations to revert")
+		}
+		return nil
+	})
+}
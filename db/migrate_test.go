@@ -0,0 +1,60 @@
+package db_test
+
+import (
+	"blog-service/db"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresMigrations(t *testing.T) {
+	migrations, err := db.PostgresMigrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		require.NotEmpty(t, migration.Up)
+		require.NotEmpty(t, migration.Down)
+		if i > 0 {
+			require.Greater(t, migration.Version, migrations[i-1].Version)
+		}
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := db.LoadMigrations(fstest.MapFS{
+		"0002_add_column.up.sql":     {Data: []byte("ALTER TABLE t ADD COLUMN c TEXT;")},
+		"0002_add_column.down.sql":   {Data: []byte("ALTER TABLE t DROP COLUMN c;")},
+		"0001_create_table.up.sql":   {Data: []byte("CREATE TABLE t ();")},
+		"0001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	require.Equal(t, int64(1), migrations[0].Version)
+	require.Equal(t, "create_table", migrations[0].Name)
+	require.Equal(t, "DROP TABLE t;", migrations[0].Down)
+	require.Equal(t, int64(2), migrations[1].Version)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE t ();")},
+		},
+		"bad file name": {
+			"create_table.sql": {Data: []byte("CREATE TABLE t ();")},
+		},
+		"mismatched names": {
+			"0001_create_table.up.sql": {Data: []byte("CREATE TABLE t ();")},
+			"0001_drop_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		},
+	}
+
+	for name, fsys := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := db.LoadMigrations(fsys)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+-- IF NOT EXISTS so databases where the table was created by hand, as the README used to describe, adopt it as is
+CREATE TABLE IF NOT EXISTS blogs (
+    id SERIAL PRIMARY KEY,
+    title TEXT,
+    content TEXT
+);
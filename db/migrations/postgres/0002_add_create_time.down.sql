@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS blogs_title_id_idx;
+DROP INDEX IF EXISTS blogs_create_time_idx;
+
+ALTER TABLE blogs DROP COLUMN IF EXISTS create_time;
@@ -0,0 +1,5 @@
+-- rows that existed before this migration get the time it ran, since their real creation time was never recorded
+ALTER TABLE blogs ADD COLUMN create_time TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX blogs_create_time_idx ON blogs (create_time);
+CREATE INDEX blogs_title_id_idx ON blogs (title, id);
@@ -0,0 +1,4 @@
+ALTER TABLE blogs ADD COLUMN update_time TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- existing blogs count as never updated
+UPDATE blogs SET update_time = create_time;
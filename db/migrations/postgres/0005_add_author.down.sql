@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS blogs_author_id_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS author_id;
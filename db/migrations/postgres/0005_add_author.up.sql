@@ -0,0 +1,5 @@
+-- blogs created before authors were recorded keep an empty author_id, only admins may change them
+ALTER TABLE blogs ADD COLUMN author_id TEXT NOT NULL DEFAULT '';
+
+-- serves ListBlog's author_id filter in id order
+CREATE INDEX blogs_author_id_idx ON blogs (author_id, id);
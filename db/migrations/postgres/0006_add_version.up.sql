@@ -0,0 +1,2 @@
+-- existing blogs start at version 1, like new ones
+ALTER TABLE blogs ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+-- blogs in the trash would reappear once the column is gone, so they are purged first
+DELETE FROM blogs WHERE delete_time IS NOT NULL;
+DROP INDEX IF EXISTS blogs_delete_time_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS delete_time;
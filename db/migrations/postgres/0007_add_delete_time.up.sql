@@ -0,0 +1,5 @@
+-- blogs with a delete_time are in the trash until they are restored or purged
+ALTER TABLE blogs ADD COLUMN delete_time TIMESTAMPTZ;
+
+-- serves the purge job and ListDeletedBlogs, which only look at the trash
+CREATE INDEX blogs_delete_time_idx ON blogs (delete_time) WHERE delete_time IS NOT NULL;
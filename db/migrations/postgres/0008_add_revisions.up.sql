@@ -0,0 +1,11 @@
+-- the title and content each UpdateBlog replaced, keyed by the version they had
+CREATE TABLE blog_revisions (
+    blog_id INTEGER NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    version BIGINT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    create_time TIMESTAMPTZ NOT NULL,
+    revise_time TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revised_by TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (blog_id, version)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS blog_tags;
+DROP INDEX IF EXISTS blogs_category_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS category;
@@ -0,0 +1,12 @@
+ALTER TABLE blogs ADD COLUMN category TEXT NOT NULL DEFAULT '';
+CREATE INDEX blogs_category_idx ON blogs (category);
+
+-- one row per tag of a blog, tags are stored normalized, see normalizeTags
+CREATE TABLE blog_tags (
+    blog_id INTEGER NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (blog_id, tag)
+);
+
+-- serves the tag filters of ListBlog and the counts of ListTags
+CREATE INDEX blog_tags_tag_idx ON blog_tags (tag, blog_id);
@@ -0,0 +1,18 @@
+-- parent_id is NULL on top-level comments, orphan_time is set while the blog is in the trash
+CREATE TABLE comments (
+    id SERIAL PRIMARY KEY,
+    blog_id INTEGER NOT NULL REFERENCES blogs (id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES comments (id) ON DELETE CASCADE,
+    author_id TEXT NOT NULL DEFAULT '',
+    content TEXT NOT NULL DEFAULT '',
+    create_time TIMESTAMPTZ NOT NULL DEFAULT now(),
+    update_time TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delete_time TIMESTAMPTZ,
+    orphan_time TIMESTAMPTZ
+);
+
+-- serves ListComments, one thread level of one blog in id order
+CREATE INDEX comments_blog_id_parent_id_idx ON comments (blog_id, parent_id, id);
+
+-- serves the purge job
+CREATE INDEX comments_orphan_time_idx ON comments (orphan_time) WHERE orphan_time IS NOT NULL;
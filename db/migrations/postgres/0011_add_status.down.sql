@@ -0,0 +1,5 @@
+-- every blog was live before the draft workflow, unpublished blogs become visible again
+DROP INDEX IF EXISTS blogs_scheduled_idx;
+DROP INDEX IF EXISTS blogs_status_idx;
+ALTER TABLE blogs DROP COLUMN IF EXISTS publish_time;
+ALTER TABLE blogs DROP COLUMN IF EXISTS status;
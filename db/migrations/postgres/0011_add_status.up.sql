@@ -0,0 +1,10 @@
+-- blogs created before the draft workflow were live, so they are published as of their create_time
+ALTER TABLE blogs ADD COLUMN status SMALLINT NOT NULL DEFAULT 3;
+ALTER TABLE blogs ADD COLUMN publish_time TIMESTAMPTZ;
+UPDATE blogs SET publish_time = create_time;
+-- new blogs are drafts, the values match the BlogStatus enum
+ALTER TABLE blogs ALTER COLUMN status SET DEFAULT 1;
+
+CREATE INDEX blogs_status_idx ON blogs (status);
+-- serves the scheduler, which only looks at scheduled blogs
+CREATE INDEX blogs_scheduled_idx ON blogs (publish_time) WHERE status = 2;
@@ -0,0 +1,10 @@
+-- the current slug of each blog, existing blogs get one from their title when the service connects
+ALTER TABLE blogs ADD COLUMN slug TEXT;
+
+-- every slug a blog has had, so previous slugs keep resolving and are never given to another blog
+CREATE TABLE blog_slugs (
+    slug TEXT PRIMARY KEY,
+    blog_id INTEGER NOT NULL REFERENCES blogs (id) ON DELETE CASCADE
+);
+
+CREATE INDEX blog_slugs_blog_id_idx ON blog_slugs (blog_id);
@@ -0,0 +1 @@
+ALTER TABLE blogs DROP COLUMN IF EXISTS content_format;
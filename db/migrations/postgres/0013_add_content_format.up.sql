@@ -0,0 +1,2 @@
+-- existing content is rendered as plain text, the values match the ContentFormat enum
+ALTER TABLE blogs ADD COLUMN content_format SMALLINT NOT NULL DEFAULT 1;
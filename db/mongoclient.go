@@ -4,6 +4,8 @@ import (
 	blogProto "blog-service/rpc/blog"
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -22,18 +24,126 @@ https://docs.mongodb.com/drivers/go/current/fundamentals/bson/
 https://docs.mongodb.com/drivers/go/current/fundamentals/crud/
 */
 
-type MongoClient struct{}
+type MongoClient struct {
+	uri        string
+	database   string
+	collection string
+}
 
 var Collection *mongo.Collection
 
+// maxUpdateAttempts bounds how often UpdateBlog starts over when the blog changes between its read and its write
+const maxUpdateAttempts = 5
+
+// Revisions holds the revisions of the blogs in Collection, in a collection named after it with a _revisions suffix
+var Revisions *mongo.Collection
+
+// Comments holds the comments on the blogs in Collection, see MongoCommentStore
+var Comments *mongo.Collection
+
 type BlogItem struct {
-	Id      primitive.ObjectID `bson:"_id"`
-	Title   string             `bson:"title"`
-	Content string             `bson:"content"`
+	Id         primitive.ObjectID `bson:"_id"`
+	Title      string             `bson:"title"`
+	Content    string             `bson:"content"`
+	CreateTime time.Time          `bson:"create_time,omitempty"`
+	UpdateTime time.Time          `bson:"update_time,omitempty"`
+	// missing on documents written before authors were recorded
+	AuthorId string `bson:"author_id,omitempty"`
+	// set to 1 on older documents by ensureMongoSchema
+	Version int64 `bson:"version,omitempty"`
+	// only set while the blog is in the trash
+	DeleteTime time.Time `bson:"delete_time,omitempty"`
+	// sorted, see normalizeTags
+	Tags     []string `bson:"tags,omitempty"`
+	Category string   `bson:"category,omitempty"`
+	// set to published on older documents by ensureMongoSchema
+	Status      blogProto.BlogStatus `bson:"status,omitempty"`
+	PublishTime time.Time            `bson:"publish_time,omitempty"`
+	// Slug is the current slug, Slugs every slug the blog has had including it. Both are set on older documents
+	// by ensureMongoSchema
+	Slug  string   `bson:"slug,omitempty"`
+	Slugs []string `bson:"slugs,omitempty"`
+	// missing on documents written before content formats, which are plain text
+	ContentFormat blogProto.ContentFormat `bson:"content_format,omitempty"`
 }
 
-func NewMongoClient() MongoClient {
-	return MongoClient{}
+type RevisionItem struct {
+	BlogId     primitive.ObjectID `bson:"blog_id"`
+	Version    int64              `bson:"version"`
+	Title      string             `bson:"title"`
+	Content    string             `bson:"content"`
+	CreateTime time.Time          `bson:"create_time"`
+	ReviseTime time.Time          `bson:"revise_time"`
+	RevisedBy  string             `bson:"revised_by,omitempty"`
+}
+
+func (r RevisionItem) record() revisionRecord {
+	return revisionRecord{
+		BlogId:     r.BlogId.Hex(),
+		Version:    r.Version,
+		Title:      r.Title,
+		Content:    r.Content,
+		CreateTime: r.CreateTime,
+		ReviseTime: r.ReviseTime,
+		RevisedBy:  r.RevisedBy,
+	}
+}
+
+// notDeletedFilter matches blogs outside the trash, documents without a delete_time included
+var notDeletedFilter = bson.E{Key: "delete_time", Value: nil}
+
+var deletedFilter = bson.E{Key: "delete_time", Value: bson.D{{Key: "$ne", Value: nil}}}
+
+func (b BlogItem) record() blogRecord {
+	// documents written before timestamps were stored fall back to the creation time in their ObjectID
+	createTime := b.CreateTime
+	if createTime.IsZero() {
+		createTime = b.Id.Timestamp()
+	}
+	updateTime := b.UpdateTime
+	if updateTime.IsZero() {
+		updateTime = createTime
+	}
+	version := b.Version
+	if version == 0 {
+		version = 1
+	}
+	status := b.Status
+	if status == blogProto.BlogStatus_BLOG_STATUS_UNSPECIFIED {
+		status = blogProto.BlogStatus_BLOG_STATUS_PUBLISHED
+	}
+	format := b.ContentFormat
+	if format == blogProto.ContentFormat_CONTENT_FORMAT_UNSPECIFIED {
+		format = blogProto.ContentFormat_CONTENT_FORMAT_PLAIN
+	}
+
+	return blogRecord{
+		Id:            b.Id.Hex(),
+		Title:         b.Title,
+		Content:       b.Content,
+		CreateTime:    createTime,
+		UpdateTime:    updateTime,
+		AuthorId:      b.AuthorId,
+		Version:       version,
+		DeleteTime:    b.DeleteTime,
+		Tags:          b.Tags,
+		Category:      b.Category,
+		Status:        status,
+		PublishTime:   b.PublishTime,
+		Slug:          b.Slug,
+		ContentFormat: format,
+	}
+}
+
+// publishedFilter matches the blogs readers can see, documents written before the draft workflow included
+var publishedFilter = bson.E{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{nil, int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED)}}}}
+
+func NewMongoClient(uri string, database string, collection string) MongoClient {
+	return MongoClient{
+		uri:        uri,
+		database:   database,
+		collection: collection,
+	}
 }
 
 func (m MongoClient) Connect() error {
@@ -43,95 +153,377 @@ func (m MongoClient) Connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
 
-	// mongo.Connect will create a new client and enable access to the MongoDB instance running on 27107
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://localhost:27017"))
+	// mongo.Connect will create a new client and enable access to the MongoDB instance at the configured URI
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(m.uri))
 	if err != nil {
 		return err
 	}
 
-	Collection = client.Database("mydb").Collection("blog")
-
 	fmt.Println("Successfully connected to MongoDB")
+
+	// creates the collection, validator and indexes if this is a fresh database
+	collection, err := ensureMongoSchema(ctx, client.Database(m.database), m.collection)
+	if err != nil {
+		return err
+	}
+	Collection = collection
+
+	revisions, err := ensureMongoIndexes(ctx, client.Database(m.database), m.collection+"_revisions", revisionIndexes)
+	if err != nil {
+		return err
+	}
+	Revisions = revisions
+
+	comments, err := ensureMongoIndexes(ctx, client.Database(m.database), m.collection+"_comments", commentIndexes)
+	if err != nil {
+		return err
+	}
+	Comments = comments
+
 	return nil
 }
 
-func (m MongoClient) CreateBlog(data *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
-	res, err := Collection.InsertOne(context.Background(), data)
+func (m MongoClient) CreateBlog(ctx context.Context, data *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
+	// BSON dates have millisecond precision, truncating keeps the response equal to what is stored
+	item, err := newBlogItem(ctx, data, time.Now().Truncate(time.Millisecond))
 	if err != nil {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", err))
+		return nil, err
 	}
 
-	// type assertion that res.InsertedID is of type primitive.ObjectID
-	oid, ok := res.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Cannot convert to oid: %v", ok))
+	// the unique slugs index rejects a slug another blog claimed since it was found free, so it is looked up again
+	for attempt := 1; ; attempt++ {
+		item.Slug, err = freeMongoSlug(ctx, Collection, item.Id, slugify(item.Title))
+		if err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating a blog: %v", err))
+		}
+		item.Slugs = []string{item.Slug}
+
+		_, err = Collection.InsertOne(ctx, item)
+		if err == nil || !mongo.IsDuplicateKeyError(err) || attempt == maxSlugAttempts {
+			break
+		}
 	}
-	return &blogProto.CreateBlogResponse{
-		Id:      oid.Hex(),
-		Title:   data.Title,
-		Content: data.Content,
-	}, nil
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", err))
+	}
+
+	return item.record().createResponse(), nil
+}
 
+// newBlogItem checks data and builds the document CreateBlog inserts, without its slug
+func newBlogItem(ctx context.Context, data *blogProto.CreateBlogRequest, now time.Time) (BlogItem, error) {
+	labels, err := newBlogLabels(data.Tags, data.Category)
+	if err != nil {
+		return BlogItem{}, err
+	}
+	status, publishTime, err := createStatus(data.Status, now)
+	if err != nil {
+		return BlogItem{}, err
+	}
+	format, err := contentFormat(data.ContentFormat)
+	if err != nil {
+		return BlogItem{}, err
+	}
+	return BlogItem{
+		Id:            primitive.NewObjectID(),
+		Title:         data.Title,
+		Content:       data.Content,
+		CreateTime:    now,
+		UpdateTime:    now,
+		AuthorId:      authorOf(ctx),
+		Version:       1,
+		Tags:          labels.Tags,
+		Category:      labels.Category,
+		Status:        status,
+		PublishTime:   publishTime,
+		ContentFormat: format,
+	}, nil
 }
 
-func (m MongoClient) GetBlog(data *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
+func (m MongoClient) GetBlog(ctx context.Context, data *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
 	oid, err := primitive.ObjectIDFromHex(data.Id)
 	if err != nil {
 		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
 	}
 
 	filter := bson.D{{Key: "_id", Value: oid}}
+	if !data.ShowDeleted {
+		filter = append(filter, notDeletedFilter)
+	}
 
 	result := BlogItem{}
 
 	// Decode() method unmarshals BSON into result
-	unmarshal_err := Collection.FindOne(context.TODO(), filter).Decode(&result)
+	unmarshal_err := Collection.FindOne(ctx, filter).Decode(&result)
 	if unmarshal_err != nil {
 		if unmarshal_err == mongo.ErrNoDocuments {
 			return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", data.Id))
 		}
-		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("There was an error finding a blog with ID: %v \nError: %v", data.Id, unmarshal_err))
+		return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error finding a blog with ID: %v \nError: %v", data.Id, unmarshal_err))
 	}
 
-	return &blogProto.GetBlogResponse{
-		Id:      data.Id,
-		Title:   result.Title,
-		Content: result.Content,
-	}, nil
+	return result.record().getResponse(), nil
+}
+
+// freeMongoSlug returns the first variant of base that no blog other than oid has had
+func freeMongoSlug(ctx context.Context, collection *mongo.Collection, oid primitive.ObjectID, base string) (string, error) {
+	taken, err := takenMongoSlugs(ctx, collection, oid, base)
+	if err != nil {
+		return "", err
+	}
+	return uniqueSlug(base, taken), nil
+}
+
+// takenMongoSlugs returns the variants of base that blogs other than oid have had
+func takenMongoSlugs(ctx context.Context, collection *mongo.Collection, oid primitive.ObjectID, base string) (map[string]bool, error) {
+	// an anchored regex can use the slugs index
+	filter := bson.D{
+		{Key: "slugs", Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(base) + "(-[0-9]+)?$"}},
+		{Key: "_id", Value: bson.D{{Key: "$ne", Value: oid}}},
+	}
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.D{{Key: "slugs", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var items []BlogItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	taken := map[string]bool{}
+	for _, item := range items {
+		for _, slug := range item.Slugs {
+			if isSlugVariant(slug, base) {
+				taken[slug] = true
+			}
+		}
+	}
+	return taken, nil
+}
+
+// assignMongoSlugs gives each of the new items the first variant of its title's slug that no blog has had and
+// no earlier item was given, looking up the slugs taken for each distinct title once
+func assignMongoSlugs(ctx context.Context, items []*BlogItem) error {
+	takenByBase := map[string]map[string]bool{}
+	for _, item := range items {
+		base := slugify(item.Title)
+		taken, ok := takenByBase[base]
+		if !ok {
+			var err error
+			if taken, err = takenMongoSlugs(ctx, Collection, item.Id, base); err != nil {
+				return err
+			}
+			takenByBase[base] = taken
+		}
+		item.Slug = uniqueSlug(base, taken)
+		item.Slugs = []string{item.Slug}
+		// the slug may be a variant of another title's slug too, e.g. "go-2" for "Go" and "Go 2"
+		for _, others := range takenByBase {
+			others[item.Slug] = true
+		}
+	}
+	return nil
+}
+
+// mongoSlug returns the slug UpdateBlog assigns blog oid: requested, unless another blog has had it, or when
+// requested is empty the first free variant of title
+func mongoSlug(ctx context.Context, oid primitive.ObjectID, requested string, title string) (string, error) {
+	if requested == "" {
+		return freeMongoSlug(ctx, Collection, oid, slugify(title))
+	}
+	owner := BlogItem{}
+	err := Collection.FindOne(ctx, bson.D{{Key: "slugs", Value: requested}}).Decode(&owner)
+	if err == mongo.ErrNoDocuments || (err == nil && owner.Id == oid) {
+		return requested, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return "", slugTaken(requested)
 }
 
-func (m MongoClient) UpdateBlog(data *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+func (m MongoClient) GetBlogBySlug(ctx context.Context, data *blogProto.GetBlogBySlugRequest) (*blogProto.GetBlogResponse, error) {
+	result := BlogItem{}
+	find_err := Collection.FindOne(ctx, bson.D{{Key: "slugs", Value: data.Slug}, notDeletedFilter}).Decode(&result)
+	if find_err == mongo.ErrNoDocuments {
+		return nil, slugNotFound(data.Slug)
+	}
+	if find_err != nil {
+		return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error finding a blog with slug: %v \nError: %v", data.Slug, find_err))
+	}
+
+	return result.record().getResponse(), nil
+}
+
+func (m MongoClient) UpdateBlog(ctx context.Context, data *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
 	oid, err := primitive.ObjectIDFromHex(data.Id)
 	if err != nil {
 		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
 	}
+	fields, err := parseUpdateMask(data)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := newBlogLabels(data.Tags, data.Category)
+	if err != nil {
+		return nil, err
+	}
+	requestedSlug, err := normalizeSlug(data.Slug)
+	if err != nil {
+		return nil, err
+	}
+	format, err := contentFormat(data.ContentFormat)
+	if err != nil {
+		return nil, err
+	}
 
-	filter := bson.D{{Key: "_id", Value: oid}}
+	// only the fields in the update mask are set, the others keep their stored value
+	now := time.Now().Truncate(time.Millisecond)
+	set := bson.M{"update_time": now}
+	if fields.Title {
+		set["title"] = data.Title
+	}
+	if fields.Content {
+		set["content"] = data.Content
+	}
+	// empty tags and categories are unset rather than stored, like omitempty does on insert
+	unset := bson.M{}
+	if fields.Tags {
+		if len(labels.Tags) > 0 {
+			set["tags"] = labels.Tags
+		} else {
+			unset["tags"] = ""
+		}
+	}
+	if fields.Category {
+		if labels.Category != "" {
+			set["category"] = labels.Category
+		} else {
+			unset["category"] = ""
+		}
+	}
+	if fields.ContentFormat {
+		set["content_format"] = format
+	}
 
-	update := bson.D{{Key: "$set", Value: bson.M{"title": data.Title, "content": data.Content}}}
+	/*
+		The update only applies to the version that was read, so of concurrent updates of one version a single one
+		matches, and only that one saves the replaced version as a revision. An update that fails or misses leaves
+		no revision behind.
+	*/
+	for attempt := 1; ; attempt++ {
+		previous := BlogItem{}
+		find_err := Collection.FindOne(ctx, bson.D{{Key: "_id", Value: oid}, notDeletedFilter}).Decode(&previous)
+		if find_err != nil {
+			return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v", data.Id, data))
+		}
+		current := previous.record()
+		if data.Version != 0 && data.Version != current.Version {
+			return nil, versionConflict(data.Id, data.Version, current.Version)
+		}
 
-	result, update_err := Collection.UpdateOne(context.TODO(), filter, update)
-	if update_err != nil || result.MatchedCount == 0 {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v", data.Id, data))
-	}
+		slug := ""
+		if fields.Slug {
+			title := current.Title
+			if fields.Title {
+				title = data.Title
+			}
+			if slug, err = mongoSlug(ctx, oid, requestedSlug, title); err != nil {
+				if twerr, ok := err.(twirp.Error); ok {
+					return nil, twerr
+				}
+				return nil, newError(ctx, twirp.Internal, fmt.Sprintf("Blog id: %v could not be updated with %v: %v", data.Id, data, err))
+			}
+			set["slug"] = slug
+		}
+		update := bson.D{
+			{Key: "$set", Value: set},
+			{Key: "$inc", Value: bson.M{"version": 1}},
+		}
+		if len(unset) > 0 {
+			update = append(update, bson.E{Key: "$unset", Value: unset})
+		}
+		if fields.Slug {
+			// previous slugs stay in slugs, so they keep resolving
+			update = append(update, bson.E{Key: "$addToSet", Value: bson.M{"slugs": slug}})
+		}
 
-	return &blogProto.UpdateBlogResponse{
-		Id:      oid.Hex(),
-		Title:   data.Title,
-		Content: data.Content,
-	}, nil
+		// matching on the version makes the check and the write a single atomic operation
+		filter := bson.D{{Key: "_id", Value: oid}, notDeletedFilter, {Key: "version", Value: current.Version}}
+		result, update_err := Collection.UpdateOne(ctx, filter, update)
+		if mongo.IsDuplicateKeyError(update_err) {
+			// another blog claimed the slug since it was found free
+			return nil, slugTaken(slug)
+		}
+		if update_err != nil {
+			return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v", data.Id, data))
+		}
+		if result.MatchedCount == 0 {
+			// the blog changed since it was read, a versioned update reports it and the others start over
+			if conflict := mongoVersionConflict(ctx, oid, data.Version); conflict != nil {
+				return nil, conflict
+			}
+			if attempt == maxUpdateAttempts {
+				return nil, newError(ctx, twirp.Aborted, fmt.Sprintf("Blog id: %v kept changing while it was being updated, retry", data.Id))
+			}
+			continue
+		}
+
+		revision := newRevision(ctx, current, now)
+		if _, err := Revisions.InsertOne(ctx, revisionItem(oid, revision)); err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("Blog id: %v was updated but its revision could not be saved: %v", data.Id, err))
+		}
+
+		record := current
+		if fields.Title {
+			record.Title = data.Title
+		}
+		if fields.Content {
+			record.Content = data.Content
+		}
+		if fields.Tags {
+			record.Tags = labels.Tags
+		}
+		if fields.Category {
+			record.Category = labels.Category
+		}
+		if fields.Slug {
+			record.Slug = slug
+		}
+		if fields.ContentFormat {
+			record.ContentFormat = format
+		}
+		record.UpdateTime = now
+		record.Version++
+
+		return record.updateResponse(), nil
+	}
 }
 
-func (m MongoClient) DeleteBlog(data *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
+func (m MongoClient) DeleteBlog(ctx context.Context, data *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
 	oid, err := primitive.ObjectIDFromHex(data.Id)
 	if err != nil {
 		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
 	}
-	filter := bson.D{{Key: "_id", Value: oid}}
+	filter := bson.D{{Key: "_id", Value: oid}, notDeletedFilter}
+	if data.Version != 0 {
+		filter = append(filter, bson.E{Key: "version", Value: data.Version})
+	}
 
-	result, delete_err := Collection.DeleteOne(context.TODO(), filter)
-	if delete_err != nil || result.DeletedCount != 1 {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v", data.Id))
+	// blogs are moved to the trash, PurgeDeletedBlogs removes them for good after the retention
+	update := bson.D{
+		{Key: "$set", Value: bson.M{"delete_time": time.Now().Truncate(time.Millisecond)}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
+
+	result, delete_err := Collection.UpdateOne(ctx, filter, update)
+	if delete_err == nil && result.MatchedCount == 0 {
+		if conflict := mongoVersionConflict(ctx, oid, data.Version); conflict != nil {
+			return nil, conflict
+		}
+	}
+	if delete_err != nil || result.MatchedCount != 1 {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v", data.Id))
 	}
 
 	return &blogProto.DeleteBlogResponse{
@@ -139,39 +531,806 @@ func (m MongoClient) DeleteBlog(data *blogProto.DeleteBlogRequest) (*blogProto.D
 	}, nil
 }
 
-func (m MongoClient) ListBlog(data *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
-	filter := bson.D{}
+// inMongoTransaction runs fn in a session transaction, which needs a replica set or sharded cluster. fn runs
+// again when the transaction is retried after a transient error
+func inMongoTransaction(ctx context.Context, fn func(mongo.SessionContext) error) error {
+	session, err := Collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+// isDuplicateKey reports whether the write of one document of a bulk write was rejected by a unique index
+func isDuplicateKey(writeErr mongo.BulkWriteError) bool {
+	return mongo.IsDuplicateKeyError(mongo.WriteException{WriteErrors: mongo.WriteErrors{writeErr.WriteError}})
+}
+
+func (m MongoClient) BatchCreateBlogs(ctx context.Context, data *blogProto.BatchCreateBlogsRequest) (*blogProto.BatchCreateBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Requests), "requests"); err != nil {
+		return nil, err
+	}
+	now := time.Now().Truncate(time.Millisecond)
+	errs := make([]error, len(data.Requests))
+	// the items that passed the checks, and the position in the batch of each
+	items := []*BlogItem{}
+	positions := []int{}
+	for i, request := range data.Requests {
+		item, err := newBlogItem(ctx, request, now)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		items = append(items, &item)
+		positions = append(positions, i)
+	}
+	if data.AllOrNothing {
+		if err := firstFailure(errs); err != nil {
+			return nil, err
+		}
+	}
+	created := make([]*blogProto.CreateBlogResponse, len(data.Requests))
+	if len(items) == 0 {
+		return createResults(created, errs), nil
+	}
+
+	if err := assignMongoSlugs(ctx, items); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating blogs: %v", err))
+	}
+	documents := make([]interface{}, len(items))
+	for i, item := range items {
+		documents[i] = item
+	}
+
+	var err error
+	if data.AllOrNothing {
+		err = inMongoTransaction(ctx, func(sc mongo.SessionContext) error {
+			_, err := Collection.InsertMany(sc, documents)
+			return err
+		})
+	} else {
+		// unordered, so one rejected document does not stop the others
+		_, err = Collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	}
+	bulkErr, ok := err.(mongo.BulkWriteException)
+	if err != nil && (!ok || len(bulkErr.WriteErrors) == 0) {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating blogs: %v", err))
+	}
+	if data.AllOrNothing && err != nil {
+		writeErr := bulkErr.WriteErrors[0]
+		failure := newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", writeErr.Message))
+		if isDuplicateKey(writeErr) {
+			failure = newError(ctx, twirp.Aborted, "Another blog claimed the same slug, retry the batch")
+		}
+		return nil, BatchFailure(positions[writeErr.Index], failure)
+	}
+
+	failed := map[int]mongo.BulkWriteError{}
+	for _, writeErr := range bulkErr.WriteErrors {
+		failed[writeErr.Index] = writeErr
+	}
+	for j, item := range items {
+		i := positions[j]
+		writeErr, ok := failed[j]
+		switch {
+		case !ok:
+			created[i] = item.record().createResponse()
+		case isDuplicateKey(writeErr):
+			// another blog claimed the slug since it was found free, CreateBlog looks for one again
+			created[i], errs[i] = m.CreateBlog(ctx, data.Requests[i])
+		default:
+			errs[i] = newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", writeErr.Message))
+		}
+	}
+	return createResults(created, errs), nil
+}
+
+func (m MongoClient) BatchGetBlogs(ctx context.Context, data *blogProto.BatchGetBlogsRequest) (*blogProto.BatchGetBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Ids), "ids"); err != nil {
+		return nil, err
+	}
+	errs := make([]error, len(data.Ids))
+	parsed := make([]primitive.ObjectID, len(data.Ids))
+	oids := bson.A{}
+	for i, id := range data.Ids {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			errs[i] = twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+			continue
+		}
+		parsed[i] = oid
+		oids = append(oids, oid)
+	}
+
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: oids}}}}
+	if !data.ShowDeleted {
+		filter = append(filter, notDeletedFilter)
+	}
+	cursor, err := Collection.Find(ctx, filter)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error finding blogs: %v", err))
+	}
+	var items []BlogItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error finding blogs: %v", err))
+	}
+	// keyed by the ObjectID rather than the id as given, whose hex digits may be uppercase
+	found := map[primitive.ObjectID]BlogItem{}
+	for _, item := range items {
+		found[item.Id] = item
+	}
+
+	blogs := make([]*blogProto.GetBlogResponse, len(data.Ids))
+	for i, id := range data.Ids {
+		if errs[i] != nil {
+			continue
+		}
+		item, ok := found[parsed[i]]
+		if !ok {
+			errs[i] = twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", id))
+			continue
+		}
+		blogs[i] = item.record().getResponse()
+	}
+	if data.AllOrNothing {
+		if err := firstFailure(errs); err != nil {
+			return nil, err
+		}
+	}
+	return getResults(blogs, errs), nil
+}
+
+func (m MongoClient) BatchDeleteBlogs(ctx context.Context, data *blogProto.BatchDeleteBlogsRequest) (*blogProto.BatchDeleteBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Requests), "requests"); err != nil {
+		return nil, err
+	}
+	errs := repeatedIds(data.Requests, canonicalMongoId)
+	if !data.AllOrNothing {
+		if err := deleteMongoBlogs(ctx, data.Requests, errs); err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error deleting blogs: %v", err))
+		}
+		return deleteResults(data.Requests, errs), nil
+	}
+	if err := firstFailure(errs); err != nil {
+		return nil, err
+	}
+
+	err := inMongoTransaction(ctx, func(sc mongo.SessionContext) error {
+		// a retried transaction starts over with every item
+		attempt := make([]error, len(data.Requests))
+		if err := deleteMongoBlogs(sc, data.Requests, attempt); err != nil {
+			return err
+		}
+		return firstFailure(attempt)
+	})
+	if _, ok := err.(twirp.Error); ok {
+		return nil, err
+	}
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error deleting blogs: %v", err))
+	}
+	return deleteResults(data.Requests, errs), nil
+}
+
+// canonicalMongoId is the lowercase hex of an ObjectID given in either case, ids that are not ObjectIDs are kept
+// as they are and fail later
+func canonicalMongoId(id string) string {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return id
+	}
+	return oid.Hex()
+}
+
+// deleteMongoBlogs moves the blogs of requests to the trash with one $in query checking them and one update, and
+// records in errs why each of those whose error is still nil could not be deleted
+func deleteMongoBlogs(ctx context.Context, requests []*blogProto.DeleteBlogRequest, errs []error) error {
+	oids := map[int]primitive.ObjectID{}
+	ids := bson.A{}
+	for i, request := range requests {
+		if errs[i] != nil {
+			continue
+		}
+		oid, err := primitive.ObjectIDFromHex(request.Id)
+		if err != nil {
+			errs[i] = twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+			continue
+		}
+		oids[i] = oid
+		ids = append(ids, oid)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	filter := bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, notDeletedFilter}
+	cursor, err := Collection.Find(ctx, filter, options.Find().SetProjection(bson.D{{Key: "version", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	var items []BlogItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return err
+	}
+	versions := map[primitive.ObjectID]int64{}
+	for _, item := range items {
+		versions[item.Id] = item.record().Version
+	}
+
+	// each blog is deleted only if it is still at the version it was checked at, when the request has one
+	matches := bson.A{}
+	matched := []int{}
+	for i, oid := range oids {
+		version, ok := versions[oid]
+		switch {
+		case !ok:
+			errs[i] = twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v", requests[i].Id))
+		case requests[i].Version != 0 && requests[i].Version != version:
+			errs[i] = versionConflict(requests[i].Id, requests[i].Version, version)
+		default:
+			match := bson.D{{Key: "_id", Value: oid}}
+			if requests[i].Version != 0 {
+				match = append(match, bson.E{Key: "version", Value: version})
+			}
+			matches = append(matches, match)
+			matched = append(matched, i)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	update := bson.D{
+		{Key: "$set", Value: bson.M{"delete_time": now}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
+	result, err := Collection.UpdateMany(ctx, bson.D{{Key: "$or", Value: matches}, notDeletedFilter}, update)
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount == int64(len(matched)) {
+		return nil
+	}
+
+	// some blogs changed between the check and the update, those the update missed were not deleted
+	ids = bson.A{}
+	for _, i := range matched {
+		ids = append(ids, oids[i])
+	}
+	filter = bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, {Key: "delete_time", Value: now}}
+	cursor, err = Collection.Find(ctx, filter, options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	items = nil
+	if err := cursor.All(ctx, &items); err != nil {
+		return err
+	}
+	deleted := map[primitive.ObjectID]bool{}
+	for _, item := range items {
+		deleted[item.Id] = true
+	}
+	for _, i := range matched {
+		if !deleted[oids[i]] {
+			errs[i] = twirp.NewError(twirp.Aborted, fmt.Sprintf("Blog id: %v changed while it was being deleted, retry", requests[i].Id))
+		}
+	}
+	return nil
+}
+
+func (m MongoClient) UndeleteBlog(ctx context.Context, data *blogProto.UndeleteBlogRequest) (*blogProto.UndeleteBlogResponse, error) {
+	oid, err := primitive.ObjectIDFromHex(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	filter := bson.D{{Key: "_id", Value: oid}, deletedFilter}
+	if data.Version != 0 {
+		filter = append(filter, bson.E{Key: "version", Value: data.Version})
+	}
+	update := bson.D{
+		{Key: "$unset", Value: bson.M{"delete_time": ""}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
+	options := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	result := BlogItem{}
+	update_err := Collection.FindOneAndUpdate(ctx, filter, update, options).Decode(&result)
+	if update_err == mongo.ErrNoDocuments {
+		// find out whether the blog is missing, not deleted or at another version
+		current := BlogItem{}
+		if err := Collection.FindOne(ctx, bson.D{{Key: "_id", Value: oid}}).Decode(&current); err != nil {
+			return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", data.Id))
+		}
+		if current.DeleteTime.IsZero() {
+			return nil, notDeleted(data.Id)
+		}
+		return nil, versionConflict(data.Id, data.Version, current.record().Version)
+	}
+	if update_err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be restored: %v", data.Id, update_err))
+	}
+
+	return result.record().undeleteResponse(), nil
+}
+
+func (m MongoClient) PublishBlog(ctx context.Context, data *blogProto.PublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	status, publishTime, err := publishStatus(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return m.setStatus(ctx, data.Id, data.Version, status, publishTime.Truncate(time.Millisecond))
+}
+
+func (m MongoClient) UnpublishBlog(ctx context.Context, data *blogProto.UnpublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	return m.setStatus(ctx, data.Id, data.Version, unpublishStatus(data), time.Time{})
+}
+
+// setStatus changes the status of blog id, and its publish_time unless publishTime is zero
+func (m MongoClient) setStatus(ctx context.Context, id string, version int64, status blogProto.BlogStatus, publishTime time.Time) (*blogProto.UpdateBlogResponse, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+	filter := bson.D{{Key: "_id", Value: oid}, notDeletedFilter}
+	if version != 0 {
+		filter = append(filter, bson.E{Key: "version", Value: version})
+	}
+
+	set := bson.M{"status": status}
+	if !publishTime.IsZero() {
+		set["publish_time"] = publishTime
+	}
+	update := bson.D{
+		{Key: "$set", Value: set},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
+	options := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	result := BlogItem{}
+	update_err := Collection.FindOneAndUpdate(ctx, filter, update, options).Decode(&result)
+	if update_err == mongo.ErrNoDocuments {
+		if conflict := mongoVersionConflict(ctx, oid, version); conflict != nil {
+			return nil, conflict
+		}
+	}
+	if update_err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be changed to %v, no matching rows", id, status))
+	}
+
+	return result.record().updateResponse(), nil
+}
+
+func (m MongoClient) PublishScheduledBlogs(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.D{
+		{Key: "status", Value: blogProto.BlogStatus_BLOG_STATUS_SCHEDULED},
+		{Key: "publish_time", Value: bson.D{{Key: "$lte", Value: now}}},
+		notDeletedFilter,
+	}
+	update := bson.D{
+		{Key: "$set", Value: bson.M{"status": blogProto.BlogStatus_BLOG_STATUS_PUBLISHED}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
+	}
+	result, err := Collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+func (m MongoClient) CountPublishedBlogs(ctx context.Context) (int64, error) {
+	return Collection.CountDocuments(ctx, bson.D{notDeletedFilter, publishedFilter})
+}
+
+func (m MongoClient) WalkPublishedBlogs(ctx context.Context, from string, to string, limit int64, fn SitemapFunc) error {
+	bounds := bson.D{}
+	if from != "" {
+		start, err := primitive.ObjectIDFromHex(from)
+		if err != nil {
+			return invalidSitemapBound("from", from)
+		}
+		bounds = append(bounds, bson.E{Key: "$gte", Value: start})
+	}
+	if to != "" {
+		end, err := primitive.ObjectIDFromHex(to)
+		if err != nil {
+			return invalidSitemapBound("to", to)
+		}
+		bounds = append(bounds, bson.E{Key: "$lt", Value: end})
+	}
+	filter := bson.D{notDeletedFilter, publishedFilter}
+	if len(bounds) > 0 {
+		filter = append(filter, bson.E{Key: "_id", Value: bounds})
+	}
+
+	// unlike ListBlog, documents are decoded one batch at a time and without their content, a limit of 0 is none
+	options := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit).
+		SetBatchSize(1000).
+		SetProjection(bson.M{"slug": 1, "create_time": 1, "update_time": 1, "publish_time": 1})
+	cursor, err := Collection.Find(ctx, filter, options)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		item := BlogItem{}
+		if err := cursor.Decode(&item); err != nil {
+			return err
+		}
+		record := item.record()
+		if err := fn(record.Id, record.Slug, record.lastModified()); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (m MongoClient) PurgeDeletedBlogs(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	expired := bson.D{{Key: "delete_time", Value: bson.D{{Key: "$lt", Value: deletedBefore}}}}
+	ids, err := Collection.Distinct(ctx, "_id", expired)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	// the delete_time condition is repeated, so a blog restored since the ids were found is kept
+	result, err := Collection.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}, expired[0]})
+	if err != nil {
+		return 0, err
+	}
+
+	// remove the revisions of the purged blogs, i.e. of the ids that are gone now
+	kept, err := Collection.Distinct(ctx, "_id", bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}})
+	if err != nil {
+		return result.DeletedCount, err
+	}
+	_, err = Revisions.DeleteMany(ctx, bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "blog_id", Value: bson.D{{Key: "$in", Value: ids}}}},
+		bson.D{{Key: "blog_id", Value: bson.D{{Key: "$nin", Value: kept}}}},
+	}}})
+	return result.DeletedCount, err
+}
+
+func revisionItem(blogId primitive.ObjectID, revision revisionRecord) RevisionItem {
+	return RevisionItem{
+		BlogId:     blogId,
+		Version:    revision.Version,
+		Title:      revision.Title,
+		Content:    revision.Content,
+		CreateTime: revision.CreateTime,
+		ReviseTime: revision.ReviseTime,
+		RevisedBy:  revision.RevisedBy,
+	}
+}
+
+func (m MongoClient) ListBlogRevisions(ctx context.Context, data *blogProto.ListBlogRevisionsRequest) (*blogProto.ListBlogRevisionsResponse, error) {
+	oid, err := primitive.ObjectIDFromHex(data.BlogId)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+	query, err := newRevisionQuery(data)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.D{{Key: "blog_id", Value: oid}}
+	if query.Before != 0 {
+		filter = append(filter, bson.E{Key: "version", Value: bson.D{{Key: "$lt", Value: query.Before}}})
+	}
+	options := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	if query.Limit > 0 {
+		// fetch one extra revision to find out whether there is a next page
+		options.SetLimit(query.Limit + 1)
+	}
+
+	cursor, find_err := Revisions.Find(ctx, filter, options)
+	if find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing the revisions of blog id: %v, err: %v", data.BlogId, find_err))
+	}
+	var items []RevisionItem
+	if find_err := cursor.All(ctx, &items); find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error reading the revisions of blog id: %v, err: %v", data.BlogId, find_err))
+	}
+
+	revisions := []revisionRecord{}
+	for _, item := range items {
+		revisions = append(revisions, item.record())
+	}
+	return query.page(revisions), nil
+}
+
+func (m MongoClient) GetBlogRevision(ctx context.Context, data *blogProto.GetBlogRevisionRequest) (*blogProto.BlogRevision, error) {
+	oid, err := primitive.ObjectIDFromHex(data.BlogId)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	item := RevisionItem{}
+	find_err := Revisions.FindOne(ctx, bson.D{{Key: "blog_id", Value: oid}, {Key: "version", Value: data.Version}}).Decode(&item)
+	if find_err == mongo.ErrNoDocuments {
+		return nil, revisionNotFound(data.BlogId, data.Version)
+	}
+	if find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error getting revision %d of blog id: %v, err: %v", data.Version, data.BlogId, find_err))
+	}
+
+	return item.record().response(), nil
+}
+
+// mongoVersionConflict explains why a versioned write matched no document: it returns a conflict
+// when the blog exists at another version, or nil when the blog does not exist at all
+func mongoVersionConflict(ctx context.Context, oid primitive.ObjectID, expected int64) error {
+	if expected == 0 {
+		return nil
+	}
+	current := BlogItem{}
+	if err := Collection.FindOne(ctx, bson.D{{Key: "_id", Value: oid}, notDeletedFilter}).Decode(&current); err != nil {
+		return nil
+	}
+	return versionConflict(oid.Hex(), expected, current.record().Version)
+}
+
+func (m MongoClient) ListBlog(ctx context.Context, data *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
+	query, err := newListQuery(data, false)
+	if err != nil {
+		return nil, err
+	}
+	blogs, nextPageToken, err := m.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blogProto.ListBlogResponse{
+		Blogs:         blogs,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (m MongoClient) ListDeletedBlogs(ctx context.Context, data *blogProto.ListDeletedBlogsRequest) (*blogProto.ListDeletedBlogsResponse, error) {
+	query, err := newDeletedListQuery(data)
+	if err != nil {
+		return nil, err
+	}
+	blogs, nextPageToken, err := m.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blogProto.ListDeletedBlogsResponse{
+		Blogs:         blogs,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// list returns one page of the blogs matching query and the token of the next page
+func (m MongoClient) list(ctx context.Context, query listQuery) ([]*blogProto.CreateBlogResponse, string, error) {
+	filter, err := mongoListFilter(query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// ObjectIDs grow with insertion time, so _id both orders by create_time and breaks ties on the other fields
+	direction := 1
+	if query.Order.Desc {
+		direction = -1
+	}
+	sort := bson.D{{Key: "_id", Value: direction}}
+	if !query.Order.ByCreateTime() {
+		sort = bson.D{{Key: query.Order.Field, Value: direction}, {Key: "_id", Value: direction}}
+	}
 
 	options := &options.FindOptions{
-		Limit: &data.Limit,
+		Sort: sort,
+	}
+	if query.Limit > 0 {
+		// fetch one extra blog to find out whether there is a next page
+		limit := query.Limit + 1
+		options.Limit = &limit
 	}
 
 	var results []BlogItem
 
-	cursor, find_err := Collection.Find(context.TODO(), filter, options)
+	cursor, find_err := Collection.Find(ctx, filter, options)
 	if find_err != nil {
 		if find_err == mongo.ErrNoDocuments {
-			return nil, twirp.NewError(twirp.NotFound, "No documents were found")
+			return nil, "", twirp.NewError(twirp.NotFound, "No documents were found")
 		}
-		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("There was an error listing blogs: %v", find_err))
+		return nil, "", newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error listing blogs: %v", find_err))
 	}
 
-	if find_err := cursor.All(context.TODO(), &results); find_err != nil {
-		fmt.Printf("error: %v", find_err)
+	if find_err := cursor.All(ctx, &results); find_err != nil {
+		return nil, "", newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error reading listed blogs: %v", find_err))
 	}
 
 	blogs := []*blogProto.CreateBlogResponse{}
 
 	for _, result := range results {
-		blog := blogProto.CreateBlogResponse{
-			Id:      result.Id.Hex(),
-			Title:   result.Title,
-			Content: result.Content,
+		blogs = append(blogs, result.record().createResponse())
+	}
+
+	nextPageToken := ""
+	if query.Limit > 0 && int64(len(blogs)) > query.Limit {
+		blogs = blogs[:query.Limit]
+		nextPageToken = query.nextPageToken(blogs[len(blogs)-1])
+	}
+
+	return blogs, nextPageToken, nil
+}
+
+// mongoListFilter translates a listQuery, including its page token, into a bson filter
+func mongoListFilter(query listQuery) (bson.D, error) {
+	conditions := bson.A{bson.D{notDeletedFilter}}
+	if query.Deleted {
+		conditions = bson.A{bson.D{deletedFilter}}
+	}
+
+	if query.TitlePrefix != "" {
+		// an anchored, case-sensitive regex can use the title index
+		conditions = append(conditions, bson.D{{Key: "title", Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(query.TitlePrefix)}}})
+	}
+	if query.TitleContains != "" {
+		conditions = append(conditions, bson.D{{Key: "title", Value: primitive.Regex{Pattern: regexp.QuoteMeta(query.TitleContains), Options: "i"}}})
+	}
+
+	// the creation time is embedded in the ObjectID, with second precision
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: primitive.NewObjectIDFromTimestamp(*query.CreatedAfter)}}}})
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, bson.D{{Key: "_id", Value: bson.D{{Key: "$lt", Value: primitive.NewObjectIDFromTimestamp(*query.CreatedBefore)}}}})
+	}
+	if query.AuthorId != "" {
+		conditions = append(conditions, bson.D{{Key: "author_id", Value: query.AuthorId}})
+	}
+	if query.Category != "" {
+		conditions = append(conditions, bson.D{{Key: "category", Value: query.Category}})
+	}
+	if query.Status == blogProto.BlogStatus_BLOG_STATUS_PUBLISHED {
+		conditions = append(conditions, bson.D{publishedFilter})
+	} else if query.Status != blogProto.BlogStatus_BLOG_STATUS_UNSPECIFIED {
+		conditions = append(conditions, bson.D{{Key: "status", Value: query.Status}})
+	}
+	if len(query.Tags) > 0 {
+		// both operators use the multikey tags index
+		operator := "$in"
+		if query.AllTags {
+			operator = "$all"
 		}
-		blogs = append(blogs, &blog)
+		conditions = append(conditions, bson.D{{Key: "tags", Value: bson.D{{Key: operator, Value: query.Tags}}}})
 	}
 
-	return &blogProto.ListBlogResponse{
-		Blogs: blogs,
+	if query.Token.After != "" {
+		after, err := primitive.ObjectIDFromHex(query.Token.After)
+		if err != nil {
+			return nil, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+		comparison := "$gt"
+		if query.Order.Desc {
+			comparison = "$lt"
+		}
+		switch query.Order.Field {
+		case "create_time":
+			conditions = append(conditions, bson.D{{Key: "_id", Value: bson.D{{Key: comparison, Value: after}}}})
+		case "title":
+			conditions = append(conditions, bson.D{{Key: "$or", Value: bson.A{
+				bson.D{{Key: "title", Value: bson.D{{Key: comparison, Value: query.Token.Title}}}},
+				bson.D{{Key: "title", Value: query.Token.Title}, {Key: "_id", Value: bson.D{{Key: comparison, Value: after}}}},
+			}}})
+		case "publish_time":
+			conditions = append(conditions, mongoPublishTimeAfter(query.Token.Published, after, query.Order.Desc))
+		}
+	}
+
+	return bson.D{{Key: "$and", Value: conditions}}, nil
+}
+
+// mongoPublishTimeAfter matches the blogs after the one published at published with id after in a publish_time
+// order. Comparisons never match a missing publish_time, which sorts before every date, so the blogs never
+// published are matched on their own.
+func mongoPublishTimeAfter(published *time.Time, after primitive.ObjectID, desc bool) bson.D {
+	unpublished := bson.E{Key: "publish_time", Value: nil}
+	switch {
+	case published == nil && desc:
+		return bson.D{unpublished, {Key: "_id", Value: bson.D{{Key: "$lt", Value: after}}}}
+	case published == nil:
+		return bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "publish_time", Value: bson.D{{Key: "$ne", Value: nil}}}},
+			bson.D{unpublished, {Key: "_id", Value: bson.D{{Key: "$gt", Value: after}}}},
+		}}}
+	case desc:
+		return bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "publish_time", Value: bson.D{{Key: "$lt", Value: *published}}}},
+			bson.D{{Key: "publish_time", Value: *published}, {Key: "_id", Value: bson.D{{Key: "$lt", Value: after}}}},
+			bson.D{unpublished},
+		}}}
+	default:
+		return bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "publish_time", Value: bson.D{{Key: "$gt", Value: *published}}}},
+			bson.D{{Key: "publish_time", Value: *published}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: after}}}},
+		}}}
+	}
+}
+
+func (m MongoClient) ListTags(ctx context.Context, data *blogProto.ListTagsRequest) (*blogProto.ListTagsResponse, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{notDeletedFilter, publishedFilter}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$tags"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if data.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: data.Limit}})
+	}
+
+	cursor, find_err := Collection.Aggregate(ctx, pipeline)
+	if find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing tags: %v", find_err))
+	}
+	var counts []struct {
+		Tag   string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if find_err := cursor.All(ctx, &counts); find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error reading tags: %v", find_err))
+	}
+
+	tags := []*blogProto.TagCount{}
+	for _, count := range counts {
+		tags = append(tags, &blogProto.TagCount{Tag: count.Tag, Count: count.Count})
+	}
+
+	return &blogProto.ListTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+func (m MongoClient) SearchBlogs(ctx context.Context, data *blogProto.SearchBlogsRequest) (*blogProto.SearchBlogsResponse, error) {
+	terms := searchTerms(data.Query)
+
+	// $text ORs space-separated words and ranks documents by the weights of the text index in mongoschema.go
+	filter := bson.D{{Key: "$text", Value: bson.D{{Key: "$search", Value: strings.Join(terms, " ")}}}, notDeletedFilter, publishedFilter}
+	textScore := bson.D{{Key: "$meta", Value: "textScore"}}
+
+	options := options.Find().
+		SetProjection(bson.D{{Key: "title", Value: 1}, {Key: "content", Value: 1}, {Key: "slug", Value: 1}, {Key: "score", Value: textScore}}).
+		SetSort(bson.D{{Key: "score", Value: textScore}, {Key: "_id", Value: 1}})
+	if data.Limit > 0 {
+		options.SetLimit(data.Limit)
+	}
+
+	cursor, find_err := Collection.Find(ctx, filter, options)
+	if find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error searching blogs: %v", find_err))
+	}
+
+	var matches []struct {
+		BlogItem `bson:",inline"`
+		Score    float64 `bson:"score"`
+	}
+	if find_err := cursor.All(ctx, &matches); find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error reading search results: %v", find_err))
+	}
+
+	results := []*blogProto.SearchBlogResult{}
+	for _, match := range matches {
+		results = append(results, &blogProto.SearchBlogResult{
+			Id:      match.Id.Hex(),
+			Title:   match.Title,
+			Snippet: buildSnippet(match.Content, terms),
+			Score:   match.Score,
+			Slug:    match.Slug,
+		})
+	}
+
+	return &blogProto.SearchBlogsResponse{
+		Results: results,
 	}, nil
 }
@@ -0,0 +1,238 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoCommentStore keeps comments in the Comments collection, which MongoClient sets up on Connect
+type MongoCommentStore struct{}
+
+func NewMongoCommentStore() MongoCommentStore {
+	return MongoCommentStore{}
+}
+
+type CommentItem struct {
+	Id     primitive.ObjectID `bson:"_id"`
+	BlogId primitive.ObjectID `bson:"blog_id"`
+	// missing on top-level comments
+	ParentId   primitive.ObjectID `bson:"parent_id,omitempty"`
+	AuthorId   string             `bson:"author_id,omitempty"`
+	Content    string             `bson:"content"`
+	CreateTime time.Time          `bson:"create_time"`
+	UpdateTime time.Time          `bson:"update_time"`
+	DeleteTime time.Time          `bson:"delete_time,omitempty"`
+	// only set while the blog is in the trash
+	OrphanTime time.Time `bson:"orphan_time,omitempty"`
+}
+
+func (c CommentItem) record() commentRecord {
+	parentId := ""
+	if !c.ParentId.IsZero() {
+		parentId = c.ParentId.Hex()
+	}
+	return commentRecord{
+		Id:         c.Id.Hex(),
+		BlogId:     c.BlogId.Hex(),
+		ParentId:   parentId,
+		AuthorId:   c.AuthorId,
+		Content:    c.Content,
+		CreateTime: c.CreateTime,
+		UpdateTime: c.UpdateTime,
+		DeleteTime: c.DeleteTime,
+	}
+}
+
+// notOrphanedFilter matches the comments of blogs outside the trash
+var notOrphanedFilter = bson.E{Key: "orphan_time", Value: nil}
+
+func (m MongoCommentStore) CreateComment(ctx context.Context, data *blogProto.CreateCommentRequest) (*blogProto.Comment, error) {
+	blogId, err := primitive.ObjectIDFromHex(data.BlogId)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+	content, err := validateCommentContent(data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	item := CommentItem{
+		Id:       primitive.NewObjectID(),
+		BlogId:   blogId,
+		AuthorId: authorOf(ctx),
+		Content:  content,
+	}
+	if data.ParentId != "" {
+		parentId, err := primitive.ObjectIDFromHex(data.ParentId)
+		if err != nil {
+			return nil, parentNotFound(data.ParentId, data.BlogId)
+		}
+		count, err := Comments.CountDocuments(ctx, bson.D{{Key: "_id", Value: parentId}, {Key: "blog_id", Value: blogId}, notOrphanedFilter})
+		if err != nil || count == 0 {
+			return nil, parentNotFound(data.ParentId, data.BlogId)
+		}
+		item.ParentId = parentId
+	}
+
+	// BSON dates have millisecond precision, truncating keeps the response equal to what is stored
+	item.CreateTime = time.Now().Truncate(time.Millisecond)
+	item.UpdateTime = item.CreateTime
+
+	if _, err := Comments.InsertOne(ctx, item); err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a comment: %v", err))
+	}
+
+	return item.record().response(), nil
+}
+
+func (m MongoCommentStore) GetComment(ctx context.Context, data *blogProto.GetCommentRequest) (*blogProto.Comment, error) {
+	oid, err := primitive.ObjectIDFromHex(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid comment ID")
+	}
+
+	item := CommentItem{}
+	find_err := Comments.FindOne(ctx, bson.D{{Key: "_id", Value: oid}, notOrphanedFilter}).Decode(&item)
+	if find_err == mongo.ErrNoDocuments {
+		return nil, commentNotFound(data.Id)
+	}
+	if find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error finding a comment with ID: %v \nError: %v", data.Id, find_err))
+	}
+
+	return item.record().response(), nil
+}
+
+func (m MongoCommentStore) ListComments(ctx context.Context, data *blogProto.ListCommentsRequest) (*blogProto.ListCommentsResponse, error) {
+	blogId, err := primitive.ObjectIDFromHex(data.BlogId)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+	query, err := newCommentQuery(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// a nil parent_id matches the top-level comments, which have no parent_id field
+	filter := bson.D{{Key: "blog_id", Value: blogId}, {Key: "parent_id", Value: nil}, notOrphanedFilter}
+	if query.ParentId != "" {
+		parentId, err := primitive.ObjectIDFromHex(query.ParentId)
+		if err != nil {
+			return nil, twirp.NewError(twirp.InvalidArgument, "Invalid parent ID")
+		}
+		filter[1].Value = parentId
+	}
+	if query.After != "" {
+		after, err := primitive.ObjectIDFromHex(query.After)
+		if err != nil {
+			return nil, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: after}}})
+	}
+
+	options := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	if query.Limit > 0 {
+		// fetch one extra comment to find out whether there is a next page
+		options.SetLimit(query.Limit + 1)
+	}
+
+	cursor, find_err := Comments.Find(ctx, filter, options)
+	if find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing comments: %v", find_err))
+	}
+	var items []CommentItem
+	if find_err := cursor.All(ctx, &items); find_err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error reading listed comments: %v", find_err))
+	}
+
+	comments := []commentRecord{}
+	for _, item := range items {
+		comments = append(comments, item.record())
+	}
+	return query.page(comments), nil
+}
+
+func (m MongoCommentStore) UpdateComment(ctx context.Context, data *blogProto.UpdateCommentRequest) (*blogProto.Comment, error) {
+	oid, err := primitive.ObjectIDFromHex(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid comment ID")
+	}
+	content, err := validateCommentContent(data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.D{{Key: "_id", Value: oid}, notOrphanedFilter, {Key: "delete_time", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.M{"content": content, "update_time": time.Now().Truncate(time.Millisecond)}}}
+	options := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	item := CommentItem{}
+	update_err := Comments.FindOneAndUpdate(ctx, filter, update, options).Decode(&item)
+	if update_err == mongo.ErrNoDocuments {
+		// find out whether the comment is missing or deleted
+		if _, err := m.GetComment(ctx, &blogProto.GetCommentRequest{Id: data.Id}); err != nil {
+			return nil, err
+		}
+		return nil, twirp.NewError(twirp.FailedPrecondition, "A deleted comment cannot be edited")
+	}
+	if update_err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Comment id: %v could not be updated: %v", data.Id, update_err))
+	}
+
+	return item.record().response(), nil
+}
+
+func (m MongoCommentStore) DeleteComment(ctx context.Context, data *blogProto.DeleteCommentRequest) (*blogProto.DeleteCommentResponse, error) {
+	oid, err := primitive.ObjectIDFromHex(data.Id)
+	if err != nil {
+		return nil, twirp.NewError(twirp.InvalidArgument, "Invalid comment ID")
+	}
+
+	// the document stays as a tombstone so its replies keep their parent, an already deleted comment matches
+	// nothing and is reported as deleted below
+	filter := bson.D{{Key: "_id", Value: oid}, notOrphanedFilter, {Key: "delete_time", Value: nil}}
+	update := bson.D{{Key: "$set", Value: bson.M{"content": "", "delete_time": time.Now().Truncate(time.Millisecond)}}}
+	result, delete_err := Comments.UpdateOne(ctx, filter, update)
+	if delete_err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Unable to delete comment with ID: %v", data.Id))
+	}
+	if result.MatchedCount == 0 {
+		if _, err := m.GetComment(ctx, &blogProto.GetCommentRequest{Id: data.Id}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &blogProto.DeleteCommentResponse{
+		Id: data.Id,
+	}, nil
+}
+
+func (m MongoCommentStore) OrphanComments(ctx context.Context, blogId string, orphaned bool) error {
+	oid, err := primitive.ObjectIDFromHex(blogId)
+	if err != nil {
+		return twirp.NewError(twirp.InvalidArgument, "Invalid blog ID")
+	}
+
+	update := bson.D{{Key: "$unset", Value: bson.M{"orphan_time": ""}}}
+	if orphaned {
+		update = bson.D{{Key: "$set", Value: bson.M{"orphan_time": time.Now().Truncate(time.Millisecond)}}}
+	}
+	_, err = Comments.UpdateMany(ctx, bson.D{{Key: "blog_id", Value: oid}}, update)
+	return err
+}
+
+func (m MongoCommentStore) PurgeOrphanedComments(ctx context.Context, orphanedBefore time.Time) (int64, error) {
+	result, err := Comments.DeleteMany(ctx, bson.D{{Key: "orphan_time", Value: bson.D{{Key: "$lt", Value: orphanedBefore}}}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,349 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+/*
+ensureMongoSchema makes a fresh MongoDB usable with no manual steps: it creates the blog collection
+with a JSON-schema validator (or updates the validator on an existing collection) and creates the
+declared indexes. Index drift, i.e. an index whose keys differ from its declaration or an index nobody
+declared, is reported rather than fixed, since dropping indexes on a live collection is an ops decision.
+*/
+
+// blogValidator mirrors the fields of BlogItem that every document must have
+var blogValidator = bson.M{
+	"$jsonSchema": bson.M{
+		"bsonType": "object",
+		"required": []string{"title", "content"},
+		"properties": bson.M{
+			"title": bson.M{
+				"bsonType":    "string",
+				"description": "title must be a string",
+			},
+			"content": bson.M{
+				"bsonType":    "string",
+				"description": "content must be a string",
+			},
+			"author_id": bson.M{
+				"bsonType":    "string",
+				"description": "author_id must be a string",
+			},
+			"delete_time": bson.M{
+				"bsonType":    "date",
+				"description": "delete_time must be a date",
+			},
+			"tags": bson.M{
+				"bsonType":    "array",
+				"items":       bson.M{"bsonType": "string"},
+				"description": "tags must be an array of strings",
+			},
+			"category": bson.M{
+				"bsonType":    "string",
+				"description": "category must be a string",
+			},
+			"status": bson.M{
+				"bsonType":    "int",
+				"minimum":     1,
+				"description": "status must be a BlogStatus",
+			},
+			"publish_time": bson.M{
+				"bsonType":    "date",
+				"description": "publish_time must be a date",
+			},
+			"slug": bson.M{
+				"bsonType":    "string",
+				"description": "slug must be a string",
+			},
+			"slugs": bson.M{
+				"bsonType":    "array",
+				"items":       bson.M{"bsonType": "string"},
+				"description": "slugs must be an array of strings",
+			},
+			"content_format": bson.M{
+				"bsonType":    "int",
+				"minimum":     1,
+				"maximum":     3,
+				"description": "content_format must be a ContentFormat",
+			},
+			"version": bson.M{
+				"bsonType":    "long",
+				"minimum":     1,
+				"description": "version must be a positive 64-bit integer",
+			},
+		},
+	},
+}
+
+// blogIndexes are the indexes the blog collection should have, each with an explicit name so drift can be detected
+var blogIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "title", Value: 1}},
+		Options: options.Index().SetName("title_1"),
+	},
+	{
+		// used by SearchBlogs, title matches weigh double
+		Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}},
+		Options: options.Index().SetName("title_text_content_text").SetWeights(bson.D{{Key: "title", Value: 2}, {Key: "content", Value: 1}}),
+	},
+	{
+		// serves ListBlog's author_id filter in _id order
+		Keys:    bson.D{{Key: "author_id", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("author_id_1__id_1"),
+	},
+	{
+		// multikey, serves ListBlog's tag filters and ListTags
+		Keys:    bson.D{{Key: "tags", Value: 1}},
+		Options: options.Index().SetName("tags_1"),
+	},
+	{
+		Keys:    bson.D{{Key: "category", Value: 1}},
+		Options: options.Index().SetName("category_1"),
+	},
+	{
+		// multikey, serves GetBlogBySlug and keeps every slug, current or previous, to a single blog. Sparse so
+		// documents are indexed only once ensureMongoSchema has given them a slug
+		Keys:    bson.D{{Key: "slugs", Value: 1}},
+		Options: options.Index().SetName("slugs_1").SetUnique(true).SetSparse(true),
+	},
+	{
+		// serves the status filter of ListBlog and, with publish_time, the scheduler
+		Keys:    bson.D{{Key: "status", Value: 1}, {Key: "publish_time", Value: 1}},
+		Options: options.Index().SetName("status_1_publish_time_1"),
+	},
+	{
+		// serves the purge job and ListDeletedBlogs, sparse since only blogs in the trash have a delete_time
+		Keys:    bson.D{{Key: "delete_time", Value: 1}},
+		Options: options.Index().SetName("delete_time_1").SetSparse(true),
+	},
+}
+
+// indexSpec is the comparable part of an index: its name and its keys, e.g. "title:1"
+type indexSpec struct {
+	Name string
+	Keys string
+}
+
+// specOf normalizes declared keys to the form MongoDB lists them in, where all the fields of a
+// text index are replaced by the single pair _fts:text,_ftsx:1 (their weights are not compared)
+func specOf(name string, keys bson.D) indexSpec {
+	parts := []string{}
+	textSeen := false
+	for _, key := range keys {
+		if key.Value == "text" {
+			if !textSeen {
+				parts = append(parts, "_fts:text", "_ftsx:1")
+				textSeen = true
+			}
+			continue
+		}
+		if key.Key == "_ftsx" {
+			// already added along with _fts when listing an existing text index
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s:%v", key.Key, key.Value))
+	}
+	return indexSpec{Name: name, Keys: strings.Join(parts, ",")}
+}
+
+// diffIndexes returns the declared indexes that are missing and a description of every drifted index
+func diffIndexes(declared []indexSpec, existing []indexSpec) (missing []string, drift []string) {
+	existingByName := map[string]indexSpec{}
+	for _, spec := range existing {
+		existingByName[spec.Name] = spec
+	}
+	declaredByName := map[string]bool{}
+
+	for _, spec := range declared {
+		declaredByName[spec.Name] = true
+		current, ok := existingByName[spec.Name]
+		if !ok {
+			missing = append(missing, spec.Name)
+			continue
+		}
+		if current.Keys != spec.Keys {
+			drift = append(drift, fmt.Sprintf("index %s has keys {%s}, declared {%s}", spec.Name, current.Keys, spec.Keys))
+		}
+	}
+
+	for _, spec := range existing {
+		// every collection has the _id index, so it never counts as drift
+		if spec.Name != "_id_" && !declaredByName[spec.Name] {
+			drift = append(drift, fmt.Sprintf("index %s {%s} exists but is not declared", spec.Name, spec.Keys))
+		}
+	}
+
+	sort.Strings(missing)
+	sort.Strings(drift)
+	return missing, drift
+}
+
+func ensureMongoSchema(ctx context.Context, database *mongo.Database, name string) (*mongo.Collection, error) {
+	names, err := database.ListCollectionNames(ctx, bson.D{{Key: "name", Value: name}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list collections: %w", err)
+	}
+
+	if len(names) == 0 {
+		fmt.Printf("Creating MongoDB collection %s\n", name)
+		err = database.CreateCollection(ctx, name, options.CreateCollection().SetValidator(blogValidator))
+	} else {
+		err = database.RunCommand(ctx, bson.D{{Key: "collMod", Value: name}, {Key: "validator", Value: blogValidator}}).Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply validator to collection %s: %w", name, err)
+	}
+
+	collection := database.Collection(name)
+
+	// documents written before versions were stored start at version 1, so UpdateBlog's $inc moves them to 2
+	backfilled, err := collection.UpdateMany(ctx, bson.D{{Key: "version", Value: bson.D{{Key: "$exists", Value: false}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "version", Value: int64(1)}}}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to set the version of existing blogs: %w", err)
+	}
+	if backfilled.ModifiedCount > 0 {
+		fmt.Printf("Set version 1 on %d existing blogs in %s\n", backfilled.ModifiedCount, name)
+	}
+
+	// documents written before the draft workflow were live, so they are published as of their creation
+	published, err := collection.UpdateMany(ctx, bson.D{{Key: "status", Value: bson.D{{Key: "$exists", Value: false}}}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.D{
+			{Key: "status", Value: int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED)},
+			{Key: "publish_time", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$create_time", bson.D{{Key: "$toDate", Value: "$_id"}}}}}},
+		}}}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to set the status of existing blogs: %w", err)
+	}
+	if published.ModifiedCount > 0 {
+		fmt.Printf("Published %d existing blogs in %s\n", published.ModifiedCount, name)
+	}
+
+	existing, err := listIndexes(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := []indexSpec{}
+	models := map[string]mongo.IndexModel{}
+	for _, model := range blogIndexes {
+		spec := specOf(*model.Options.Name, model.Keys.(bson.D))
+		declared = append(declared, spec)
+		models[spec.Name] = model
+	}
+
+	missing, drift := diffIndexes(declared, existing)
+	for _, description := range drift {
+		fmt.Printf("WARNING: MongoDB index drift on %s: %s\n", name, description)
+	}
+
+	// only missing indexes are created, since recreating a drifted one under the same name would fail
+	toCreate := []mongo.IndexModel{}
+	for _, indexName := range missing {
+		toCreate = append(toCreate, models[indexName])
+	}
+	if len(toCreate) > 0 {
+		created, err := collection.Indexes().CreateMany(ctx, toCreate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create indexes on %s: %w", name, err)
+		}
+		fmt.Printf("Created MongoDB indexes on %s: %v\n", name, created)
+	}
+
+	if err := backfillMongoSlugs(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// backfillMongoSlugs generates the slugs of documents written before slugs were stored, after the unique
+// slugs index exists so two of them cannot get the same slug
+func backfillMongoSlugs(ctx context.Context, collection *mongo.Collection) error {
+	cursor, err := collection.Find(ctx, bson.D{{Key: "slug", Value: bson.D{{Key: "$exists", Value: false}}}},
+		options.Find().SetProjection(bson.D{{Key: "title", Value: 1}}).SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return fmt.Errorf("unable to find blogs without a slug: %w", err)
+	}
+	var items []BlogItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return fmt.Errorf("unable to find blogs without a slug: %w", err)
+	}
+
+	for _, item := range items {
+		slug, err := freeMongoSlug(ctx, collection, item.Id, slugify(item.Title))
+		if err == nil {
+			_, err = collection.UpdateOne(ctx, bson.D{{Key: "_id", Value: item.Id}},
+				bson.D{{Key: "$set", Value: bson.M{"slug": slug}}, {Key: "$addToSet", Value: bson.M{"slugs": slug}}})
+		}
+		if err != nil {
+			return fmt.Errorf("unable to generate the slug of blog %v: %w", item.Id.Hex(), err)
+		}
+	}
+	if len(items) > 0 {
+		fmt.Printf("Generated slugs for %d existing blogs in %s\n", len(items), collection.Name())
+	}
+	return nil
+}
+
+// revisionIndexes are the indexes of the revisions collection, the unique key of a revision
+// is its blog and the version it replaced, and ListBlogRevisions reads them newest first
+var revisionIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "blog_id", Value: 1}, {Key: "version", Value: -1}},
+		Options: options.Index().SetName("blog_id_1_version_-1").SetUnique(true),
+	},
+}
+
+// commentIndexes are the indexes of the comments collection
+var commentIndexes = []mongo.IndexModel{
+	{
+		// serves ListComments, one thread level of one blog in _id order
+		Keys:    bson.D{{Key: "blog_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "_id", Value: 1}},
+		Options: options.Index().SetName("blog_id_1_parent_id_1__id_1"),
+	},
+	{
+		// serves the purge job, sparse since only comments of blogs in the trash have an orphan_time
+		Keys:    bson.D{{Key: "orphan_time", Value: 1}},
+		Options: options.Index().SetName("orphan_time_1").SetSparse(true),
+	},
+}
+
+// ensureMongoIndexes creates the indexes of a collection that, unlike the blog collection, has no validator
+// or drift reporting. Creating an index that already exists is a no-op
+func ensureMongoIndexes(ctx context.Context, database *mongo.Database, name string, indexes []mongo.IndexModel) (*mongo.Collection, error) {
+	collection := database.Collection(name)
+	if _, err := collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return nil, fmt.Errorf("unable to create indexes on %s: %w", name, err)
+	}
+	return collection, nil
+}
+
+func listIndexes(ctx context.Context, collection *mongo.Collection) ([]indexSpec, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list indexes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	existing := []indexSpec{}
+	for cursor.Next(ctx) {
+		var index struct {
+			Name string `bson:"name"`
+			Key  bson.D `bson:"key"`
+		}
+		if err := cursor.Decode(&index); err != nil {
+			return nil, fmt.Errorf("unable to read index: %w", err)
+		}
+		existing = append(existing, specOf(index.Name, index.Key))
+	}
+	return existing, cursor.Err()
+}
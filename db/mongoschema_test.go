@@ -0,0 +1,50 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSpecOf(t *testing.T) {
+	spec := specOf("title_1", bson.D{{Key: "title", Value: int32(1)}, {Key: "_id", Value: -1}})
+	require.Equal(t, indexSpec{Name: "title_1", Keys: "title:1,_id:-1"}, spec)
+
+	// text index fields collapse into the keys MongoDB reports for them
+	spec = specOf("search", bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}})
+	require.Equal(t, indexSpec{Name: "search", Keys: "_fts:text,_ftsx:1"}, spec)
+	require.Equal(t, spec, specOf("search", bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: int32(1)}}))
+}
+
+func TestDiffIndexes(t *testing.T) {
+	declared := []indexSpec{
+		{Name: "title_1", Keys: "title:1"},
+		{Name: "content_1", Keys: "content:1"},
+	}
+
+	missing, drift := diffIndexes(declared, []indexSpec{{Name: "_id_", Keys: "_id:1"}})
+	require.Equal(t, []string{"content_1", "title_1"}, missing)
+	require.Empty(t, drift)
+
+	missing, drift = diffIndexes(declared, []indexSpec{
+		{Name: "_id_", Keys: "_id:1"},
+		{Name: "title_1", Keys: "title:-1"},
+		{Name: "content_1", Keys: "content:1"},
+		{Name: "legacy", Keys: "author:1"},
+	})
+	require.Empty(t, missing)
+	require.Equal(t, []string{
+		"index legacy {author:1} exists but is not declared",
+		"index title_1 has keys {title:-1}, declared {title:1}",
+	}, drift)
+}
+
+// every declared index needs a name, otherwise ensureMongoSchema cannot compare it
+func TestBlogIndexesAreNamed(t *testing.T) {
+	for _, model := range blogIndexes {
+		require.NotNil(t, model.Options)
+		require.NotNil(t, model.Options.Name)
+		require.IsType(t, bson.D{}, model.Keys)
+	}
+}
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+/*
+Page tokens are opaque to clients: base64url-encoded JSON holding the sort key of the last blog on
+the previous page. Every order ends with the id as a tie-breaker (Mongo ObjectIDs and Postgres serials
+both grow with insertion), so continuing with "sort key after the last one" never repeats or skips a
+blog, even when blogs are inserted between pages.
+*/
+
+type pageToken struct {
+	// Order is the order_by the token was issued for, a token cannot be used with a different order
+	Order string `json:"order"`
+	After string `json:"after"`
+	// Title of the last blog, when ordering by title
+	Title string `json:"title,omitempty"`
+	// Published is the publish_time of the last blog when ordering by publish_time, unset if it has none
+	Published *time.Time `json:"published,omitempty"`
+	// Deleted is set on tokens from ListDeletedBlogs, which cannot continue a ListBlog and vice versa
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// publishTime is the publish_time of the last blog, or the zero time when it was never published
+func (t pageToken) publishTime() time.Time {
+	if t.Published == nil {
+		return time.Time{}
+	}
+	return *t.Published
+}
+
+func encodePageToken(token pageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken returns the zero pageToken, i.e. the first page, for an empty token
+func decodePageToken(token string) (pageToken, bool) {
+	decoded := pageToken{}
+	if token == "" {
+		return decoded, true
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return decoded, false
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.After == "" {
+		return decoded, false
+	}
+	return decoded, true
+}
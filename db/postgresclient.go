@@ -2,13 +2,17 @@ package db
 
 import (
 	blogProto "blog-service/rpc/blog"
+	"context"
 	"database/sql"
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/twitchtv/twirp"
 
-	_ "github.com/lib/pq" // importing so drivers are registered with database/sql package, _ means we will not directly reference this package in code
+	"github.com/lib/pq" // importing also registers the driver with the database/sql package, pq.Array passes tags as arrays
 )
 
 /*
@@ -17,139 +21,990 @@ https://pkg.go.dev/database/sql
 https://www.calhoun.io/using-postgresql-with-go/
 */
 
-type PostgresClient struct{}
+type PostgresClient struct {
+	dsn         string
+	autoMigrate bool
+}
 
 var SqlDB *sql.DB
 
-func NewPostgresClient() PostgresClient {
-	return PostgresClient{}
+// dsn is a lib/pq connection string, e.g. "host=localhost port=5432 user=root dbname=root sslmode=disable"
+// autoMigrate applies pending migrations from db/migrations/postgres on Connect
+func NewPostgresClient(dsn string, autoMigrate bool) PostgresClient {
+	return PostgresClient{
+		dsn:         dsn,
+		autoMigrate: autoMigrate,
+	}
 }
 
 func (p PostgresClient) Connect() error {
 	fmt.Println("Connecting to Postgres")
 
-	const (
-		host     = "localhost"
-		port     = 5432
-		user     = "root"
-		password = "password"
-		dbname   = "root"
-	)
+	db, err := OpenPostgres(p.dsn)
+	if err != nil {
+		return err
+	}
 
-	dbConnectionString := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
+	SqlDB = db
 
-	db, err := sql.Open("postgres", dbConnectionString) // does not create connect to db, just validates arguments
+	fmt.Println("Successfully connected to Postgres")
+
+	if p.autoMigrate {
+		migrator, err := NewMigrator(db, os.Stdout)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := migrator.Up(ctx, false); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return backfillPostgresSlugs(ctx)
+}
+
+// backfillPostgresSlugs generates the slugs of blogs created before slugs were stored, which needs the
+// transliteration in slug.go and so cannot be part of migration 0012_add_slugs
+func backfillPostgresSlugs(ctx context.Context) error {
+	rows, err := SqlDB.QueryContext(ctx, "SELECT id, title FROM blogs WHERE slug IS NULL ORDER BY id")
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to find blogs without a slug: %w", err)
+	}
+	titles := map[string]string{}
+	ids := []string{}
+	for rows.Next() {
+		var id int
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			rows.Close()
+			return fmt.Errorf("unable to find blogs without a slug: %w", err)
+		}
+		ids = append(ids, strconv.Itoa(id))
+		titles[strconv.Itoa(id)] = title
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("unable to find blogs without a slug: %w", err)
+	}
+
+	for _, id := range ids {
+		tx, err := SqlDB.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		_, err = setPostgresSlug(ctx, tx, id, "", titles[id])
+		if err == nil {
+			err = tx.Commit()
+		}
+		tx.Rollback()
+		if err != nil {
+			return fmt.Errorf("unable to generate the slug of blog %v: %w", id, err)
+		}
+	}
+	if len(ids) > 0 {
+		fmt.Printf("Generated slugs for %d existing blogs\n", len(ids))
+	}
+	return nil
+}
+
+// OpenPostgres opens and pings a connection pool, without applying migrations
+func OpenPostgres(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn) // does not create connect to db, just validates arguments
+	if err != nil {
+		return nil, err
 	}
 
 	err = db.Ping() // verifies connection to db, establishes connection if necessary
 	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// blogColumns are selected by every query returning blogs, in the order scanBlog reads them
+const blogColumns = "id, title, content, create_time, update_time, author_id, version, delete_time, category, " +
+	"ARRAY(SELECT tag FROM blog_tags WHERE blog_tags.blog_id = blogs.id ORDER BY tag), status, publish_time, coalesce(slug, ''), content_format"
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBlog(row rowScanner) (blogRecord, error) {
+	var id int
+	var deleteTime sql.NullTime
+	var status int32
+	var publishTime sql.NullTime
+	var contentFormat int32
+	record := blogRecord{}
+	err := row.Scan(&id, &record.Title, &record.Content, &record.CreateTime, &record.UpdateTime, &record.AuthorId, &record.Version, &deleteTime,
+		&record.Category, pq.Array(&record.Tags), &status, &publishTime, &record.Slug, &contentFormat)
+	record.Id = strconv.Itoa(id)
+	record.DeleteTime = deleteTime.Time
+	record.Status = blogProto.BlogStatus(status)
+	record.PublishTime = publishTime.Time
+	record.ContentFormat = blogProto.ContentFormat(contentFormat)
+	return record, err
+}
+
+func (p PostgresClient) CreateBlog(ctx context.Context, data *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
+	tx, err := SqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating a blog: %v", err))
+	}
+	defer tx.Rollback()
+
+	record, err := insertPostgresBlog(ctx, tx, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating a blog: %v", err))
+	}
+
+	return record.createResponse(), nil
+}
+
+// insertPostgresBlog checks data and inserts the blog it describes within tx, with its tags and slug
+func insertPostgresBlog(ctx context.Context, tx *sql.Tx, data *blogProto.CreateBlogRequest) (blogRecord, error) {
+	labels, err := newBlogLabels(data.Tags, data.Category)
+	if err != nil {
+		return blogRecord{}, err
+	}
+	status, publishTime, err := createStatus(data.Status, time.Now())
+	if err != nil {
+		return blogRecord{}, err
+	}
+	// drafts have no publish_time
+	var publishAt interface{}
+	if !publishTime.IsZero() {
+		publishAt = publishTime
+	}
+	format, err := contentFormat(data.ContentFormat)
+	if err != nil {
+		return blogRecord{}, err
+	}
+
+	// create_time and update_time default to now()
+	sqlStatement := "INSERT INTO blogs (title, content, author_id, category, status, publish_time, content_format) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING " + blogColumns
+	record, err := scanBlog(tx.QueryRowContext(ctx, sqlStatement, data.Title, data.Content, authorOf(ctx), labels.Category, int32(status), publishAt, int32(format)))
+	if err != nil {
+		return blogRecord{}, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", err))
+	}
+	if err := setPostgresTags(ctx, tx, record.Id, labels.Tags); err != nil {
+		return blogRecord{}, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", err))
+	}
+	slug, err := setPostgresSlug(ctx, tx, record.Id, "", record.Title)
+	if err != nil {
+		return blogRecord{}, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating a blog: %v", err))
+	}
+	record.Tags = labels.Tags
+	record.Slug = slug
+	return record, nil
+}
+
+// setPostgresTags replaces the tags of blog id within tx
+func setPostgresTags(ctx context.Context, tx *sql.Tx, id string, tags []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM blog_tags WHERE blog_id=$1", id); err != nil {
 		return err
 	}
+	_, err := tx.ExecContext(ctx, "INSERT INTO blog_tags (blog_id, tag) SELECT $1, unnest($2::text[])", id, pq.Array(tags))
+	return err
+}
 
-	SqlDB = db
+// setPostgresSlug claims a slug for blog id within tx and makes it the blog's current slug: requested, unless
+// another blog has it, or when requested is empty the first free variant of title. Slugs the blog already
+// had are free to it
+func setPostgresSlug(ctx context.Context, tx *sql.Tx, id string, requested string, title string) (string, error) {
+	if requested != "" {
+		claimed, err := claimPostgresSlug(ctx, tx, id, requested)
+		if err != nil {
+			return "", err
+		}
+		if !claimed {
+			return "", slugTaken(requested)
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE blogs SET slug = $2 WHERE id=$1", id, requested)
+		return requested, err
+	}
 
-	fmt.Println("Successfully connected to Postgres")
-	return nil
+	base := slugify(title)
+	for attempt := 0; attempt < maxSlugAttempts; attempt++ {
+		rows, err := tx.QueryContext(ctx, "SELECT slug FROM blog_slugs WHERE (slug = $1 OR slug LIKE $2) AND blog_id <> $3",
+			base, likeEscaper.Replace(base)+"-%", id)
+		if err != nil {
+			return "", err
+		}
+		taken := map[string]bool{}
+		for rows.Next() {
+			var slug string
+			if err := rows.Scan(&slug); err != nil {
+				rows.Close()
+				return "", err
+			}
+			if isSlugVariant(slug, base) {
+				taken[slug] = true
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+
+		// another blog may claim the same slug first, in which case the next attempt sees it as taken
+		slug := uniqueSlug(base, taken)
+		claimed, err := claimPostgresSlug(ctx, tx, id, slug)
+		if err != nil {
+			return "", err
+		}
+		if claimed {
+			_, err = tx.ExecContext(ctx, "UPDATE blogs SET slug = $2 WHERE id=$1", id, slug)
+			return slug, err
+		}
+	}
+	return "", fmt.Errorf("no free slug for %q after %d attempts", base, maxSlugAttempts)
 }
 
-func (p PostgresClient) CreateBlog(data *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
+// claimPostgresSlug reserves slug for blog id within tx, it returns false when another blog holds it
+func claimPostgresSlug(ctx context.Context, tx *sql.Tx, id string, slug string) (bool, error) {
+	// waits for a concurrent transaction inserting the same slug to finish, rather than failing the whole tx
+	if _, err := tx.ExecContext(ctx, "INSERT INTO blog_slugs (slug, blog_id) VALUES ($1, $2) ON CONFLICT (slug) DO NOTHING", slug, id); err != nil {
+		return false, err
+	}
+	var owner string
+	if err := tx.QueryRowContext(ctx, "SELECT blog_id FROM blog_slugs WHERE slug=$1", slug).Scan(&owner); err != nil {
+		return false, err
+	}
+	return owner == id, nil
+}
 
-	sqlStatement := "INSERT INTO blogs (title, content) VALUES ($1, $2) RETURNING id"
-	id := 0
-	err := SqlDB.QueryRow(sqlStatement, data.Title, data.Content).Scan(&id)
+func (p PostgresClient) GetBlogBySlug(ctx context.Context, data *blogProto.GetBlogBySlugRequest) (*blogProto.GetBlogResponse, error) {
+	sqlStatement := "SELECT " + blogColumns + " FROM blogs WHERE id = (SELECT blog_id FROM blog_slugs WHERE slug=$1) AND delete_time IS NULL"
+
+	record, err := scanBlog(SqlDB.QueryRowContext(ctx, sqlStatement, data.Slug))
+	if err == sql.ErrNoRows {
+		return nil, slugNotFound(data.Slug)
+	}
 	if err != nil {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("There was an error creating a blog: %v", err))
+		return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("No documents were found for slug: %v, err: %v", data.Slug, err))
 	}
 
-	return &blogProto.CreateBlogResponse{
-		Id:      strconv.Itoa(id),
-		Title:   data.Title,
-		Content: data.Content,
-	}, nil
+	return record.getResponse(), nil
+}
+
+func (p PostgresClient) GetBlog(ctx context.Context, data *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
+	sqlStatement := "SELECT " + blogColumns + " FROM blogs WHERE id=$1 AND ($2 OR delete_time IS NULL)"
+
+	record, err := scanBlog(SqlDB.QueryRowContext(ctx, sqlStatement, data.Id, data.ShowDeleted))
+	if err != nil {
+		return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("No documents were found for id: %v, err: %v", data.Id, err))
+	}
 
+	return record.getResponse(), nil
 }
 
-func (p PostgresClient) GetBlog(data *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
-	var title string
-	var content string
+func (p PostgresClient) UpdateBlog(ctx context.Context, data *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	fields, err := parseUpdateMask(data)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := newBlogLabels(data.Tags, data.Category)
+	if err != nil {
+		return nil, err
+	}
+	requestedSlug, err := normalizeSlug(data.Slug)
+	if err != nil {
+		return nil, err
+	}
+	format, err := contentFormat(data.ContentFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := SqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("Blog id: %v could not be updated, err: %v", data.Id, err))
+	}
+	defer tx.Rollback()
+
+	// the row lock holds off concurrent writes until the revision and the update are committed together,
+	// so the version checked here is the one replaced and saved as the revision
+	current, err := scanBlog(tx.QueryRowContext(ctx, "SELECT "+blogColumns+" FROM blogs WHERE id=$1 AND delete_time IS NULL FOR UPDATE", data.Id))
+	if err == sql.ErrNoRows {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, no matching rows", data.Id, data))
+	}
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, err: %v", data.Id, data, err))
+	}
+	if data.Version != 0 && data.Version != current.Version {
+		return nil, versionConflict(data.Id, data.Version, current.Version)
+	}
 
-	sqlStatement := "SELECT title, content FROM blogs WHERE id=$1"
+	revision := newRevision(ctx, current, time.Now())
+	_, err = tx.ExecContext(ctx, `INSERT INTO blog_revisions (blog_id, version, title, content, create_time, revise_time, revised_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		current.Id, revision.Version, revision.Title, revision.Content, revision.CreateTime, revision.ReviseTime, revision.RevisedBy)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("Blog id: %v could not save a revision, err: %v", data.Id, err))
+	}
 
-	err := SqlDB.QueryRow(sqlStatement, data.Id).Scan(&title, &content)
+	if fields.Tags {
+		if err := setPostgresTags(ctx, tx, current.Id, labels.Tags); err != nil {
+			return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, err: %v", data.Id, data, err))
+		}
+	}
+	if fields.Slug {
+		title := current.Title
+		if fields.Title {
+			title = data.Title
+		}
+		if _, err := setPostgresSlug(ctx, tx, current.Id, requestedSlug, title); err != nil {
+			if twerr, ok := err.(twirp.Error); ok {
+				return nil, twerr
+			}
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("Blog id: %v could not be updated with %v, err: %v", data.Id, data, err))
+		}
+	}
+
+	// fields outside the update mask are set to their current value, the returned tags and slug include those set above
+	sqlStatement := `UPDATE blogs SET
+			title = CASE WHEN $4 THEN $2 ELSE title END,
+			content = CASE WHEN $5 THEN $3 ELSE content END,
+			category = CASE WHEN $7 THEN $8 ELSE category END,
+			content_format = CASE WHEN $9 THEN $10 ELSE content_format END,
+			update_time = $6, version = version + 1
+		WHERE id=$1 RETURNING ` + blogColumns
+	record, err := scanBlog(tx.QueryRowContext(ctx, sqlStatement, current.Id, data.Title, data.Content, fields.Title, fields.Content, revision.ReviseTime,
+		fields.Category, labels.Category, fields.ContentFormat, int32(format)))
 	if err != nil {
-		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v, err: %v", data.Id, err))
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, err: %v", data.Id, data, err))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("Blog id: %v could not be updated, err: %v", data.Id, err))
+	}
+
+	return record.updateResponse(), nil
+}
+
+func (p PostgresClient) DeleteBlog(ctx context.Context, data *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
+	if err := deletePostgresBlog(ctx, SqlDB, data); err != nil {
+		return nil, err
 	}
 
-	return &blogProto.GetBlogResponse{
-		Id:      data.Id,
-		Title:   title,
-		Content: content,
+	return &blogProto.DeleteBlogResponse{
+		Id: data.Id,
 	}, nil
 }
 
-func (p PostgresClient) UpdateBlog(data *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
-	sqlStatement := "UPDATE blogs SET title=$2, content=$3 WHERE id=$1"
-	result, err := SqlDB.Exec(sqlStatement, data.Id, data.Title, data.Content)
+// postgresExecer is implemented by both *sql.DB and *sql.Tx
+type postgresExecer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// deletePostgresBlog moves the blog data deletes to the trash, on its own or within a transaction
+func deletePostgresBlog(ctx context.Context, db postgresExecer, data *blogProto.DeleteBlogRequest) error {
+	// blogs are moved to the trash, PurgeDeletedBlogs removes them for good after the retention
+	sqlStatement := `UPDATE blogs SET delete_time = now(), version = version + 1
+		WHERE id=$1 AND delete_time IS NULL AND ($2 = 0 OR version = $2)`
+	result, err := db.ExecContext(ctx, sqlStatement, data.Id, data.Version)
 	if err != nil {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, err: %v", data.Id, data, err))
+		return newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v, err: %v", data.Id, err))
 	}
 	rows, err := result.RowsAffected()
+	if err == nil && rows == 0 {
+		if conflict := postgresVersionConflict(ctx, data.Id, data.Version); conflict != nil {
+			return conflict
+		}
+	}
 	if err != nil || rows == 0 {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be updated with %v, no matching rows", data.Id, data))
+		return newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v, no matching rows", data.Id))
+	}
+	return nil
+}
+
+func (p PostgresClient) BatchCreateBlogs(ctx context.Context, data *blogProto.BatchCreateBlogsRequest) (*blogProto.BatchCreateBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Requests), "requests"); err != nil {
+		return nil, err
+	}
+	created := make([]*blogProto.CreateBlogResponse, len(data.Requests))
+	errs := make([]error, len(data.Requests))
+	if !data.AllOrNothing {
+		for i, request := range data.Requests {
+			created[i], errs[i] = p.CreateBlog(ctx, request)
+		}
+		return createResults(created, errs), nil
+	}
+
+	tx, err := SqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating blogs: %v", err))
+	}
+	defer tx.Rollback()
+
+	// a failed statement aborts the transaction, so the batch stops at the first failure
+	for i, request := range data.Requests {
+		record, err := insertPostgresBlog(ctx, tx, request)
+		if err != nil {
+			return nil, BatchFailure(i, err)
+		}
+		created[i] = record.createResponse()
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error creating blogs: %v", err))
+	}
+	return createResults(created, errs), nil
+}
+
+func (p PostgresClient) BatchGetBlogs(ctx context.Context, data *blogProto.BatchGetBlogsRequest) (*blogProto.BatchGetBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Ids), "ids"); err != nil {
+		return nil, err
+	}
+	ids := []int64{}
+	for _, id := range data.Ids {
+		// ids that are not numbers match no blog, as they do for GetBlog
+		if parsed, err := strconv.ParseInt(id, 10, 64); err == nil {
+			ids = append(ids, parsed)
+		}
+	}
+
+	sqlStatement := "SELECT " + blogColumns + " FROM blogs WHERE id = ANY($1) AND ($2 OR delete_time IS NULL)"
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, pq.Array(ids), data.ShowDeleted)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error finding blogs: %v", err))
+	}
+	defer rows.Close()
+	// keyed by the number rather than the id as given, which may be written differently, e.g. "007" for 7
+	found := map[int64]blogRecord{}
+	for rows.Next() {
+		record, err := scanBlog(rows)
+		if err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error finding blogs: %v", err))
+		}
+		id, err := strconv.ParseInt(record.Id, 10, 64)
+		if err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error with blog id: %v, err: %v", record.Id, err))
+		}
+		found[id] = record
+	}
+	if err := rows.Err(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error finding blogs: %v", err))
+	}
+
+	blogs := make([]*blogProto.GetBlogResponse, len(data.Ids))
+	errs := make([]error, len(data.Ids))
+	for i, id := range data.Ids {
+		parsed, err := strconv.ParseInt(id, 10, 64)
+		record, ok := found[parsed]
+		if err != nil || !ok {
+			errs[i] = twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", id))
+			continue
+		}
+		blogs[i] = record.getResponse()
+	}
+	if data.AllOrNothing {
+		if err := firstFailure(errs); err != nil {
+			return nil, err
+		}
+	}
+	return getResults(blogs, errs), nil
+}
+
+func (p PostgresClient) BatchDeleteBlogs(ctx context.Context, data *blogProto.BatchDeleteBlogsRequest) (*blogProto.BatchDeleteBlogsResponse, error) {
+	if err := CheckBatchSize(len(data.Requests), "requests"); err != nil {
+		return nil, err
 	}
+	errs := repeatedIds(data.Requests, canonicalNumericId)
+	if !data.AllOrNothing {
+		for i, request := range data.Requests {
+			if errs[i] == nil {
+				errs[i] = deletePostgresBlog(ctx, SqlDB, request)
+			}
+		}
+		return deleteResults(data.Requests, errs), nil
+	}
+	if err := firstFailure(errs); err != nil {
+		return nil, err
+	}
+
+	tx, err := SqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error deleting blogs: %v", err))
+	}
+	defer tx.Rollback()
+
+	for i, request := range data.Requests {
+		if err := deletePostgresBlog(ctx, tx, request); err != nil {
+			return nil, BatchFailure(i, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error deleting blogs: %v", err))
+	}
+	return deleteResults(data.Requests, errs), nil
+}
+
+func (p PostgresClient) UndeleteBlog(ctx context.Context, data *blogProto.UndeleteBlogRequest) (*blogProto.UndeleteBlogResponse, error) {
+	sqlStatement := `UPDATE blogs SET delete_time = NULL, version = version + 1
+		WHERE id=$1 AND delete_time IS NOT NULL AND ($2 = 0 OR version = $2) RETURNING ` + blogColumns
+	record, err := scanBlog(SqlDB.QueryRowContext(ctx, sqlStatement, data.Id, data.Version))
+	if err == sql.ErrNoRows {
+		// find out whether the blog is missing, not deleted or at another version
+		var version int64
+		var deleted bool
+		err := SqlDB.QueryRowContext(ctx, "SELECT version, delete_time IS NOT NULL FROM blogs WHERE id=$1", data.Id).Scan(&version, &deleted)
+		switch {
+		case err != nil:
+			return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", data.Id))
+		case !deleted:
+			return nil, notDeleted(data.Id)
+		default:
+			return nil, versionConflict(data.Id, data.Version, version)
+		}
+	}
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be restored, err: %v", data.Id, err))
+	}
+
+	return record.undeleteResponse(), nil
+}
+
+func (p PostgresClient) PublishBlog(ctx context.Context, data *blogProto.PublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	status, publishTime, err := publishStatus(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return p.setStatus(ctx, data.Id, data.Version, status, publishTime)
+}
+
+func (p PostgresClient) UnpublishBlog(ctx context.Context, data *blogProto.UnpublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	return p.setStatus(ctx, data.Id, data.Version, unpublishStatus(data), time.Time{})
+}
+
+// setStatus changes the status of blog id, and its publish_time unless publishTime is zero
+func (p PostgresClient) setStatus(ctx context.Context, id string, version int64, status blogProto.BlogStatus, publishTime time.Time) (*blogProto.UpdateBlogResponse, error) {
+	var publishAt interface{}
+	if !publishTime.IsZero() {
+		publishAt = publishTime
+	}
+
+	sqlStatement := `UPDATE blogs SET status = $3, publish_time = coalesce($4, publish_time), version = version + 1
+		WHERE id=$1 AND delete_time IS NULL AND ($2 = 0 OR version = $2) RETURNING ` + blogColumns
+	record, err := scanBlog(SqlDB.QueryRowContext(ctx, sqlStatement, id, version, int32(status), publishAt))
+	if err == sql.ErrNoRows {
+		if conflict := postgresVersionConflict(ctx, id, version); conflict != nil {
+			return nil, conflict
+		}
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be changed to %v, no matching rows", id, status))
+	}
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Blog id: %v could not be changed to %v, err: %v", id, status, err))
+	}
+
+	return record.updateResponse(), nil
+}
+
+func (p PostgresClient) PublishScheduledBlogs(ctx context.Context, now time.Time) (int64, error) {
+	sqlStatement := "UPDATE blogs SET status = $1, version = version + 1 WHERE status = $2 AND publish_time <= $3 AND delete_time IS NULL"
+	result, err := SqlDB.ExecContext(ctx, sqlStatement,
+		int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED), int32(blogProto.BlogStatus_BLOG_STATUS_SCHEDULED), now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (p PostgresClient) CountPublishedBlogs(ctx context.Context) (int64, error) {
+	count := int64(0)
+	err := SqlDB.QueryRowContext(ctx, "SELECT count(*) FROM blogs WHERE status = $1 AND delete_time IS NULL",
+		int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED)).Scan(&count)
+	return count, err
+}
+
+func (p PostgresClient) WalkPublishedBlogs(ctx context.Context, from string, to string, limit int64, fn SitemapFunc) error {
+	start := int64(0)
+	if from != "" {
+		var err error
+		if start, err = strconv.ParseInt(from, 10, 64); err != nil {
+			return invalidSitemapBound("from", from)
+		}
+	}
+	// a NULL end leaves the range open
+	var end interface{}
+	if to != "" {
+		parsed, err := strconv.ParseInt(to, 10, 64)
+		if err != nil {
+			return invalidSitemapBound("to", to)
+		}
+		end = parsed
+	}
+	// LIMIT NULL returns every row
+	var rowLimit interface{}
+	if limit > 0 {
+		rowLimit = limit
+	}
+
+	// rows are read from the connection as they are scanned, so only one blog is held at a time
+	sqlStatement := `SELECT id, coalesce(slug, ''), greatest(update_time, publish_time) FROM blogs
+		WHERE status = $1 AND delete_time IS NULL AND id >= $2 AND ($3::bigint IS NULL OR id < $3) ORDER BY id LIMIT $4`
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED), start, end, rowLimit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, slug string
+		var lastModified time.Time
+		if err := rows.Scan(&id, &slug, &lastModified); err != nil {
+			return err
+		}
+		if err := fn(id, slug, lastModified); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (p PostgresClient) ListBlogRevisions(ctx context.Context, data *blogProto.ListBlogRevisionsRequest) (*blogProto.ListBlogRevisionsResponse, error) {
+	query, err := newRevisionQuery(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// LIMIT NULL returns every revision, otherwise fetch one extra to find out whether there is a next page
+	var limit interface{}
+	if query.Limit > 0 {
+		limit = query.Limit + 1
+	}
+
+	sqlStatement := "SELECT " + revisionColumns + ` FROM blog_revisions
+		WHERE blog_id=$1 AND ($2 = 0 OR version < $2)
+		ORDER BY version DESC
+		LIMIT $3`
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, data.BlogId, query.Before, limit)
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error listing the revisions of blog id: %v, err: %v", data.BlogId, err))
+	}
+	defer rows.Close()
+
+	revisions := []revisionRecord{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error with a revision of blog id: %v, err: %v", data.BlogId, err))
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing the revisions of blog id: %v, err: %v", data.BlogId, err))
+	}
+
+	return query.page(revisions), nil
+}
+
+func (p PostgresClient) GetBlogRevision(ctx context.Context, data *blogProto.GetBlogRevisionRequest) (*blogProto.BlogRevision, error) {
+	sqlStatement := "SELECT " + revisionColumns + " FROM blog_revisions WHERE blog_id=$1 AND version=$2"
+	revision, err := scanRevision(SqlDB.QueryRowContext(ctx, sqlStatement, data.BlogId, data.Version))
+	if err == sql.ErrNoRows {
+		return nil, revisionNotFound(data.BlogId, data.Version)
+	}
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error getting revision %d of blog id: %v, err: %v", data.Version, data.BlogId, err))
+	}
+
+	return revision.response(), nil
+}
+
+// revisionColumns are selected by every query returning revisions, in the order scanRevision reads them
+const revisionColumns = "blog_id, version, title, content, create_time, revise_time, revised_by"
+
+func scanRevision(row rowScanner) (revisionRecord, error) {
+	var blogId int
+	revision := revisionRecord{}
+	err := row.Scan(&blogId, &revision.Version, &revision.Title, &revision.Content, &revision.CreateTime, &revision.ReviseTime, &revision.RevisedBy)
+	revision.BlogId = strconv.Itoa(blogId)
+	return revision, err
+}
+
+func (p PostgresClient) PurgeDeletedBlogs(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	// the revisions of purged blogs are removed by the ON DELETE CASCADE of blog_revisions
+	result, err := SqlDB.ExecContext(ctx, "DELETE FROM blogs WHERE delete_time < $1", deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
 
-	return &blogProto.UpdateBlogResponse{
-		Id:      data.Id,
-		Title:   data.Title,
-		Content: data.Content,
+// postgresVersionConflict explains why a versioned write matched no rows: it returns a conflict
+// when the blog exists at another version, or nil when the blog does not exist at all
+func postgresVersionConflict(ctx context.Context, id string, expected int64) error {
+	if expected == 0 {
+		return nil
+	}
+	var current int64
+	if err := SqlDB.QueryRowContext(ctx, "SELECT version FROM blogs WHERE id=$1 AND delete_time IS NULL", id).Scan(&current); err != nil {
+		return nil
+	}
+	return versionConflict(id, expected, current)
+}
+
+func (p PostgresClient) ListBlog(ctx context.Context, data *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
+	query, err := newListQuery(data, false)
+	if err != nil {
+		return nil, err
+	}
+	blogs, nextPageToken, err := p.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blogProto.ListBlogResponse{
+		Blogs:         blogs,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
-func (p PostgresClient) DeleteBlog(data *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
-	sqlStatement := "DELETE FROM blogs WHERE id=$1"
-	result, err := SqlDB.Exec(sqlStatement, data.Id)
+func (p PostgresClient) ListDeletedBlogs(ctx context.Context, data *blogProto.ListDeletedBlogsRequest) (*blogProto.ListDeletedBlogsResponse, error) {
+	query, err := newDeletedListQuery(data)
 	if err != nil {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v, err: %v", data.Id, err))
+		return nil, err
 	}
-	rows, err := result.RowsAffected()
-	if err != nil || rows == 0 {
-		return nil, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Unable to delete blog with ID: %v, no matching rows", data.Id))
+	blogs, nextPageToken, err := p.list(ctx, query)
+	if err != nil {
+		return nil, err
 	}
 
-	return &blogProto.DeleteBlogResponse{
-		Id: data.Id,
+	return &blogProto.ListDeletedBlogsResponse{
+		Blogs:         blogs,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
-func (p PostgresClient) ListBlog(data *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
-	sqlStatement := "SELECT * from blogs LIMIT $1"
-	rows, err := SqlDB.Query(sqlStatement, data.Limit)
+// list returns one page of the blogs matching query and the token of the next page
+func (p PostgresClient) list(ctx context.Context, query listQuery) ([]*blogProto.CreateBlogResponse, string, error) {
+	where, args, err := postgresListWhere(query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// ids come from a serial, so id both orders by create_time and breaks ties on the other fields
+	direction := "ASC"
+	if query.Order.Desc {
+		direction = "DESC"
+	}
+	orderBy := fmt.Sprintf("id %s", direction)
+	switch query.Order.Field {
+	case "title":
+		orderBy = fmt.Sprintf("title %s, id %s", direction, direction)
+	case "publish_time":
+		// blogs never published sort first, as in the other backends
+		nulls := "FIRST"
+		if query.Order.Desc {
+			nulls = "LAST"
+		}
+		orderBy = fmt.Sprintf("publish_time %s NULLS %s, id %s", direction, nulls, direction)
+	}
+
+	// LIMIT NULL returns every row, otherwise fetch one extra blog to find out whether there is a next page
+	var limit interface{}
+	if query.Limit > 0 {
+		limit = query.Limit + 1
+	}
+	args = append(args, limit)
+
+	// only the ORDER BY direction is formatted into the statement, every value is a parameter
+	sqlStatement := fmt.Sprintf("SELECT %s FROM blogs %s ORDER BY %s LIMIT $%d", blogColumns, where, orderBy, len(args))
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, args...)
 	if err != nil {
-		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("There was an error listing blogs: %v", err))
+		return nil, "", newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error listing blogs: %v", err))
 	}
 	defer rows.Close()
 
 	blogs := []*blogProto.CreateBlogResponse{}
 
+	for rows.Next() {
+		record, err := scanBlog(rows)
+		if err != nil {
+			return nil, "", newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error with blog id: %v, err: %v", record.Id, err))
+		}
+		blogs = append(blogs, record.createResponse())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", newError(ctx, twirp.NotFound, fmt.Sprintf("There was an error listing blogs: %v", err))
+	}
+
+	nextPageToken := ""
+	if query.Limit > 0 && int64(len(blogs)) > query.Limit {
+		blogs = blogs[:query.Limit]
+		nextPageToken = query.nextPageToken(blogs[len(blogs)-1])
+	}
+
+	return blogs, nextPageToken, nil
+}
+
+// likeEscaper escapes the LIKE wildcards so user input only matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// postgresListWhere translates a listQuery, including its page token, into a parameterized WHERE clause
+func postgresListWhere(query listQuery) (string, []interface{}, error) {
+	conditions := []string{"delete_time IS NULL"}
+	if query.Deleted {
+		conditions = []string{"delete_time IS NOT NULL"}
+	}
+	args := []interface{}{}
+	param := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.TitlePrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("title LIKE %s", param(likeEscaper.Replace(query.TitlePrefix)+"%")))
+	}
+	if query.TitleContains != "" {
+		conditions = append(conditions, fmt.Sprintf("title ILIKE %s", param("%"+likeEscaper.Replace(query.TitleContains)+"%")))
+	}
+	if query.CreatedAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("create_time >= %s", param(*query.CreatedAfter)))
+	}
+	if query.CreatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("create_time < %s", param(*query.CreatedBefore)))
+	}
+	if query.AuthorId != "" {
+		conditions = append(conditions, fmt.Sprintf("author_id = %s", param(query.AuthorId)))
+	}
+	if query.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category = %s", param(query.Category)))
+	}
+	if query.Status != blogProto.BlogStatus_BLOG_STATUS_UNSPECIFIED {
+		conditions = append(conditions, fmt.Sprintf("status = %s", param(int32(query.Status))))
+	}
+	if len(query.Tags) > 0 {
+		tags := param(pq.Array(query.Tags))
+		if query.AllTags {
+			// tags are unique per blog, so a blog with every tag has as many matching rows as there are tags
+			conditions = append(conditions, fmt.Sprintf("id IN (SELECT blog_id FROM blog_tags WHERE tag = ANY(%s) GROUP BY blog_id HAVING count(*) = %s)",
+				tags, param(len(query.Tags))))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("id IN (SELECT blog_id FROM blog_tags WHERE tag = ANY(%s))", tags))
+		}
+	}
+
+	if query.Token.After != "" {
+		after, err := strconv.Atoi(query.Token.After)
+		if err != nil {
+			return "", nil, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+		comparison := ">"
+		if query.Order.Desc {
+			comparison = "<"
+		}
+		switch query.Order.Field {
+		case "create_time":
+			conditions = append(conditions, fmt.Sprintf("id %s %s", comparison, param(after)))
+		case "title":
+			conditions = append(conditions, fmt.Sprintf("(title, id) %s (%s, %s)", comparison, param(query.Token.Title), param(after)))
+		case "publish_time":
+			// a row comparison is null for a null publish_time, so the blogs never published are matched on their own
+			switch {
+			case query.Token.Published == nil && query.Order.Desc:
+				conditions = append(conditions, fmt.Sprintf("(publish_time IS NULL AND id < %s)", param(after)))
+			case query.Token.Published == nil:
+				conditions = append(conditions, fmt.Sprintf("(publish_time IS NOT NULL OR id > %s)", param(after)))
+			case query.Order.Desc:
+				conditions = append(conditions, fmt.Sprintf("(publish_time IS NULL OR (publish_time, id) < (%s, %s))", param(*query.Token.Published), param(after)))
+			default:
+				conditions = append(conditions, fmt.Sprintf("(publish_time, id) > (%s, %s)", param(*query.Token.Published), param(after)))
+			}
+		}
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+func (p PostgresClient) ListTags(ctx context.Context, data *blogProto.ListTagsRequest) (*blogProto.ListTagsResponse, error) {
+	// LIMIT NULL returns every tag
+	var limit interface{}
+	if data.Limit > 0 {
+		limit = data.Limit
+	}
+
+	sqlStatement := `SELECT tag, count(*) AS count FROM blog_tags
+		JOIN blogs ON blogs.id = blog_tags.blog_id
+		WHERE blogs.delete_time IS NULL AND blogs.status = $2
+		GROUP BY tag
+		ORDER BY count DESC, tag
+		LIMIT $1`
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, limit, int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED))
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing tags: %v", err))
+	}
+	defer rows.Close()
+
+	tags := []*blogProto.TagCount{}
+	for rows.Next() {
+		tag := &blogProto.TagCount{}
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error reading tags: %v", err))
+		}
+		tags = append(tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing tags: %v", err))
+	}
+
+	return &blogProto.ListTagsResponse{
+		Tags: tags,
+	}, nil
+}
+
+func (p PostgresClient) SearchBlogs(ctx context.Context, data *blogProto.SearchBlogsRequest) (*blogProto.SearchBlogsResponse, error) {
+	terms := searchTerms(data.Query)
+
+	// LIMIT NULL returns every match
+	var limit interface{}
+	if data.Limit > 0 {
+		limit = data.Limit
+	}
+
+	// joining with "or" gives the same any-word semantics as Mongo's $text, ranked by the A/B weights of the search column
+	headlineOptions := fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=%d, MinWords=%d`, markStart, markStop, snippetWords, snippetWords/3)
+	sqlStatement := `SELECT id, title, ts_headline('english', coalesce(content, ''), query, $2), ts_rank(search, query) AS rank, coalesce(slug, '')
+		FROM blogs, websearch_to_tsquery('english', $1) query
+		WHERE search @@ query AND delete_time IS NULL AND status = $4
+		ORDER BY rank DESC, id
+		LIMIT $3`
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, strings.Join(terms, " or "), headlineOptions, limit, int32(blogProto.BlogStatus_BLOG_STATUS_PUBLISHED))
+	if err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error searching blogs: %v", err))
+	}
+	defer rows.Close()
+
+	results := []*blogProto.SearchBlogResult{}
 	for rows.Next() {
 		var id int
 		var title string
-		var content string
-		err := rows.Scan(&id, &content, &title)
-		if err != nil {
-			return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("There was an error with blog id: %v, err: %v", id, err))
+		var headline string
+		var rank float64
+		var slug string
+		if err := rows.Scan(&id, &title, &headline, &rank, &slug); err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error with search result id: %v, err: %v", id, err))
 		}
-		blog := blogProto.CreateBlogResponse{
+		results = append(results, &blogProto.SearchBlogResult{
 			Id:      strconv.Itoa(id),
 			Title:   title,
-			Content: content,
-		}
-		blogs = append(blogs, &blog)
+			Snippet: markedToHTML(headline),
+			Score:   rank,
+			Slug:    slug,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error searching blogs: %v", err))
 	}
 
-	return &blogProto.ListBlogResponse{
-		Blogs: blogs,
+	return &blogProto.SearchBlogsResponse{
+		Results: results,
 	}, nil
 }
@@ -0,0 +1,61 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresListWhere(t *testing.T) {
+	where, args, err := postgresListWhere(listQuery{Order: listOrder{Field: "create_time"}})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL", where)
+	require.Empty(t, args)
+
+	where, _, err = postgresListWhere(listQuery{Order: listOrder{Field: "create_time"}, Deleted: true})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NOT NULL", where)
+
+	after := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	where, args, err = postgresListWhere(listQuery{
+		Order:         listOrder{Field: "title", Desc: true},
+		Token:         pageToken{After: "42", Title: "Last"},
+		TitlePrefix:   "100%_",
+		TitleContains: "go",
+		CreatedAfter:  &after,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL AND title LIKE $1 AND title ILIKE $2 AND create_time >= $3 AND (title, id) < ($4, $5)", where)
+	require.Equal(t, []interface{}{`100\%\_%`, "%go%", after, "Last", 42}, args)
+
+	where, args, err = postgresListWhere(listQuery{Order: listOrder{Field: "create_time"}, Category: "news", Tags: []string{"go", "web"}, AllTags: true})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL AND category = $1 AND id IN (SELECT blog_id FROM blog_tags WHERE tag = ANY($2) GROUP BY blog_id HAVING count(*) = $3)", where)
+	require.Equal(t, []interface{}{"news", pq.Array([]string{"go", "web"}), 2}, args)
+
+	where, _, err = postgresListWhere(listQuery{Order: listOrder{Field: "create_time"}, Tags: []string{"go"}})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL AND id IN (SELECT blog_id FROM blog_tags WHERE tag = ANY($1))", where)
+
+	where, args, err = postgresListWhere(listQuery{Order: listOrder{Field: "create_time"}, Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL AND status = $1", where)
+	require.Equal(t, []interface{}{int32(3)}, args)
+
+	published := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	where, args, err = postgresListWhere(listQuery{Order: listOrder{Field: "publish_time", Desc: true}, Token: pageToken{After: "42", Published: &published}})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL AND (publish_time IS NULL OR (publish_time, id) < ($1, $2))", where)
+	require.Equal(t, []interface{}{published, 42}, args)
+
+	where, args, err = postgresListWhere(listQuery{Order: listOrder{Field: "publish_time"}, Token: pageToken{After: "42"}})
+	require.NoError(t, err)
+	require.Equal(t, "WHERE delete_time IS NULL AND (publish_time IS NOT NULL OR id > $1)", where)
+	require.Equal(t, []interface{}{42}, args)
+
+	_, _, err = postgresListWhere(listQuery{Order: listOrder{Field: "create_time"}, Token: pageToken{After: "not-a-number"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,188 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// PostgresCommentStore keeps comments in the comments table, using the connection PostgresClient opens
+type PostgresCommentStore struct{}
+
+func NewPostgresCommentStore() PostgresCommentStore {
+	return PostgresCommentStore{}
+}
+
+// commentColumns are selected by every query returning comments, in the order scanComment reads them
+const commentColumns = "id, blog_id, parent_id, author_id, content, create_time, update_time, delete_time"
+
+func scanComment(row rowScanner) (commentRecord, error) {
+	var id, blogId int
+	var parentId sql.NullInt64
+	var deleteTime sql.NullTime
+	record := commentRecord{}
+	err := row.Scan(&id, &blogId, &parentId, &record.AuthorId, &record.Content, &record.CreateTime, &record.UpdateTime, &deleteTime)
+	record.Id = strconv.Itoa(id)
+	record.BlogId = strconv.Itoa(blogId)
+	if parentId.Valid {
+		record.ParentId = strconv.FormatInt(parentId.Int64, 10)
+	}
+	record.DeleteTime = deleteTime.Time
+	return record, err
+}
+
+// nullableId stores an empty parent_id as NULL
+func nullableId(id string) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id
+}
+
+func (p PostgresCommentStore) CreateComment(ctx context.Context, data *blogProto.CreateCommentRequest) (*blogProto.Comment, error) {
+	content, err := validateCommentContent(data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ParentId != "" {
+		var parentBlogId int
+		err := SqlDB.QueryRowContext(ctx, "SELECT blog_id FROM comments WHERE id=$1 AND orphan_time IS NULL", data.ParentId).Scan(&parentBlogId)
+		if err != nil || strconv.Itoa(parentBlogId) != data.BlogId {
+			return nil, parentNotFound(data.ParentId, data.BlogId)
+		}
+	}
+
+	// create_time and update_time default to now()
+	sqlStatement := "INSERT INTO comments (blog_id, parent_id, author_id, content) VALUES ($1, $2, $3, $4) RETURNING " + commentColumns
+	record, err := scanComment(SqlDB.QueryRowContext(ctx, sqlStatement, data.BlogId, nullableId(data.ParentId), authorOf(ctx), content))
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error creating a comment: %v", err))
+	}
+
+	return record.response(), nil
+}
+
+func (p PostgresCommentStore) GetComment(ctx context.Context, data *blogProto.GetCommentRequest) (*blogProto.Comment, error) {
+	sqlStatement := "SELECT " + commentColumns + " FROM comments WHERE id=$1 AND orphan_time IS NULL"
+	record, err := scanComment(SqlDB.QueryRowContext(ctx, sqlStatement, data.Id))
+	if err != nil {
+		return nil, newError(ctx, twirp.NotFound, fmt.Sprintf("No comment was found for id: %v, err: %v", data.Id, err))
+	}
+
+	return record.response(), nil
+}
+
+func (p PostgresCommentStore) ListComments(ctx context.Context, data *blogProto.ListCommentsRequest) (*blogProto.ListCommentsResponse, error) {
+	query, err := newCommentQuery(data)
+	if err != nil {
+		return nil, err
+	}
+	after := 0
+	if query.After != "" {
+		after, err = strconv.Atoi(query.After)
+		if err != nil {
+			return nil, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+	}
+
+	// LIMIT NULL returns every comment, otherwise fetch one extra to find out whether there is a next page
+	var limit interface{}
+	if query.Limit > 0 {
+		limit = query.Limit + 1
+	}
+
+	// top-level comments need IS NULL, since comparing with NULL never matches
+	args := []interface{}{query.BlogId, after, limit}
+	parentCondition := "parent_id IS NULL"
+	if query.ParentId != "" {
+		args = append(args, query.ParentId)
+		parentCondition = "parent_id = $4"
+	}
+	sqlStatement := "SELECT " + commentColumns + " FROM comments WHERE blog_id=$1 AND " + parentCondition + `
+		AND orphan_time IS NULL AND id > $2
+		ORDER BY id
+		LIMIT $3`
+	rows, err := SqlDB.QueryContext(ctx, sqlStatement, args...)
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("There was an error listing comments: %v", err))
+	}
+	defer rows.Close()
+
+	comments := []commentRecord{}
+	for rows.Next() {
+		record, err := scanComment(rows)
+		if err != nil {
+			return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error with comment id: %v, err: %v", record.Id, err))
+		}
+		comments = append(comments, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, newError(ctx, twirp.Internal, fmt.Sprintf("There was an error listing comments: %v", err))
+	}
+
+	return query.page(comments), nil
+}
+
+func (p PostgresCommentStore) UpdateComment(ctx context.Context, data *blogProto.UpdateCommentRequest) (*blogProto.Comment, error) {
+	content, err := validateCommentContent(data.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStatement := `UPDATE comments SET content = $2, update_time = now()
+		WHERE id=$1 AND orphan_time IS NULL AND delete_time IS NULL RETURNING ` + commentColumns
+	record, err := scanComment(SqlDB.QueryRowContext(ctx, sqlStatement, data.Id, content))
+	if err == sql.ErrNoRows {
+		// find out whether the comment is missing or deleted
+		if _, err := p.GetComment(ctx, &blogProto.GetCommentRequest{Id: data.Id}); err != nil {
+			return nil, err
+		}
+		return nil, twirp.NewError(twirp.FailedPrecondition, "A deleted comment cannot be edited")
+	}
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Comment id: %v could not be updated, err: %v", data.Id, err))
+	}
+
+	return record.response(), nil
+}
+
+func (p PostgresCommentStore) DeleteComment(ctx context.Context, data *blogProto.DeleteCommentRequest) (*blogProto.DeleteCommentResponse, error) {
+	// the row stays as a tombstone so its replies keep their parent, deleting twice keeps the first delete_time
+	sqlStatement := `UPDATE comments SET content = '', delete_time = coalesce(delete_time, now())
+		WHERE id=$1 AND orphan_time IS NULL`
+	result, err := SqlDB.ExecContext(ctx, sqlStatement, data.Id)
+	if err != nil {
+		return nil, newError(ctx, twirp.InvalidArgument, fmt.Sprintf("Unable to delete comment with ID: %v, err: %v", data.Id, err))
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return nil, commentNotFound(data.Id)
+	}
+
+	return &blogProto.DeleteCommentResponse{
+		Id: data.Id,
+	}, nil
+}
+
+func (p PostgresCommentStore) OrphanComments(ctx context.Context, blogId string, orphaned bool) error {
+	sqlStatement := "UPDATE comments SET orphan_time = NULL WHERE blog_id=$1"
+	if orphaned {
+		sqlStatement = "UPDATE comments SET orphan_time = now() WHERE blog_id=$1"
+	}
+	_, err := SqlDB.ExecContext(ctx, sqlStatement, blogId)
+	return err
+}
+
+func (p PostgresCommentStore) PurgeOrphanedComments(ctx context.Context, orphanedBefore time.Time) (int64, error) {
+	// comments of purged blogs are also removed by the ON DELETE CASCADE of comments.blog_id
+	result, err := SqlDB.ExecContext(ctx, "DELETE FROM comments WHERE orphan_time < $1", orphanedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/*
+Every UpdateBlog saves the title and content it replaces as a revision, keyed by the blog id and the
+version being replaced. Only updates save revisions: publishing, unpublishing, deleting and restoring a blog
+bump its version without changing its title or content, so the versions they replace have no revision, and
+GetBlogRevision and DiffBlogRevisions report them as NotFound. Revisions are removed with their blog when
+it is purged from the trash.
+*/
+
+type revisionRecord struct {
+	BlogId  string
+	Version int64
+	Title   string
+	Content string
+	// CreateTime is when this title and content were saved, the blog's update_time at this version
+	CreateTime time.Time
+	ReviseTime time.Time
+	RevisedBy  string
+}
+
+// newRevision snapshots blog, which the caller in ctx is about to update
+func newRevision(ctx context.Context, blog blogRecord, now time.Time) revisionRecord {
+	return revisionRecord{
+		BlogId:     blog.Id,
+		Version:    blog.Version,
+		Title:      blog.Title,
+		Content:    blog.Content,
+		CreateTime: blog.UpdateTime,
+		ReviseTime: now,
+		RevisedBy:  authorOf(ctx),
+	}
+}
+
+func (r revisionRecord) response() *blogProto.BlogRevision {
+	return &blogProto.BlogRevision{
+		BlogId:     r.BlogId,
+		Version:    r.Version,
+		Title:      r.Title,
+		Content:    r.Content,
+		CreateTime: timestamppb.New(r.CreateTime),
+		ReviseTime: timestamppb.New(r.ReviseTime),
+		RevisedBy:  r.RevisedBy,
+	}
+}
+
+// revisionQuery is a validated ListBlogRevisionsRequest, revisions are always listed newest first
+type revisionQuery struct {
+	Limit int64
+	// Before is the version of the last revision on the previous page, 0 on the first page
+	Before int64
+}
+
+const revisionOrder = "version desc"
+
+func newRevisionQuery(data *blogProto.ListBlogRevisionsRequest) (revisionQuery, error) {
+	token, ok := decodePageToken(data.PageToken)
+	if !ok || (data.PageToken != "" && token.Order != revisionOrder) {
+		return revisionQuery{}, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+	}
+	query := revisionQuery{Limit: data.Limit}
+	if token.After != "" {
+		before, err := strconv.ParseInt(token.After, 10, 64)
+		if err != nil || before < 1 {
+			return revisionQuery{}, twirp.NewError(twirp.InvalidArgument, "Invalid page token")
+		}
+		query.Before = before
+	}
+	return query, nil
+}
+
+// page trims revisions, fetched with one extra to detect a next page, to the limit and returns the response
+func (q revisionQuery) page(revisions []revisionRecord) *blogProto.ListBlogRevisionsResponse {
+	nextPageToken := ""
+	if q.Limit > 0 && int64(len(revisions)) > q.Limit {
+		revisions = revisions[:q.Limit]
+		last := revisions[len(revisions)-1]
+		nextPageToken = encodePageToken(pageToken{Order: revisionOrder, After: strconv.FormatInt(last.Version, 10)})
+	}
+
+	results := []*blogProto.BlogRevision{}
+	for _, revision := range revisions {
+		results = append(results, revision.response())
+	}
+	return &blogProto.ListBlogRevisionsResponse{
+		Revisions:     results,
+		NextPageToken: nextPageToken,
+	}
+}
+
+func revisionNotFound(blogId string, version int64) twirp.Error {
+	return twirp.NewError(twirp.NotFound, fmt.Sprintf("Blog id: %v has no revision at version %d", blogId, version))
+}
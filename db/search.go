@@ -0,0 +1,100 @@
+package db
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+/*
+Every backend returns snippets in the same form: an excerpt of the content, HTML-escaped, with matching
+words wrapped in <mark></mark>. Matches are first marked with private-use characters, which cannot
+come from an escaped query and survive html.EscapeString, and are only turned into tags after escaping,
+so blog content can never inject markup through a snippet.
+*/
+
+const (
+	markStart = "\uE000"
+	markStop  = "\uE001"
+	// maximum number of words around the first match in a snippet built in Go
+	snippetWords = 30
+	// queries are cut to this many words, to bound the cost of a search
+	maxSearchTerms = 20
+)
+
+var searchWord = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// searchTerms splits a query into lowercase, de-duplicated words, dropping any query syntax.
+// "or" is dropped too: it is a stop word for every backend and an operator to websearch_to_tsquery
+func searchTerms(query string) []string {
+	terms := []string{}
+	seen := map[string]bool{"or": true}
+	for _, word := range searchWord.FindAllString(strings.ToLower(query), -1) {
+		if !seen[word] && len(terms) < maxSearchTerms {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// markedToHTML escapes a snippet marked with markStart/markStop and turns the marks into <mark> tags
+func markedToHTML(marked string) string {
+	escaped := html.EscapeString(marked)
+	escaped = strings.ReplaceAll(escaped, markStart, "<mark>")
+	return strings.ReplaceAll(escaped, markStop, "</mark>")
+}
+
+// termPattern matches words starting with any of the terms, a rough stand-in for stemming
+func termPattern(terms []string) *regexp.Regexp {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(quoted, "|") + `)[\p{L}\p{N}]*`)
+}
+
+// countMatches is the number of words in text starting with any of the terms
+func countMatches(text string, terms []string) int {
+	if len(terms) == 0 {
+		return 0
+	}
+	return len(termPattern(terms).FindAllStringIndex(text, -1))
+}
+
+// buildSnippet returns up to snippetWords words of content around the first match, as HTML with matches marked
+func buildSnippet(content string, terms []string) string {
+	words := strings.Fields(content)
+	if len(words) == 0 {
+		return ""
+	}
+
+	pattern := termPattern(terms)
+	first := 0
+	for i, word := range words {
+		if pattern.MatchString(word) {
+			first = i
+			break
+		}
+	}
+
+	// start a few words before the first match, so it has some context
+	start := first - snippetWords/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	excerpt := strings.Join(words[start:end], " ")
+	marked := pattern.ReplaceAllString(excerpt, markStart+"$0"+markStop)
+	if start > 0 {
+		marked = "… " + marked
+	}
+	if end < len(words) {
+		marked = marked + " …"
+	}
+	return markedToHTML(marked)
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTerms(t *testing.T) {
+	require.Equal(t, []string{"go", "generics", "café"}, searchTerms(`"Go" -generics OR go Café!`))
+	require.Empty(t, searchTerms("  !!! "))
+	require.Len(t, searchTerms(strings.Repeat("word ", 50)+"other"), 2)
+}
+
+func TestBuildSnippet(t *testing.T) {
+	snippet := buildSnippet("Running <script>alert(1)</script> is fun", []string{"run", "alert"})
+	require.Equal(t, "<mark>Running</mark> &lt;script&gt;<mark>alert</mark>(1)&lt;/script&gt; is fun", snippet)
+
+	long := strings.Repeat("filler ", 50) + "needle " + strings.Repeat("filler ", 50)
+	snippet = buildSnippet(long, []string{"needle"})
+	require.True(t, strings.HasPrefix(snippet, "… "))
+	require.True(t, strings.HasSuffix(snippet, " …"))
+	require.Contains(t, snippet, "<mark>needle</mark>")
+	require.Len(t, strings.Fields(strings.Trim(snippet, "… ")), snippetWords)
+}
+
+func TestMarkedToHTML(t *testing.T) {
+	require.Equal(t, "a &amp; <mark>b</mark>", markedToHTML("a & "+markStart+"b"+markStop))
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+/*
+The sitemap lists every published blog, which may be more than fits in memory, so the backends walk them one
+at a time in id order rather than returning them like ListBlog does. Sitemaps split into pages of at most
+50,000 URLs, each covering a fixed range of ids, so a page is read with a range on the id rather than by skipping
+the blogs of the pages before it, and blogs published or unpublished meanwhile do not move the other pages.
+*/
+
+// SitemapFunc is called with the id and slug of each published blog and when it last changed
+type SitemapFunc func(id string, slug string, lastModified time.Time) error
+
+// lastModified is when the blog was last updated or, since publishing is not an update, published
+func (b blogRecord) lastModified() time.Time {
+	return changedAt(b.UpdateTime, b.PublishTime)
+}
+
+func changedAt(updateTime time.Time, publishTime time.Time) time.Time {
+	if publishTime.After(updateTime) {
+		return publishTime
+	}
+	return updateTime
+}
+
+func invalidSitemapBound(field string, id string) error {
+	return twirp.InvalidArgumentError(field, fmt.Sprintf("%q is not a blog id", id))
+}
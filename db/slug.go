@@ -0,0 +1,151 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/twitchtv/twirp"
+	"golang.org/x/text/unicode/norm"
+)
+
+/*
+Slugs address blogs in public URLs, which must not expose the ids of either backend. A slug is generated
+from the title when the blog is created and only changes when UpdateBlog sets one. Every slug a blog has
+had stays reserved to it, so old URLs keep resolving, and a slug is freed only when its blog is purged.
+*/
+
+// maxSlugLength leaves room for a collision suffix within a readable URL
+const maxSlugLength = 60
+
+// fallbackSlug is used for titles with nothing to transliterate, e.g. empty or only in an unsupported script
+const fallbackSlug = "post"
+
+// transliterations covers the letters that do not decompose into an ASCII letter and combining marks
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d", 'þ': "th", 'ı': "i", 'ħ': "h", 'ŋ': "ng",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i", 'й': "y",
+	'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f",
+	'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+	// Greek, accented vowels are decomposed first
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l",
+	'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f",
+	'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// slugify turns title into lowercase ASCII letters and digits separated by single hyphens. Accented letters
+// lose their accents, Cyrillic and Greek are transliterated and anything else separates words
+func slugify(title string) string {
+	if slug := slugWords(title); slug != "" {
+		return slug
+	}
+	return fallbackSlug
+}
+
+// normalizeSlug makes a slug requested by UpdateBlog URL-safe, it returns "" when the slug should be
+// generated from the title instead
+func normalizeSlug(slug string) (string, error) {
+	if strings.TrimSpace(slug) == "" {
+		return "", nil
+	}
+	normalized := slugWords(slug)
+	if normalized == "" {
+		return "", twirp.InvalidArgumentError("slug", "must contain letters or digits")
+	}
+	return normalized, nil
+}
+
+// slugWords is slugify without the fallback, it returns "" when title has nothing to transliterate
+func slugWords(title string) string {
+	var slug strings.Builder
+	hyphen := false
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if hyphen && slug.Len() > 0 {
+			slug.WriteByte('-')
+		}
+		hyphen = false
+		slug.WriteString(s)
+	}
+
+	for _, letter := range strings.ToLower(title) {
+		// letters like й are transliterated whole, the others are decomposed by NFKD, which splits letters
+		// from their accents and ligatures like ﬁ into their letters
+		if ascii, ok := transliterations[letter]; ok {
+			write(ascii)
+			continue
+		}
+		for _, r := range norm.NFKD.String(string(letter)) {
+			switch {
+			case unicode.Is(unicode.Mn, r):
+				// a combining mark of the previous letter
+			case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+				write(string(r))
+			case r == '\'' || r == '’':
+				// apostrophes join words, "don't" becomes "dont"
+			default:
+				if ascii, ok := transliterations[r]; ok {
+					write(ascii)
+					continue
+				}
+				hyphen = true
+			}
+		}
+	}
+
+	result := slug.String()
+	if len(result) > maxSlugLength {
+		result = result[:maxSlugLength]
+		// cut at the last word boundary, unless that would leave too little of the title
+		if i := strings.LastIndexByte(result, '-'); i > maxSlugLength/2 {
+			result = result[:i]
+		}
+		result = strings.TrimRight(result, "-")
+	}
+	return result
+}
+
+// uniqueSlug returns base, or base with the lowest suffix from -2 up, that is not taken
+func uniqueSlug(base string, taken map[string]bool) string {
+	if !taken[base] {
+		return base
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", base, i)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// isSlugVariant reports whether slug is base or base with a numeric suffix, the slugs uniqueSlug picks from
+func isSlugVariant(slug string, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix := strings.TrimPrefix(slug, base+"-")
+	if suffix == slug || suffix == "" {
+		return false
+	}
+	for _, r := range suffix {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// maxSlugAttempts bounds the retries of a generated slug that another blog took concurrently
+const maxSlugAttempts = 5
+
+func slugTaken(slug string) twirp.Error {
+	return twirp.NewError(twirp.AlreadyExists, fmt.Sprintf("Slug %q is used by another blog", slug))
+}
+
+func slugNotFound(slug string) twirp.Error {
+	return twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for slug: %v", slug))
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlugify(t *testing.T) {
+	for title, slug := range map[string]string{
+		"Hello, World!":               "hello-world",
+		"  Go 1.17 -- what's new?  ":  "go-1-17-whats-new",
+		"Crème brûlée à la française": "creme-brulee-a-la-francaise",
+		"Straße & Ærø":                "strasse-aero",
+		"Привет, мир":                 "privet-mir",
+		"Мой край":                    "moy-kray",
+		"Καλημέρα κόσμε":              "kalimera-kosme",
+		"ﬁnal":                        "final",
+		"日本語":                         fallbackSlug,
+		"":                            fallbackSlug,
+	} {
+		require.Equal(t, slug, slugify(title), title)
+	}
+
+	long := slugify(strings.Repeat("word ", 30))
+	require.LessOrEqual(t, len(long), maxSlugLength)
+	require.True(t, strings.HasSuffix(long, "word"), long)
+}
+
+func TestNormalizeSlug(t *testing.T) {
+	slug, err := normalizeSlug(" My New Slug ")
+	require.NoError(t, err)
+	require.Equal(t, "my-new-slug", slug)
+
+	slug, err = normalizeSlug("  ")
+	require.NoError(t, err)
+	require.Empty(t, slug)
+
+	_, err = normalizeSlug("!!!")
+	require.Error(t, err)
+}
+
+func TestUniqueSlug(t *testing.T) {
+	require.Equal(t, "go", uniqueSlug("go", map[string]bool{"go-2": true}))
+	require.Equal(t, "go-3", uniqueSlug("go", map[string]bool{"go": true, "go-2": true}))
+
+	require.True(t, isSlugVariant("go", "go"))
+	require.True(t, isSlugVariant("go-12", "go"))
+	require.False(t, isSlugVariant("go-web", "go"))
+	require.False(t, isSlugVariant("go-", "go"))
+	require.False(t, isSlugVariant("golang", "go"))
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"fmt"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// createStatus returns the status a CreateBlogRequest asks for and its publish_time, blogs are drafts unless
+// they are published right away
+func createStatus(status blogProto.BlogStatus, now time.Time) (blogProto.BlogStatus, time.Time, error) {
+	switch status {
+	case blogProto.BlogStatus_BLOG_STATUS_UNSPECIFIED, blogProto.BlogStatus_BLOG_STATUS_DRAFT:
+		return blogProto.BlogStatus_BLOG_STATUS_DRAFT, time.Time{}, nil
+	case blogProto.BlogStatus_BLOG_STATUS_PUBLISHED:
+		return status, now, nil
+	default:
+		return 0, time.Time{}, twirp.InvalidArgumentError("status", fmt.Sprintf("must be BLOG_STATUS_DRAFT or BLOG_STATUS_PUBLISHED, use PublishBlog to schedule, got %v", status))
+	}
+}
+
+// publishStatus returns the status and publish_time PublishBlog sets: a publish_time in the future schedules
+// the blog, otherwise it is published at that time, or now when unset
+func publishStatus(data *blogProto.PublishBlogRequest, now time.Time) (blogProto.BlogStatus, time.Time, error) {
+	if data.PublishTime == nil {
+		return blogProto.BlogStatus_BLOG_STATUS_PUBLISHED, now, nil
+	}
+	if err := data.PublishTime.CheckValid(); err != nil {
+		return 0, time.Time{}, twirp.InvalidArgumentError("publish_time", err.Error())
+	}
+	publishTime := data.PublishTime.AsTime()
+	if publishTime.After(now) {
+		return blogProto.BlogStatus_BLOG_STATUS_SCHEDULED, publishTime, nil
+	}
+	return blogProto.BlogStatus_BLOG_STATUS_PUBLISHED, publishTime, nil
+}
+
+// unpublishStatus returns the status UnpublishBlog sets, the publish_time is kept as a record of the last publication
+func unpublishStatus(data *blogProto.UnpublishBlogRequest) blogProto.BlogStatus {
+	if data.Archive {
+		return blogProto.BlogStatus_BLOG_STATUS_ARCHIVED
+	}
+	return blogProto.BlogStatus_BLOG_STATUS_DRAFT
+}
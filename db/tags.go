@@ -0,0 +1,67 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/twitchtv/twirp"
+)
+
+const (
+	maxTags           = 20
+	maxTagLength      = 50
+	maxCategoryLength = 100
+)
+
+// normalizeTags trims, lowercases, deduplicates and sorts tags, so every backend stores and matches them
+// the same way whatever casing clients send
+func normalizeTags(tags []string) ([]string, error) {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, twirp.InvalidArgumentError("tags", "must not be empty")
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, twirp.InvalidArgumentError("tags", fmt.Sprintf("must be at most %d characters, got %q", maxTagLength, tag))
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > maxTags {
+		return nil, twirp.InvalidArgumentError("tags", fmt.Sprintf("at most %d tags are allowed", maxTags))
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+func normalizeCategory(category string) (string, error) {
+	category = strings.TrimSpace(category)
+	if utf8.RuneCountInString(category) > maxCategoryLength {
+		return "", twirp.InvalidArgumentError("category", fmt.Sprintf("must be at most %d characters", maxCategoryLength))
+	}
+	return category, nil
+}
+
+// blogLabels are the normalized tags and category of a CreateBlogRequest or UpdateBlogRequest
+type blogLabels struct {
+	Tags     []string
+	Category string
+}
+
+func newBlogLabels(tags []string, category string) (blogLabels, error) {
+	normalizedTags, err := normalizeTags(tags)
+	if err != nil {
+		return blogLabels{}, err
+	}
+	normalizedCategory, err := normalizeCategory(category)
+	if err != nil {
+		return blogLabels{}, err
+	}
+	return blogLabels{Tags: normalizedTags, Category: normalizedCategory}, nil
+}
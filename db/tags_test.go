@@ -0,0 +1,29 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{" Go ", "web", "go", "API"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "go", "web"}, tags)
+
+	tags, err = normalizeTags(nil)
+	require.NoError(t, err)
+	require.Empty(t, tags)
+
+	_, err = normalizeTags([]string{"go", "  "})
+	require.Error(t, err)
+	_, err = normalizeTags([]string{strings.Repeat("x", maxTagLength+1)})
+	require.Error(t, err)
+	many := []string{}
+	for i := 0; i <= maxTags; i++ {
+		many = append(many, strings.Repeat("t", i+1))
+	}
+	_, err = normalizeTags(many)
+	require.Error(t, err)
+}
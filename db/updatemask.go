@@ -0,0 +1,63 @@
+package db
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"fmt"
+
+	"github.com/twitchtv/twirp"
+)
+
+// updateFields are the fields an UpdateBlogRequest changes, shared by every backend so they accept the same masks
+type updateFields struct {
+	Title    bool
+	Content  bool
+	Tags     bool
+	Category bool
+	Slug     bool
+	// ContentFormat changes how the content is rendered, not the content itself
+	ContentFormat bool
+}
+
+func (f updateFields) any() bool {
+	return f.Title || f.Content || f.Tags || f.Category || f.Slug || f.ContentFormat
+}
+
+// parseUpdateMask resolves the update_mask of data following https://google.aip.dev/134: an unset mask
+// changes the non-empty fields, "*" changes every field, otherwise only the listed fields change
+func parseUpdateMask(data *blogProto.UpdateBlogRequest) (updateFields, error) {
+	mask := data.GetUpdateMask()
+	if mask == nil {
+		fields := updateFields{Title: data.Title != "", Content: data.Content != "", Tags: len(data.Tags) > 0, Category: data.Category != "", Slug: data.Slug != "",
+			ContentFormat: data.ContentFormat != blogProto.ContentFormat_CONTENT_FORMAT_UNSPECIFIED}
+		if !fields.any() {
+			return fields, twirp.NewError(twirp.InvalidArgument, "Nothing to update, set title, content, tags, category, slug, content_format or update_mask")
+		}
+		return fields, nil
+	}
+
+	fields := updateFields{}
+	for _, path := range mask.GetPaths() {
+		switch path {
+		case "title":
+			fields.Title = true
+		case "content":
+			fields.Content = true
+		case "tags":
+			fields.Tags = true
+		case "category":
+			fields.Category = true
+		case "slug":
+			fields.Slug = true
+		case "content_format":
+			fields.ContentFormat = true
+		case "*":
+			fields = updateFields{Title: true, Content: true, Tags: true, Category: true, Slug: true, ContentFormat: true}
+		default:
+			return updateFields{}, twirp.NewError(twirp.InvalidArgument, fmt.Sprintf("Invalid update_mask path %q, use title, content, tags, category, slug, content_format or *", path))
+		}
+	}
+	if !fields.any() {
+		return fields, twirp.NewError(twirp.InvalidArgument, "update_mask must name at least one field")
+	}
+	return fields, nil
+}
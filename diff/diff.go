@@ -0,0 +1,169 @@
+// Package diff computes line-level differences between two texts and formats them as unified diffs
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of an Edit
+type Op byte
+
+const (
+	Equal  Op = ' '
+	Delete Op = '-'
+	Insert Op = '+'
+)
+
+// Edit is one line of an edit script turning a into b
+type Edit struct {
+	Op   Op
+	Line string
+}
+
+// Lines splits text into lines, a trailing newline does not start another line
+func Lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Compute returns a shortest edit script turning a into b, using Myers' O(ND) algorithm
+// (http://www.xmailserver.org/diff2.pdf). Deletions come before insertions where both are possible
+func Compute(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	max := n + m
+	if max == 0 {
+		return nil
+	}
+
+	// v[offset+k] is the furthest x reached on diagonal k = x - y, trace keeps v as it was before each round d
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	trace := [][]int{}
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			x := v[offset+k-1] + 1
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, offset)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks trace back from the end of a and b, collecting the edits in reverse
+func backtrack(a, b []string, trace [][]int, offset int) []Edit {
+	edits := []Edit{}
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, Edit{Equal, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, Edit{Insert, b[y-1]})
+			} else {
+				edits = append(edits, Edit{Delete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// ContextLines is the number of unchanged lines Unified shows around each change, as diff -u does
+const ContextLines = 3
+
+// Unified returns the unified diff turning from into to, with the file headers fromName and toName.
+// It returns "" when the texts have the same lines
+func Unified(fromName, toName, from, to string) string {
+	context := ContextLines
+	edits := Compute(Lines(from), Lines(to))
+
+	// positions[i] is the line of a and of b that edits[i] starts at
+	type position struct{ a, b int }
+	positions := make([]position, len(edits)+1)
+	changes := []int{}
+	for i, edit := range edits {
+		next := positions[i]
+		if edit.Op != Insert {
+			next.a++
+		}
+		if edit.Op != Delete {
+			next.b++
+		}
+		positions[i+1] = next
+		if edit.Op != Equal {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+	for first := 0; first < len(changes); {
+		// changes separated by at most twice the context share a hunk, since their context lines would overlap
+		last := first
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*context+1 {
+			last++
+		}
+		start := changes[first] - context
+		if start < 0 {
+			start = 0
+		}
+		end := changes[last] + context + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(positions[start].a, positions[end].a-positions[start].a),
+			hunkRange(positions[start].b, positions[end].b-positions[start].b))
+		for _, edit := range edits[start:end] {
+			fmt.Fprintf(&out, "%c%s\n", edit.Op, edit.Line)
+		}
+		first = last + 1
+	}
+	return out.String()
+}
+
+// hunkRange formats the 0-based start and length of a hunk side like GNU diff: lines are numbered from 1,
+// a length of 1 is left out and an empty side gives the line before it
+func hunkRange(start, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, length)
+	}
+}
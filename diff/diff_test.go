@@ -0,0 +1,73 @@
+package diff_test
+
+import (
+	"blog-service/diff"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	a := diff.Lines("a\nb\nc\na\nb\nb\na\n")
+	b := diff.Lines("c\nb\na\nb\na\nc\n")
+	edits := diff.Compute(a, b)
+
+	// the script is a shortest one and replays a into b
+	changes := 0
+	from, to := []string{}, []string{}
+	for _, edit := range edits {
+		if edit.Op != diff.Equal {
+			changes++
+		}
+		if edit.Op != diff.Insert {
+			from = append(from, edit.Line)
+		}
+		if edit.Op != diff.Delete {
+			to = append(to, edit.Line)
+		}
+	}
+	require.Equal(t, 5, changes)
+	require.Equal(t, a, from)
+	require.Equal(t, b, to)
+
+	require.Nil(t, diff.Compute(nil, nil))
+	require.Equal(t, []diff.Edit{{Op: diff.Insert, Line: "x"}}, diff.Compute(nil, []string{"x"}))
+}
+
+func TestUnified(t *testing.T) {
+	require.Equal(t, "", diff.Unified("v1", "v2", "same\n", "same"))
+
+	from := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\ntwelve\n"
+	to := strings.Replace(strings.Replace(from, "two", "2", 1), "ten\n", "", 1)
+	require.Equal(t, `--- v1
++++ v2
+@@ -1,5 +1,5 @@
+ one
+-two
++2
+ three
+ four
+ five
+@@ -7,6 +7,5 @@
+ seven
+ eight
+ nine
+-ten
+ eleven
+ twelve
+`, diff.Unified("v1", "v2", from, to))
+
+	// changes whose context would overlap share a hunk
+	require.Equal(t, `--- a
++++ b
+@@ -1,3 +1,3 @@
+-x
++y
+ keep
+-x
++y
+`, diff.Unified("a", "b", "x\nkeep\nx", "y\nkeep\ny"))
+
+	require.Equal(t, "--- a\n+++ b\n@@ -0,0 +1 @@\n+new\n", diff.Unified("a", "b", "", "new"))
+}
@@ -3,37 +3,163 @@ package main
 // import local packages with <module_name>/<package_name>
 // in this case, module_name is blog-service (see go.mod)
 import (
+	"blog-service/auth"
 	config "blog-service/config"
-	blogProto "blog-service/rpc/blog"
+	"blog-service/render"
 	"blog-service/server"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"os"
+	"time"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/grpc"
 )
 
-func startServer() {
+// newVerifier loads the configured JWKS, or returns nil when auth is not configured
+func newVerifier(settings config.AuthSettings) (*auth.Verifier, error) {
+	if !settings.Enabled() {
+		return nil, nil
+	}
+
+	var keys auth.KeySource
+	if settings.JWKSFile != "" {
+		fileKeys, err := auth.LoadJWKSFile(settings.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = fileKeys
+	} else {
+		remoteKeys := auth.NewRemoteKeySource(settings.JWKSURL)
+		if err := remoteKeys.Refresh(); err != nil {
+			return nil, err
+		}
+		keys = remoteKeys
+	}
+	return auth.NewVerifier(keys, settings.Issuer, settings.Audience), nil
+}
+
+func startServer(settings config.Settings) {
 	fmt.Println("Starting server")
 
-	// assign server variable to the address of the Server struct in the server package
-	server := &server.Server{}
+	// assign blogServer variable to the address of the Server struct in the server package
+	blogServer := &server.Server{}
+	commentServer := &server.CommentServer{}
+
+	twirpOptions := []interface{}{}
+	grpcOptions := []grpc.ServerOption{}
 
-	// assign handler variable to the TwirpServer generated by the NewBlogServiceServer function in service.twirp.go
-	handler := blogProto.NewBlogServiceServer(server)
+	verifier, err := newVerifier(settings.Auth)
+	if err != nil {
+		log.Fatalf("Unable to set up authentication: %v", err)
+	}
+	if verifier != nil {
+		// each method is checked against server.Policy, see auth/policy.go
+		twirpOptions = append(twirpOptions, twirp.WithServerInterceptors(server.Policy.TwirpInterceptor()))
+		grpcOptions = append(grpcOptions, grpc.ChainUnaryInterceptor(verifier.UnaryInterceptor(), server.Policy.UnaryInterceptor()))
+	} else {
+		fmt.Println("WARNING: authentication is disabled, set auth.jwks_url or auth.jwks_file to enable it")
+	}
+
+	// Twirp, the feeds and the sitemaps share the HTTP port, with only Twirp behind the verifier, see server/http.go
+	handler := server.NewHTTPHandler(blogServer, commentServer, verifier, settings, twirpOptions...)
+
+	// serve the same Server over gRPC on its own port, so gRPC-native clients do not need to go through Twirp
+	go startGrpcServer(blogServer, commentServer, settings.GrpcPort, grpcOptions...)
 
-	fmt.Printf("Server listening on port: %v\n", config.Port)
+	fmt.Printf("Server listening on port: %v\n", settings.Port)
 
 	// format the port number to match expected argument format for http.ListenAndServe function
-	listener := fmt.Sprintf(":%v", config.Port)
+	listener := fmt.Sprintf(":%v", settings.Port)
 
 	// see docs for more information on http package: https://pkg.go.dev/net/http#example-ListenAndServe
 	http.ListenAndServe(listener, handler)
 }
 
+func startGrpcServer(s *server.Server, comments *server.CommentServer, port int, opts ...grpc.ServerOption) {
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%v", port))
+	if err != nil {
+		log.Fatalf("Unable to listen on gRPC port %v: %v", port, err)
+	}
+
+	fmt.Printf("gRPC server listening on port: %v\n", port)
+
+	// see docs for more information on serving gRPC: https://pkg.go.dev/google.golang.org/grpc#Server.Serve
+	if err := server.NewGrpcServer(s, comments, opts...).Serve(grpcListener); err != nil {
+		log.Fatalf("gRPC server stopped: %v", err)
+	}
+}
+
+// startPurge permanently removes blogs that have been in the trash for longer than the retention, and their comments
+func startPurge(settings config.TrashSettings) {
+	retention := time.Duration(settings.Retention)
+	if retention == 0 {
+		fmt.Println("Deleted blogs are kept forever, set trash.retention to purge them")
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(settings.PurgeInterval))
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		cutoff := time.Now().Add(-retention)
+		purged, err := config.DB.PurgeDeletedBlogs(ctx, cutoff)
+		if err != nil {
+			log.Printf("Unable to purge deleted blogs: %v", err)
+		} else if purged > 0 {
+			fmt.Printf("Purged %d blogs deleted more than %v ago\n", purged, retention)
+		}
+		purged, err = config.Comments.PurgeOrphanedComments(ctx, cutoff)
+		cancel()
+		if err != nil {
+			log.Printf("Unable to purge the comments of deleted blogs: %v", err)
+		} else if purged > 0 {
+			fmt.Printf("Purged %d comments of blogs deleted more than %v ago\n", purged, retention)
+		}
+		<-ticker.C
+	}
+}
+
+// startScheduler publishes the blogs PublishBlog scheduled once their publish_time arrives
+func startScheduler(settings config.PublishSettings) {
+	ticker := time.NewTicker(time.Duration(settings.SchedulerInterval))
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		published, err := config.DB.PublishScheduledBlogs(ctx, time.Now())
+		cancel()
+		if err != nil {
+			log.Printf("Unable to publish scheduled blogs: %v", err)
+		} else if published > 0 {
+			fmt.Printf("Published %d scheduled blogs\n", published)
+		}
+		<-ticker.C
+	}
+}
+
 func main() {
-	db := "mongo"
-	if len(os.Args) > 1 {
-		db = os.Args[1]
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
+
+	// settings come from flags, BLOG_* environment variables and an optional config file, see config/settings.go
+	settings, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		config.Usage(os.Stdout)
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
 	}
-	config.SetDB(db)
-	startServer()
+	config.SetDB(settings)
+	config.RenderCache = render.NewCache(settings.Render.CacheSize)
+	go startPurge(settings.Trash)
+	go startScheduler(settings.Publish)
+	startServer(settings)
 }
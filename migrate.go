@@ -0,0 +1,86 @@
+package main
+
+import (
+	config "blog-service/config"
+	"blog-service/db"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+const migrateUsage = `usage: blog-service migrate [up|down|status] [-steps n] [-dry-run] [settings flags]
+  up      apply every pending Postgres migration (default)
+  down    revert the last -steps applied migrations (default 1)
+  status  list pending migrations
+  -dry-run prints the SQL that up or down would run, without running it
+`
+
+// runMigrate applies or reverts the Postgres migrations embedded from db/migrations/postgres
+func runMigrate(args []string) {
+	direction := "up"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		direction, args = args[0], args[1:]
+	}
+
+	steps := 1
+	dryRun := false
+	settings, err := config.Load(args, os.Getenv, func(fs *flag.FlagSet) {
+		fs.IntVar(&steps, "steps", 1, "number of migrations to revert with down")
+		fs.BoolVar(&dryRun, "dry-run", false, "print pending migrations instead of running them")
+	})
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Print(migrateUsage)
+		config.Usage(os.Stdout)
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// migrations only exist for Postgres, whatever database the settings select for serving
+	settings.Database = "postgres"
+	if err := settings.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	sqlDB, err := db.OpenPostgres(settings.Postgres.DSN())
+	if err != nil {
+		log.Fatalf("Unable to connect to Postgres: %v", err)
+	}
+	defer sqlDB.Close()
+
+	migrator, err := db.NewMigrator(sqlDB, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	switch direction {
+	case "up":
+		err = migrator.Up(ctx, dryRun)
+	case "down":
+		if steps < 1 {
+			log.Fatalf("-steps must be at least 1, got %d", steps)
+		}
+		err = migrator.Down(ctx, steps, dryRun)
+	case "status":
+		var pending []db.Migration
+		pending, err = migrator.Pending(ctx)
+		for _, migration := range pending {
+			fmt.Printf("pending: %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(pending) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	default:
+		fmt.Print(migrateUsage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
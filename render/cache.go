@@ -0,0 +1,68 @@
+package render
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache holds the rendered HTML of the most recently read blogs, one version per blog
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	// order lists the entries from the most to the least recently used
+	order *list.List
+}
+
+type cacheEntry struct {
+	id      string
+	version int64
+	html    string
+}
+
+// NewCache returns a cache of the given number of blogs, a size of 0 disables caching
+func NewCache(size int) *Cache {
+	return &Cache{size: size, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// Get returns the HTML cached for the blog at version
+func (c *Cache) Get(id string, version int64) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[id]
+	if !ok || element.Value.(*cacheEntry).version != version {
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).html, true
+}
+
+// Put caches the HTML of the blog at version, replacing any other version of it
+func (c *Cache) Put(id string, version int64, html string) {
+	if c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[id]; ok {
+		element.Value = &cacheEntry{id: id, version: version, html: html}
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[id] = c.order.PushFront(&cacheEntry{id: id, version: version, html: html})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).id)
+	}
+}
+
+// Invalidate removes the blog from the cache
+func (c *Cache) Invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[id]; ok {
+		c.order.Remove(element)
+		delete(c.entries, id)
+	}
+}
@@ -0,0 +1,45 @@
+package render_test
+
+import (
+	"blog-service/render"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	cache := render.NewCache(2)
+	cache.Put("a", 1, "a1")
+	cache.Put("b", 1, "b1")
+
+	html, ok := cache.Get("a", 1)
+	require.True(t, ok)
+	require.Equal(t, "a1", html)
+	_, ok = cache.Get("a", 2)
+	require.False(t, ok, "another version is a miss")
+
+	// b is the least recently used
+	cache.Put("c", 1, "c1")
+	_, ok = cache.Get("b", 1)
+	require.False(t, ok)
+	_, ok = cache.Get("a", 1)
+	require.True(t, ok)
+
+	// a newer version replaces the older one
+	cache.Put("a", 2, "a2")
+	_, ok = cache.Get("a", 1)
+	require.False(t, ok)
+	html, _ = cache.Get("a", 2)
+	require.Equal(t, "a2", html)
+
+	cache.Invalidate("a")
+	_, ok = cache.Get("a", 2)
+	require.False(t, ok)
+	html, _ = cache.Get("c", 1)
+	require.Equal(t, "c1", html)
+
+	disabled := render.NewCache(0)
+	disabled.Put("a", 1, "a1")
+	_, ok = disabled.Get("a", 1)
+	require.False(t, ok)
+}
@@ -0,0 +1,28 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+/*
+Markdown renders CommonMark with goldmark, plus strikethrough (~~). Raw HTML is passed through like CommonMark
+does, which is only safe because the result is sanitized, see Sanitize.
+*/
+
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough),
+	goldmark.WithRendererOptions(html.WithUnsafe(), html.WithXHTML()),
+)
+
+// Markdown renders src to HTML, which must be sanitized before it is shown
+func Markdown(src string) string {
+	var out bytes.Buffer
+	// rendering to a bytes.Buffer cannot fail
+	markdown.Convert([]byte(strings.ReplaceAll(src, "\r\n", "\n")), &out)
+	return strings.TrimSuffix(out.String(), "\n")
+}
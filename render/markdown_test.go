@@ -0,0 +1,54 @@
+package render_test
+
+import (
+	"blog-service/render"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown(t *testing.T) {
+	cases := []struct {
+		name, markdown, html string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one\ntwo</p>\n<p>three</p>"},
+		{"headings", "# Title #\n\nSub\n---\n###### six", "<h1>Title</h1>\n<h2>Sub</h2>\n<h6>six</h6>"},
+		{"emphasis", "*em* **strong** ***both*** ~~del~~", "<p><em>em</em> <strong>strong</strong> <em><strong>both</strong></em> <del>del</del></p>"},
+		{"intraword underscores", "snake_case_name and _em_", "<p>snake_case_name and <em>em</em></p>"},
+		{"unmatched delimiters", "2 * 3 * 4 and **open", "<p>2 * 3 * 4 and **open</p>"},
+		{"rule of 3", "*foo**bar*", "<p><em>foo**bar</em></p>"},
+		{"nested emphasis", "**a *b* c**", "<p><strong>a <em>b</em> c</strong></p>"},
+		{"code span", "use `a < b` and `` `x` ``", "<p>use <code>a &lt; b</code> and <code>`x`</code></p>"},
+		{"escapes", `\*not em\* 1 \< 2 & 3`, "<p>*not em* 1 &lt; 2 &amp; 3</p>"},
+		{"entities", "&copy; &#169; &nope", "<p>© © &amp;nope</p>"},
+		{"hard breaks", "one  \ntwo\\\nthree", "<p>one<br />\ntwo<br />\nthree</p>"},
+		{"links", `[a *b*](http://x.com/a_(b) "T") [no link] [x](<y z>)`, `<p><a href="http://x.com/a_(b)" title="T">a <em>b</em></a> [no link] <a href="y%20z">x</a></p>`},
+		{"no links in links", "[a [b](c) d](e)", `<p>[a <a href="c">b</a> d](e)</p>`},
+		{"images", `![alt *text*](/i.png)`, `<p><img src="/i.png" alt="alt text" /></p>`},
+		{"autolinks", "<https://x.com> <me@x.com>", `<p><a href="https://x.com">https://x.com</a> <a href="mailto:me@x.com">me@x.com</a></p>`},
+		{"inline html", "a <span class=\"x\">b</span> c", `<p>a <span class="x">b</span> c</p>`},
+		{"fenced code", "```go\nfunc() {}\n  <b>\n```", "<pre><code class=\"language-go\">func() {}\n  &lt;b&gt;\n</code></pre>"},
+		{"unclosed fence", "~~~\ncode", "<pre><code>code\n</code></pre>"},
+		{"indented code", "    a\n\n    b\n\nc", "<pre><code>a\n\nb\n</code></pre>\n<p>c</p>"},
+		{"block quotes", "> quoted\nlazy\n> > nested", "<blockquote>\n<p>quoted\nlazy</p>\n<blockquote>\n<p>nested</p>\n</blockquote>\n</blockquote>"},
+		{"thematic breaks", "a\n\n* * *\n___", "<p>a</p>\n<hr />\n<hr />"},
+		{"tight list", "- one\n- two\n  continued\n- three", "<ul>\n<li>one</li>\n<li>two\ncontinued</li>\n<li>three</li>\n</ul>"},
+		{"loose list", "1. one\n\n2. two", "<ol>\n<li>\n<p>one</p>\n</li>\n<li>\n<p>two</p>\n</li>\n</ol>"},
+		{"ordered start", "3) three\n4) four", "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>"},
+		{"nested list", "- a\n  - b\n- c", "<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul>\n</li>\n<li>c</li>\n</ul>"},
+		{"list interrupts paragraph", "text\n- item", "<p>text</p>\n<ul>\n<li>item</li>\n</ul>"},
+		{"numbers do not interrupt", "in\n2017. was a year", "<p>in\n2017. was a year</p>"},
+		{"html block", "<div>\n*raw*\n</div>\n\n*md*", "<div>\n*raw*\n</div>\n<p><em>md</em></p>"},
+		{"tabs and CRLF", "\tcode\r\n\r\ntext", "<pre><code>code\n</code></pre>\n<p>text</p>"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.html, render.Markdown(c.markdown))
+		})
+	}
+}
+
+func TestPlain(t *testing.T) {
+	require.Equal(t, "<p>a &lt;b&gt;<br />\nc</p>\n<p>*d*</p>", render.Plain("a <b>\nc\n\n\n*d*\n"))
+	require.Equal(t, "", render.Plain("\n\n"))
+}
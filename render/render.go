@@ -0,0 +1,30 @@
+// Package render turns blog content into HTML that is safe to embed in a page
+package render
+
+import (
+	"strings"
+)
+
+// escaper escapes text for HTML content and double-quoted attribute values
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// Plain renders text as paragraphs separated by blank lines, keeping its line breaks
+func Plain(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	var out strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		if out.Len() > 0 {
+			out.WriteByte('\n')
+		}
+		lines := strings.Split(paragraph, "\n")
+		for i, line := range lines {
+			lines[i] = escaper.Replace(line)
+		}
+		out.WriteString("<p>" + strings.Join(lines, "<br />\n") + "</p>")
+	}
+	return out.String()
+}
@@ -0,0 +1,88 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+/*
+Sanitize is an allowlist enforced by bluemonday: elements and attributes not listed below are removed, keeping
+the text of removed elements except for those whose content is not text meant to be read, like scripts. URLs
+must be relative or use one of safeSchemes. The input is first parsed as HTML and serialized again, which
+closes the elements it leaves open so the result cannot break the page it is embedded in.
+*/
+
+// allowedTags maps the elements Sanitize keeps to the attributes they may keep
+var allowedTags = map[string][]string{
+	"a": {"href", "title"}, "abbr": {"title"}, "b": nil, "blockquote": nil, "br": nil, "code": {"class"},
+	"dd": nil, "del": nil, "dl": nil, "dt": nil, "em": nil, "figcaption": nil, "figure": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil, "hr": nil, "i": nil,
+	"img": {"src", "alt", "title", "width", "height"}, "ins": nil, "kbd": nil, "li": nil, "mark": nil,
+	"ol": {"start"}, "p": nil, "pre": nil, "q": nil, "s": nil, "small": nil, "strong": nil, "sub": nil,
+	"sup": nil, "table": nil, "tbody": nil, "td": nil, "th": nil, "thead": nil, "tr": nil, "ul": nil,
+}
+
+// droppedTags are removed together with their content
+var droppedTags = []string{
+	"script", "style", "iframe", "object", "embed", "noscript", "noembed", "noframes", "template", "textarea",
+	"title", "xmp", "svg", "math", "select", "head", "plaintext",
+}
+
+var safeSchemes = []string{"http", "https", "mailto"}
+
+var (
+	languageClass = regexp.MustCompile(`^language-[A-Za-z0-9_+#.-]+$`)
+	number        = regexp.MustCompile(`^[0-9]{1,9}$`)
+)
+
+// attributePatterns restricts the values of the attributes that are not URLs or free text
+var attributePatterns = map[string]*regexp.Regexp{
+	"class": languageClass, "start": number, "width": number, "height": number,
+}
+
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	for tag, attributes := range allowedTags {
+		p.AllowElements(tag)
+		for _, attribute := range attributes {
+			if pattern, ok := attributePatterns[attribute]; ok {
+				p.AllowAttrs(attribute).Matching(pattern).OnElements(tag)
+			} else {
+				p.AllowAttrs(attribute).OnElements(tag)
+			}
+		}
+	}
+	p.SkipElementsContent(droppedTags...)
+	p.AllowURLSchemes(safeSchemes...)
+	p.AllowRelativeURLs(true)
+	p.RequireParseableURLs(true)
+	// links in posts are not endorsed by the site, and must not pass on its ranking
+	p.RequireNoFollowOnLinks(true)
+	return p
+}
+
+// Sanitize removes everything from src that is not on the allowlist, and closes the elements it leaves open
+func Sanitize(src string) string {
+	return policy.Sanitize(balance(src))
+}
+
+// balance parses src as the content of a <div> and serializes it again, with every element closed
+func balance(src string) string {
+	context := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(src), context)
+	if err != nil {
+		// reading a strings.Reader cannot fail
+		return ""
+	}
+	var out strings.Builder
+	for _, node := range nodes {
+		html.Render(&out, node)
+	}
+	return out.String()
+}
@@ -0,0 +1,43 @@
+package render_test
+
+import (
+	"blog-service/render"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		name, html, sanitized string
+	}{
+		{"allowed", `<p>a <em>b</em><br>c</p>`, `<p>a <em>b</em><br/>c</p>`},
+		{"unknown tags keep their text", `<div onclick="x()"><span>text</span></div>`, `text`},
+		{"scripts are dropped with their content", `a<script>alert(1)</script><style>p{}</style>b`, `ab`},
+		{"nested dropped elements", `<svg><svg></svg><a href="/x">x</a></svg>ok`, `ok`},
+		{"event handlers", `<img src="/a.png" onerror="alert(1)" alt="a">`, `<img src="/a.png" alt="a"/>`},
+		{"javascript links", `<a href="javascript:alert(1)">x</a>`, `x`},
+		{"obfuscated schemes", `<a href="jav&#x61;script:x">a</a><a href=" java\tscript:x">b</a><img src="data:image/png;base64,AA">`, `ab`},
+		{"safe links", `<a href="https://x.com/a?b=c:d" title="t">a</a><a href="/p:1">b</a><a href="mailto:me@x.com">c</a>`, `<a href="https://x.com/a?b=c:d" title="t" rel="nofollow">a</a><a href="/p:1" rel="nofollow">b</a><a href="mailto:me@x.com" rel="nofollow">c</a>`},
+		{"rel cannot be set", `<a href="/" rel="opener" target="_blank">a</a>`, `<a href="/" rel="nofollow">a</a>`},
+		{"classes", `<code class="language-go">a</code><code class="evil">b</code><p class="x">c</p>`, `<code class="language-go">a</code><code>b</code><p>c</p>`},
+		{"numbers", `<ol start="3"><li>a</li></ol><ol start="x"></ol><img width="10" height="10px">`, `<ol start="3"><li>a</li></ol><ol></ol><img width="10"/>`},
+		{"unclosed tags are closed", `<p><strong>a`, `<p><strong>a</strong></p>`},
+		{"end tags close inner elements", `<ul><li><em>a</ul>b</em>`, `<ul><li><em>a</em></li></ul><em>b</em>`},
+		{"stray end tags", `a</p></div>`, `a<p></p>`},
+		{"text is escaped", `a &lt;b&gt; &amp; "c" < d`, `a &lt;b&gt; &amp; &#34;c&#34; &lt; d`},
+		{"comments and doctypes", `<!DOCTYPE html><!-- <script> -->a`, `a`},
+		{"attribute values are escaped", `<a title='"><script>' href="/">a</a>`, `<a title="&#34;&gt;&lt;script&gt;" href="/" rel="nofollow">a</a>`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.sanitized, render.Sanitize(c.html))
+		})
+	}
+}
+
+func TestSanitizeMarkdown(t *testing.T) {
+	// raw HTML and link destinations in Markdown go through the same allowlist
+	markdown := "[click](javascript:alert(1)) <img src=x onerror=alert(1)>\n\n<script>\nalert(1)\n</script>"
+	require.Equal(t, `<p>click <img src="x"/></p>`+"\n", render.Sanitize(render.Markdown(markdown)))
+}
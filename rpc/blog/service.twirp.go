@@ -16,12 +16,6 @@ import proto "google.golang.org/protobuf/proto"
 import twirp "github.com/twitchtv/twirp"
 import ctxsetters "github.com/twitchtv/twirp/ctxsetters"
 
-import bytes "bytes"
-import errors "errors"
-import io "io"
-import path "path"
-import url "net/url"
-
 // Version compatibility assertion.
 // If the constant is not defined in the package, that likely means
 // the package needs to be updated to work with this generated code.
@@ -37,11 +31,48 @@ type BlogService interface {
 
 	GetBlog(context.Context, *GetBlogRequest) (*GetBlogResponse, error)
 
+	// returns the blog by its current slug or one of its previous slugs, in which case the slug of the
+	// response differs from the request and callers should redirect to it
+	GetBlogBySlug(context.Context, *GetBlogBySlugRequest) (*GetBlogResponse, error)
+
 	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
 
+	// moves the blog to the trash, where it is hidden from GetBlog, ListBlog and SearchBlogs
+	// until it is restored with UndeleteBlog or purged after the configured retention
 	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
 
+	UndeleteBlog(context.Context, *UndeleteBlogRequest) (*UndeleteBlogResponse, error)
+
+	// publishes or schedules a blog, blogs are created as drafts unless CreateBlog asks otherwise
+	PublishBlog(context.Context, *PublishBlogRequest) (*UpdateBlogResponse, error)
+
+	// turns a published or scheduled blog back into a draft, or archives it
+	UnpublishBlog(context.Context, *UnpublishBlogRequest) (*UpdateBlogResponse, error)
+
 	ListBlog(context.Context, *ListBlogRequest) (*ListBlogResponse, error)
+
+	ListDeletedBlogs(context.Context, *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error)
+
+	SearchBlogs(context.Context, *SearchBlogsRequest) (*SearchBlogsResponse, error)
+
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+
+	ListBlogRevisions(context.Context, *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error)
+
+	GetBlogRevision(context.Context, *GetBlogRevisionRequest) (*BlogRevision, error)
+
+	DiffBlogRevisions(context.Context, *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error)
+
+	// restores the title and content of a revision with an update, so the reverted state becomes a revision too
+	RevertBlog(context.Context, *RevertBlogRequest) (*UpdateBlogResponse, error)
+
+	// create, get or delete up to 100 blogs in one call, reporting the outcome of each item separately
+	// unless all_or_nothing is set
+	BatchCreateBlogs(context.Context, *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error)
+
+	BatchGetBlogs(context.Context, *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error)
+
+	BatchDeleteBlogs(context.Context, *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error)
 }
 
 // ===========================
@@ -50,7 +81,7 @@ type BlogService interface {
 
 type blogServiceProtobufClient struct {
 	client      HTTPClient
-	urls        [5]string
+	urls        [19]string
 	interceptor twirp.Interceptor
 	opts        twirp.ClientOptions
 }
@@ -78,12 +109,26 @@ func NewBlogServiceProtobufClient(baseURL string, client HTTPClient, opts ...twi
 	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
 	serviceURL := sanitizeBaseURL(baseURL)
 	serviceURL += baseServicePath(pathPrefix, "service", "BlogService")
-	urls := [5]string{
+	urls := [19]string{
 		serviceURL + "CreateBlog",
 		serviceURL + "GetBlog",
+		serviceURL + "GetBlogBySlug",
 		serviceURL + "UpdateBlog",
 		serviceURL + "DeleteBlog",
+		serviceURL + "UndeleteBlog",
+		serviceURL + "PublishBlog",
+		serviceURL + "UnpublishBlog",
 		serviceURL + "ListBlog",
+		serviceURL + "ListDeletedBlogs",
+		serviceURL + "SearchBlogs",
+		serviceURL + "ListTags",
+		serviceURL + "ListBlogRevisions",
+		serviceURL + "GetBlogRevision",
+		serviceURL + "DiffBlogRevisions",
+		serviceURL + "RevertBlog",
+		serviceURL + "BatchCreateBlogs",
+		serviceURL + "BatchGetBlogs",
+		serviceURL + "BatchDeleteBlogs",
 	}
 
 	return &blogServiceProtobufClient{
@@ -186,6 +231,52 @@ func (c *blogServiceProtobufClient) callGetBlog(ctx context.Context, in *GetBlog
 	return out, nil
 }
 
+func (c *blogServiceProtobufClient) GetBlogBySlug(ctx context.Context, in *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogBySlug")
+	caller := c.callGetBlogBySlug
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogBySlugRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogBySlugRequest) when calling interceptor")
+					}
+					return c.callGetBlogBySlug(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callGetBlogBySlug(ctx context.Context, in *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+	out := new(GetBlogResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
 func (c *blogServiceProtobufClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest) (*UpdateBlogResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
@@ -217,7 +308,7 @@ func (c *blogServiceProtobufClient) UpdateBlog(ctx context.Context, in *UpdateBl
 
 func (c *blogServiceProtobufClient) callUpdateBlog(ctx context.Context, in *UpdateBlogRequest) (*UpdateBlogResponse, error) {
 	out := new(UpdateBlogResponse)
-	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -263,7 +354,7 @@ func (c *blogServiceProtobufClient) DeleteBlog(ctx context.Context, in *DeleteBl
 
 func (c *blogServiceProtobufClient) callDeleteBlog(ctx context.Context, in *DeleteBlogRequest) (*DeleteBlogResponse, error) {
 	out := new(DeleteBlogResponse)
-	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -278,26 +369,26 @@ func (c *blogServiceProtobufClient) callDeleteBlog(ctx context.Context, in *Dele
 	return out, nil
 }
 
-func (c *blogServiceProtobufClient) ListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
+func (c *blogServiceProtobufClient) UndeleteBlog(ctx context.Context, in *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
-	caller := c.callListBlog
+	ctx = ctxsetters.WithMethodName(ctx, "UndeleteBlog")
+	caller := c.callUndeleteBlog
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+		caller = func(ctx context.Context, req *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListBlogRequest)
+					typedReq, ok := req.(*UndeleteBlogRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*UndeleteBlogRequest) when calling interceptor")
 					}
-					return c.callListBlog(ctx, typedReq)
+					return c.callUndeleteBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListBlogResponse)
+				typedResp, ok := resp.(*UndeleteBlogResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*UndeleteBlogResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -307,9 +398,9 @@ func (c *blogServiceProtobufClient) ListBlog(ctx context.Context, in *ListBlogRe
 	return caller(ctx, in)
 }
 
-func (c *blogServiceProtobufClient) callListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
-	out := new(ListBlogResponse)
-	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+func (c *blogServiceProtobufClient) callUndeleteBlog(ctx context.Context, in *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+	out := new(UndeleteBlogResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -324,76 +415,118 @@ func (c *blogServiceProtobufClient) callListBlog(ctx context.Context, in *ListBl
 	return out, nil
 }
 
-// =======================
-// BlogService JSON Client
-// =======================
-
-type blogServiceJSONClient struct {
-	client      HTTPClient
-	urls        [5]string
-	interceptor twirp.Interceptor
-	opts        twirp.ClientOptions
+func (c *blogServiceProtobufClient) PublishBlog(ctx context.Context, in *PublishBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "PublishBlog")
+	caller := c.callPublishBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PublishBlogRequest) when calling interceptor")
+					}
+					return c.callPublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
 }
 
-// NewBlogServiceJSONClient creates a JSON client that implements the BlogService interface.
-// It communicates using JSON and can be configured with a custom HTTPClient.
-func NewBlogServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) BlogService {
-	if c, ok := client.(*http.Client); ok {
-		client = withoutRedirects(c)
+func (c *blogServiceProtobufClient) callPublishBlog(ctx context.Context, in *PublishBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
 
-	clientOpts := twirp.ClientOptions{}
-	for _, o := range opts {
-		o(&clientOpts)
-	}
+	callClientResponseReceived(ctx, c.opts.Hooks)
 
-	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
-	literalURLs := false
-	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
-	var pathPrefix string
-	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
-		pathPrefix = "/twirp" // default prefix
-	}
+	return out, nil
+}
 
-	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
-	serviceURL := sanitizeBaseURL(baseURL)
-	serviceURL += baseServicePath(pathPrefix, "service", "BlogService")
-	urls := [5]string{
-		serviceURL + "CreateBlog",
-		serviceURL + "GetBlog",
-		serviceURL + "UpdateBlog",
-		serviceURL + "DeleteBlog",
-		serviceURL + "ListBlog",
+func (c *blogServiceProtobufClient) UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "UnpublishBlog")
+	caller := c.callUnpublishBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnpublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnpublishBlogRequest) when calling interceptor")
+					}
+					return c.callUnpublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
 	}
+	return caller(ctx, in)
+}
 
-	return &blogServiceJSONClient{
-		client:      client,
-		urls:        urls,
-		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
-		opts:        clientOpts,
+func (c *blogServiceProtobufClient) callUnpublishBlog(ctx context.Context, in *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
 	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
 }
 
-func (c *blogServiceJSONClient) CreateBlog(ctx context.Context, in *CreateBlogRequest) (*CreateBlogResponse, error) {
+func (c *blogServiceProtobufClient) ListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithMethodName(ctx, "CreateBlog")
-	caller := c.callCreateBlog
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
+	caller := c.callListBlog
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+		caller = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*CreateBlogRequest)
+					typedReq, ok := req.(*ListBlogRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*CreateBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
 					}
-					return c.callCreateBlog(ctx, typedReq)
+					return c.callListBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*CreateBlogResponse)
+				typedResp, ok := resp.(*ListBlogResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*CreateBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -403,9 +536,9 @@ func (c *blogServiceJSONClient) CreateBlog(ctx context.Context, in *CreateBlogRe
 	return caller(ctx, in)
 }
 
-func (c *blogServiceJSONClient) callCreateBlog(ctx context.Context, in *CreateBlogRequest) (*CreateBlogResponse, error) {
-	out := new(CreateBlogResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+func (c *blogServiceProtobufClient) callListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
+	out := new(ListBlogResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -420,26 +553,26 @@ func (c *blogServiceJSONClient) callCreateBlog(ctx context.Context, in *CreateBl
 	return out, nil
 }
 
-func (c *blogServiceJSONClient) GetBlog(ctx context.Context, in *GetBlogRequest) (*GetBlogResponse, error) {
+func (c *blogServiceProtobufClient) ListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithMethodName(ctx, "GetBlog")
-	caller := c.callGetBlog
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeletedBlogs")
+	caller := c.callListDeletedBlogs
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *GetBlogRequest) (*GetBlogResponse, error) {
+		caller = func(ctx context.Context, req *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*GetBlogRequest)
+					typedReq, ok := req.(*ListDeletedBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeletedBlogsRequest) when calling interceptor")
 					}
-					return c.callGetBlog(ctx, typedReq)
+					return c.callListDeletedBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*GetBlogResponse)
+				typedResp, ok := resp.(*ListDeletedBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeletedBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -449,9 +582,9 @@ func (c *blogServiceJSONClient) GetBlog(ctx context.Context, in *GetBlogRequest)
 	return caller(ctx, in)
 }
 
-func (c *blogServiceJSONClient) callGetBlog(ctx context.Context, in *GetBlogRequest) (*GetBlogResponse, error) {
-	out := new(GetBlogResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+func (c *blogServiceProtobufClient) callListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+	out := new(ListDeletedBlogsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -466,26 +599,26 @@ func (c *blogServiceJSONClient) callGetBlog(ctx context.Context, in *GetBlogRequ
 	return out, nil
 }
 
-func (c *blogServiceJSONClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+func (c *blogServiceProtobufClient) SearchBlogs(ctx context.Context, in *SearchBlogsRequest) (*SearchBlogsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithMethodName(ctx, "UpdateBlog")
-	caller := c.callUpdateBlog
+	ctx = ctxsetters.WithMethodName(ctx, "SearchBlogs")
+	caller := c.callSearchBlogs
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+		caller = func(ctx context.Context, req *SearchBlogsRequest) (*SearchBlogsResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*UpdateBlogRequest)
+					typedReq, ok := req.(*SearchBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*UpdateBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*SearchBlogsRequest) when calling interceptor")
 					}
-					return c.callUpdateBlog(ctx, typedReq)
+					return c.callSearchBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*UpdateBlogResponse)
+				typedResp, ok := resp.(*SearchBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -495,9 +628,9 @@ func (c *blogServiceJSONClient) UpdateBlog(ctx context.Context, in *UpdateBlogRe
 	return caller(ctx, in)
 }
 
-func (c *blogServiceJSONClient) callUpdateBlog(ctx context.Context, in *UpdateBlogRequest) (*UpdateBlogResponse, error) {
-	out := new(UpdateBlogResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+func (c *blogServiceProtobufClient) callSearchBlogs(ctx context.Context, in *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+	out := new(SearchBlogsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -512,26 +645,26 @@ func (c *blogServiceJSONClient) callUpdateBlog(ctx context.Context, in *UpdateBl
 	return out, nil
 }
 
-func (c *blogServiceJSONClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+func (c *blogServiceProtobufClient) ListTags(ctx context.Context, in *ListTagsRequest) (*ListTagsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithMethodName(ctx, "DeleteBlog")
-	caller := c.callDeleteBlog
+	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	caller := c.callListTags
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+		caller = func(ctx context.Context, req *ListTagsRequest) (*ListTagsResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*DeleteBlogRequest)
+					typedReq, ok := req.(*ListTagsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*DeleteBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListTagsRequest) when calling interceptor")
 					}
-					return c.callDeleteBlog(ctx, typedReq)
+					return c.callListTags(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*DeleteBlogResponse)
+				typedResp, ok := resp.(*ListTagsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*DeleteBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTagsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -541,9 +674,9 @@ func (c *blogServiceJSONClient) DeleteBlog(ctx context.Context, in *DeleteBlogRe
 	return caller(ctx, in)
 }
 
-func (c *blogServiceJSONClient) callDeleteBlog(ctx context.Context, in *DeleteBlogRequest) (*DeleteBlogResponse, error) {
-	out := new(DeleteBlogResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+func (c *blogServiceProtobufClient) callListTags(ctx context.Context, in *ListTagsRequest) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -558,26 +691,26 @@ func (c *blogServiceJSONClient) callDeleteBlog(ctx context.Context, in *DeleteBl
 	return out, nil
 }
 
-func (c *blogServiceJSONClient) ListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
+func (c *blogServiceProtobufClient) ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
 	ctx = ctxsetters.WithPackageName(ctx, "service")
 	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
-	caller := c.callListBlog
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlogRevisions")
+	caller := c.callListBlogRevisions
 	if c.interceptor != nil {
-		caller = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+		caller = func(ctx context.Context, req *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
 			resp, err := c.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListBlogRequest)
+					typedReq, ok := req.(*ListBlogRevisionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRevisionsRequest) when calling interceptor")
 					}
-					return c.callListBlog(ctx, typedReq)
+					return c.callListBlogRevisions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListBlogResponse)
+				typedResp, ok := resp.(*ListBlogRevisionsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogRevisionsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -587,9 +720,9 @@ func (c *blogServiceJSONClient) ListBlog(ctx context.Context, in *ListBlogReques
 	return caller(ctx, in)
 }
 
-func (c *blogServiceJSONClient) callListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
-	out := new(ListBlogResponse)
-	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+func (c *blogServiceProtobufClient) callListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+	out := new(ListBlogRevisionsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
 	if err != nil {
 		twerr, ok := err.(twirp.Error)
 		if !ok {
@@ -604,126 +737,3902 @@ func (c *blogServiceJSONClient) callListBlog(ctx context.Context, in *ListBlogRe
 	return out, nil
 }
 
-// ==========================
-// BlogService Server Handler
-// ==========================
-
-type blogServiceServer struct {
-	BlogService
-	interceptor      twirp.Interceptor
-	hooks            *twirp.ServerHooks
-	pathPrefix       string // prefix for routing
-	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
-	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
-}
-
-// NewBlogServiceServer builds a TwirpServer that can be used as an http.Handler to handle
-// HTTP requests that are routed to the right method in the provided svc implementation.
-// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
+func (c *blogServiceProtobufClient) GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest) (*BlogRevision, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogRevision")
+	caller := c.callGetBlogRevision
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBlogRevisionRequest) (*BlogRevision, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRevisionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRevisionRequest) when calling interceptor")
+					}
+					return c.callGetBlogRevision(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BlogRevision)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BlogRevision) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callGetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest) (*BlogRevision, error) {
+	out := new(BlogRevision)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[13], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceProtobufClient) DiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "DiffBlogRevisions")
+	caller := c.callDiffBlogRevisions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DiffBlogRevisionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DiffBlogRevisionsRequest) when calling interceptor")
+					}
+					return c.callDiffBlogRevisions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DiffBlogRevisionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DiffBlogRevisionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callDiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+	out := new(DiffBlogRevisionsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceProtobufClient) RevertBlog(ctx context.Context, in *RevertBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "RevertBlog")
+	caller := c.callRevertBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RevertBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RevertBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RevertBlogRequest) when calling interceptor")
+					}
+					return c.callRevertBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callRevertBlog(ctx context.Context, in *RevertBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceProtobufClient) BatchCreateBlogs(ctx context.Context, in *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchCreateBlogs")
+	caller := c.callBatchCreateBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchCreateBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchCreateBlogsRequest) when calling interceptor")
+					}
+					return c.callBatchCreateBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchCreateBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchCreateBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callBatchCreateBlogs(ctx context.Context, in *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+	out := new(BatchCreateBlogsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceProtobufClient) BatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchGetBlogs")
+	caller := c.callBatchGetBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchGetBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchGetBlogsRequest) when calling interceptor")
+					}
+					return c.callBatchGetBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchGetBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchGetBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callBatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+	out := new(BatchGetBlogsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceProtobufClient) BatchDeleteBlogs(ctx context.Context, in *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchDeleteBlogs")
+	caller := c.callBatchDeleteBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchDeleteBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchDeleteBlogsRequest) when calling interceptor")
+					}
+					return c.callBatchDeleteBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchDeleteBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchDeleteBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceProtobufClient) callBatchDeleteBlogs(ctx context.Context, in *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+	out := new(BatchDeleteBlogsResponse)
+	ctx, err := doProtobufRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// =======================
+// BlogService JSON Client
+// =======================
+
+type blogServiceJSONClient struct {
+	client      HTTPClient
+	urls        [19]string
+	interceptor twirp.Interceptor
+	opts        twirp.ClientOptions
+}
+
+// NewBlogServiceJSONClient creates a JSON client that implements the BlogService interface.
+// It communicates using JSON and can be configured with a custom HTTPClient.
+func NewBlogServiceJSONClient(baseURL string, client HTTPClient, opts ...twirp.ClientOption) BlogService {
+	if c, ok := client.(*http.Client); ok {
+		client = withoutRedirects(c)
+	}
+
+	clientOpts := twirp.ClientOptions{}
+	for _, o := range opts {
+		o(&clientOpts)
+	}
+
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	literalURLs := false
+	_ = clientOpts.ReadOpt("literalURLs", &literalURLs)
+	var pathPrefix string
+	if ok := clientOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	// Build method URLs: <baseURL>[<prefix>]/<package>.<Service>/<Method>
+	serviceURL := sanitizeBaseURL(baseURL)
+	serviceURL += baseServicePath(pathPrefix, "service", "BlogService")
+	urls := [19]string{
+		serviceURL + "CreateBlog",
+		serviceURL + "GetBlog",
+		serviceURL + "GetBlogBySlug",
+		serviceURL + "UpdateBlog",
+		serviceURL + "DeleteBlog",
+		serviceURL + "UndeleteBlog",
+		serviceURL + "PublishBlog",
+		serviceURL + "UnpublishBlog",
+		serviceURL + "ListBlog",
+		serviceURL + "ListDeletedBlogs",
+		serviceURL + "SearchBlogs",
+		serviceURL + "ListTags",
+		serviceURL + "ListBlogRevisions",
+		serviceURL + "GetBlogRevision",
+		serviceURL + "DiffBlogRevisions",
+		serviceURL + "RevertBlog",
+		serviceURL + "BatchCreateBlogs",
+		serviceURL + "BatchGetBlogs",
+		serviceURL + "BatchDeleteBlogs",
+	}
+
+	return &blogServiceJSONClient{
+		client:      client,
+		urls:        urls,
+		interceptor: twirp.ChainInterceptors(clientOpts.Interceptors...),
+		opts:        clientOpts,
+	}
+}
+
+func (c *blogServiceJSONClient) CreateBlog(ctx context.Context, in *CreateBlogRequest) (*CreateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "CreateBlog")
+	caller := c.callCreateBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CreateBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CreateBlogRequest) when calling interceptor")
+					}
+					return c.callCreateBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CreateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CreateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callCreateBlog(ctx context.Context, in *CreateBlogRequest) (*CreateBlogResponse, error) {
+	out := new(CreateBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[0], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) GetBlog(ctx context.Context, in *GetBlogRequest) (*GetBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlog")
+	caller := c.callGetBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBlogRequest) (*GetBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRequest) when calling interceptor")
+					}
+					return c.callGetBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callGetBlog(ctx context.Context, in *GetBlogRequest) (*GetBlogResponse, error) {
+	out := new(GetBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[1], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) GetBlogBySlug(ctx context.Context, in *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogBySlug")
+	caller := c.callGetBlogBySlug
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogBySlugRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogBySlugRequest) when calling interceptor")
+					}
+					return c.callGetBlogBySlug(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callGetBlogBySlug(ctx context.Context, in *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+	out := new(GetBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[2], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateBlog")
+	caller := c.callUpdateBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpdateBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateBlogRequest) when calling interceptor")
+					}
+					return c.callUpdateBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callUpdateBlog(ctx context.Context, in *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[3], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) DeleteBlog(ctx context.Context, in *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteBlog")
+	caller := c.callDeleteBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteBlogRequest) when calling interceptor")
+					}
+					return c.callDeleteBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callDeleteBlog(ctx context.Context, in *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+	out := new(DeleteBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[4], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) UndeleteBlog(ctx context.Context, in *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "UndeleteBlog")
+	caller := c.callUndeleteBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UndeleteBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UndeleteBlogRequest) when calling interceptor")
+					}
+					return c.callUndeleteBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UndeleteBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UndeleteBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callUndeleteBlog(ctx context.Context, in *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+	out := new(UndeleteBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[5], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) PublishBlog(ctx context.Context, in *PublishBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "PublishBlog")
+	caller := c.callPublishBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *PublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PublishBlogRequest) when calling interceptor")
+					}
+					return c.callPublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callPublishBlog(ctx context.Context, in *PublishBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[6], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "UnpublishBlog")
+	caller := c.callUnpublishBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnpublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnpublishBlogRequest) when calling interceptor")
+					}
+					return c.callUnpublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callUnpublishBlog(ctx context.Context, in *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[7], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) ListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
+	caller := c.callListBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+					}
+					return c.callListBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callListBlog(ctx context.Context, in *ListBlogRequest) (*ListBlogResponse, error) {
+	out := new(ListBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[8], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) ListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeletedBlogs")
+	caller := c.callListDeletedBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeletedBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeletedBlogsRequest) when calling interceptor")
+					}
+					return c.callListDeletedBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeletedBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeletedBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+	out := new(ListDeletedBlogsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[9], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) SearchBlogs(ctx context.Context, in *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "SearchBlogs")
+	caller := c.callSearchBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SearchBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SearchBlogsRequest) when calling interceptor")
+					}
+					return c.callSearchBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SearchBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callSearchBlogs(ctx context.Context, in *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+	out := new(SearchBlogsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[10], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) ListTags(ctx context.Context, in *ListTagsRequest) (*ListTagsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	caller := c.callListTags
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListTagsRequest) (*ListTagsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTagsRequest) when calling interceptor")
+					}
+					return c.callListTags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callListTags(ctx context.Context, in *ListTagsRequest) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[11], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlogRevisions")
+	caller := c.callListBlogRevisions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListBlogRevisionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRevisionsRequest) when calling interceptor")
+					}
+					return c.callListBlogRevisions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListBlogRevisionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogRevisionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+	out := new(ListBlogRevisionsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[12], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest) (*BlogRevision, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogRevision")
+	caller := c.callGetBlogRevision
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *GetBlogRevisionRequest) (*BlogRevision, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRevisionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRevisionRequest) when calling interceptor")
+					}
+					return c.callGetBlogRevision(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BlogRevision)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BlogRevision) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callGetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest) (*BlogRevision, error) {
+	out := new(BlogRevision)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[13], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) DiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "DiffBlogRevisions")
+	caller := c.callDiffBlogRevisions
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DiffBlogRevisionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DiffBlogRevisionsRequest) when calling interceptor")
+					}
+					return c.callDiffBlogRevisions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DiffBlogRevisionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DiffBlogRevisionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callDiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+	out := new(DiffBlogRevisionsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[14], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) RevertBlog(ctx context.Context, in *RevertBlogRequest) (*UpdateBlogResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "RevertBlog")
+	caller := c.callRevertBlog
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *RevertBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*RevertBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*RevertBlogRequest) when calling interceptor")
+					}
+					return c.callRevertBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callRevertBlog(ctx context.Context, in *RevertBlogRequest) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[15], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) BatchCreateBlogs(ctx context.Context, in *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchCreateBlogs")
+	caller := c.callBatchCreateBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchCreateBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchCreateBlogsRequest) when calling interceptor")
+					}
+					return c.callBatchCreateBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchCreateBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchCreateBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callBatchCreateBlogs(ctx context.Context, in *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+	out := new(BatchCreateBlogsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[16], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) BatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchGetBlogs")
+	caller := c.callBatchGetBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchGetBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchGetBlogsRequest) when calling interceptor")
+					}
+					return c.callBatchGetBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchGetBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchGetBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callBatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+	out := new(BatchGetBlogsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[17], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+func (c *blogServiceJSONClient) BatchDeleteBlogs(ctx context.Context, in *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchDeleteBlogs")
+	caller := c.callBatchDeleteBlogs
+	if c.interceptor != nil {
+		caller = func(ctx context.Context, req *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+			resp, err := c.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*BatchDeleteBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*BatchDeleteBlogsRequest) when calling interceptor")
+					}
+					return c.callBatchDeleteBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BatchDeleteBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchDeleteBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+	return caller(ctx, in)
+}
+
+func (c *blogServiceJSONClient) callBatchDeleteBlogs(ctx context.Context, in *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+	out := new(BatchDeleteBlogsResponse)
+	ctx, err := doJSONRequest(ctx, c.client, c.opts.Hooks, c.urls[18], in, out)
+	if err != nil {
+		twerr, ok := err.(twirp.Error)
+		if !ok {
+			twerr = twirp.InternalErrorWith(err)
+		}
+		callClientError(ctx, c.opts.Hooks, twerr)
+		return nil, err
+	}
+
+	callClientResponseReceived(ctx, c.opts.Hooks)
+
+	return out, nil
+}
+
+// ==========================
+// BlogService Server Handler
+// ==========================
+
+type blogServiceServer struct {
+	BlogService
+	interceptor      twirp.Interceptor
+	hooks            *twirp.ServerHooks
+	pathPrefix       string // prefix for routing
+	jsonSkipDefaults bool   // do not include unpopulated fields (default values) in the response
+	jsonCamelCase    bool   // JSON fields are serialized as lowerCamelCase rather than keeping the original proto names
+}
+
+// NewBlogServiceServer builds a TwirpServer that can be used as an http.Handler to handle
+// HTTP requests that are routed to the right method in the provided svc implementation.
+// The opts are twirp.ServerOption modifiers, for example twirp.WithServerHooks(hooks).
 func NewBlogServiceServer(svc BlogService, opts ...interface{}) TwirpServer {
 	serverOpts := newServerOpts(opts)
 
-	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
-	jsonSkipDefaults := false
-	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
-	jsonCamelCase := false
-	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
-	var pathPrefix string
-	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
-		pathPrefix = "/twirp" // default prefix
+	// Using ReadOpt allows backwards and forwads compatibility with new options in the future
+	jsonSkipDefaults := false
+	_ = serverOpts.ReadOpt("jsonSkipDefaults", &jsonSkipDefaults)
+	jsonCamelCase := false
+	_ = serverOpts.ReadOpt("jsonCamelCase", &jsonCamelCase)
+	var pathPrefix string
+	if ok := serverOpts.ReadOpt("pathPrefix", &pathPrefix); !ok {
+		pathPrefix = "/twirp" // default prefix
+	}
+
+	return &blogServiceServer{
+		BlogService:      svc,
+		hooks:            serverOpts.Hooks,
+		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
+		pathPrefix:       pathPrefix,
+		jsonSkipDefaults: jsonSkipDefaults,
+		jsonCamelCase:    jsonCamelCase,
+	}
+}
+
+// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
+// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
+func (s *blogServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
+	writeError(ctx, resp, err, s.hooks)
+}
+
+// handleRequestBodyError is used to handle error when the twirp server cannot read request
+func (s *blogServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
+	if context.Canceled == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+		return
+	}
+	if context.DeadlineExceeded == ctx.Err() {
+		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+		return
+	}
+	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
+}
+
+// BlogServicePathPrefix is a convenience constant that may identify URL paths.
+// Should be used with caution, it only matches routes generated by Twirp Go clients,
+// with the default "/twirp" prefix and default CamelCase service and method names.
+// More info: https://twitchtv.github.io/twirp/docs/routing.html
+const BlogServicePathPrefix = "/twirp/service.BlogService/"
+
+func (s *blogServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	ctx = ctxsetters.WithPackageName(ctx, "service")
+	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
+	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+
+	var err error
+	ctx, err = callRequestReceived(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	if req.Method != "POST" {
+		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
+	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
+	if pkgService != "service.BlogService" {
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+	if prefix != s.pathPrefix {
+		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+
+	switch method {
+	case "CreateBlog":
+		s.serveCreateBlog(ctx, resp, req)
+		return
+	case "GetBlog":
+		s.serveGetBlog(ctx, resp, req)
+		return
+	case "GetBlogBySlug":
+		s.serveGetBlogBySlug(ctx, resp, req)
+		return
+	case "UpdateBlog":
+		s.serveUpdateBlog(ctx, resp, req)
+		return
+	case "DeleteBlog":
+		s.serveDeleteBlog(ctx, resp, req)
+		return
+	case "UndeleteBlog":
+		s.serveUndeleteBlog(ctx, resp, req)
+		return
+	case "PublishBlog":
+		s.servePublishBlog(ctx, resp, req)
+		return
+	case "UnpublishBlog":
+		s.serveUnpublishBlog(ctx, resp, req)
+		return
+	case "ListBlog":
+		s.serveListBlog(ctx, resp, req)
+		return
+	case "ListDeletedBlogs":
+		s.serveListDeletedBlogs(ctx, resp, req)
+		return
+	case "SearchBlogs":
+		s.serveSearchBlogs(ctx, resp, req)
+		return
+	case "ListTags":
+		s.serveListTags(ctx, resp, req)
+		return
+	case "ListBlogRevisions":
+		s.serveListBlogRevisions(ctx, resp, req)
+		return
+	case "GetBlogRevision":
+		s.serveGetBlogRevision(ctx, resp, req)
+		return
+	case "DiffBlogRevisions":
+		s.serveDiffBlogRevisions(ctx, resp, req)
+		return
+	case "RevertBlog":
+		s.serveRevertBlog(ctx, resp, req)
+		return
+	case "BatchCreateBlogs":
+		s.serveBatchCreateBlogs(ctx, resp, req)
+		return
+	case "BatchGetBlogs":
+		s.serveBatchGetBlogs(ctx, resp, req)
+		return
+	case "BatchDeleteBlogs":
+		s.serveBatchDeleteBlogs(ctx, resp, req)
+		return
+	default:
+		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
+		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+		return
+	}
+}
+
+func (s *blogServiceServer) serveCreateBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCreateBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCreateBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveCreateBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CreateBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(CreateBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.CreateBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CreateBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CreateBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.CreateBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CreateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CreateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CreateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CreateBlogResponse and nil error while calling CreateBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveCreateBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CreateBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(CreateBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.CreateBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*CreateBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*CreateBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.CreateBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*CreateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*CreateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *CreateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *CreateBlogResponse and nil error while calling CreateBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveGetBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveGetBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.GetBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBlogRequest) (*GetBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.GetBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBlogResponse and nil error while calling GetBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveGetBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.GetBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBlogRequest) (*GetBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.GetBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBlogResponse and nil error while calling GetBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveGetBlogBySlug(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetBlogBySlugJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetBlogBySlugProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveGetBlogBySlugJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogBySlug")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetBlogBySlugRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.GetBlogBySlug
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogBySlugRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogBySlugRequest) when calling interceptor")
+					}
+					return s.BlogService.GetBlogBySlug(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBlogResponse and nil error while calling GetBlogBySlug. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveGetBlogBySlugProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogBySlug")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(GetBlogBySlugRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.GetBlogBySlug
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogBySlugRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogBySlugRequest) when calling interceptor")
+					}
+					return s.BlogService.GetBlogBySlug(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*GetBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *GetBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBlogResponse and nil error while calling GetBlogBySlug. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveUpdateBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUpdateBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUpdateBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveUpdateBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UpdateBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.UpdateBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpdateBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.UpdateBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpdateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling UpdateBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveUpdateBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UpdateBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UpdateBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.UpdateBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UpdateBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UpdateBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.UpdateBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpdateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling UpdateBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveDeleteBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveDeleteBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveDeleteBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveDeleteBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(DeleteBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.DeleteBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.DeleteBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteBlogResponse and nil error while calling DeleteBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveDeleteBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "DeleteBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(DeleteBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.DeleteBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*DeleteBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*DeleteBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.DeleteBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*DeleteBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*DeleteBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *DeleteBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteBlogResponse and nil error while calling DeleteBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveUndeleteBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUndeleteBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUndeleteBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveUndeleteBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UndeleteBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UndeleteBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.UndeleteBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UndeleteBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UndeleteBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.UndeleteBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UndeleteBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UndeleteBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UndeleteBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UndeleteBlogResponse and nil error while calling UndeleteBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveUndeleteBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UndeleteBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UndeleteBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.UndeleteBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UndeleteBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UndeleteBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.UndeleteBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UndeleteBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UndeleteBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UndeleteBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UndeleteBlogResponse and nil error while calling UndeleteBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) servePublishBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.servePublishBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.servePublishBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) servePublishBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PublishBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(PublishBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.PublishBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PublishBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.PublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpdateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling PublishBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) servePublishBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "PublishBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(PublishBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.PublishBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *PublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*PublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*PublishBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.PublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpdateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling PublishBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveUnpublishBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveUnpublishBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveUnpublishBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveUnpublishBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UnpublishBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(UnpublishBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.UnpublishBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnpublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnpublishBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.UnpublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpdateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling UnpublishBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveUnpublishBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "UnpublishBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(UnpublishBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.UnpublishBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*UnpublishBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*UnpublishBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.UnpublishBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*UpdateBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *UpdateBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling UnpublishBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListBlogJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListBlogProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveListBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListBlogRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.ListBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.ListBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListBlogResponse and nil error while calling ListBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListBlogRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.ListBlog
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListBlogRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+					}
+					return s.BlogService.ListBlog(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListBlogResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListBlogResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListBlogResponse and nil error while calling ListBlog. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListDeletedBlogs(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListDeletedBlogsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListDeletedBlogsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveListDeletedBlogsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeletedBlogs")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListDeletedBlogsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.ListDeletedBlogs
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeletedBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeletedBlogsRequest) when calling interceptor")
+					}
+					return s.BlogService.ListDeletedBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeletedBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeletedBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListDeletedBlogsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListDeletedBlogsResponse and nil error while calling ListDeletedBlogs. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListDeletedBlogsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListDeletedBlogs")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListDeletedBlogsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.ListDeletedBlogs
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListDeletedBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListDeletedBlogsRequest) when calling interceptor")
+					}
+					return s.BlogService.ListDeletedBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListDeletedBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListDeletedBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListDeletedBlogsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListDeletedBlogsResponse and nil error while calling ListDeletedBlogs. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveSearchBlogs(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveSearchBlogsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveSearchBlogsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveSearchBlogsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SearchBlogs")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(SearchBlogsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.SearchBlogs
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SearchBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SearchBlogsRequest) when calling interceptor")
+					}
+					return s.BlogService.SearchBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SearchBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SearchBlogsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SearchBlogsResponse and nil error while calling SearchBlogs. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveSearchBlogsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "SearchBlogs")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(SearchBlogsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.SearchBlogs
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*SearchBlogsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*SearchBlogsRequest) when calling interceptor")
+					}
+					return s.BlogService.SearchBlogs(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*SearchBlogsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*SearchBlogsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *SearchBlogsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *SearchBlogsResponse and nil error while calling SearchBlogs. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListTags(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListTagsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListTagsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveListTagsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListTagsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.ListTags
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListTagsRequest) (*ListTagsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTagsRequest) when calling interceptor")
+					}
+					return s.BlogService.ListTags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListTagsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagsResponse and nil error while calling ListTags. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListTagsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListTags")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListTagsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.ListTags
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListTagsRequest) (*ListTagsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListTagsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListTagsRequest) when calling interceptor")
+					}
+					return s.BlogService.ListTags(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListTagsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListTagsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListTagsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListTagsResponse and nil error while calling ListTags. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListBlogRevisions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveListBlogRevisionsJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveListBlogRevisionsProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *blogServiceServer) serveListBlogRevisionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlogRevisions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(ListBlogRevisionsRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+
+	handler := s.BlogService.ListBlogRevisions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListBlogRevisionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRevisionsRequest) when calling interceptor")
+					}
+					return s.BlogService.ListBlogRevisions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListBlogRevisionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogRevisionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListBlogRevisionsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListBlogRevisionsResponse and nil error while calling ListBlogRevisions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *blogServiceServer) serveListBlogRevisionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "ListBlogRevisions")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
+		return
+	}
+	reqContent := new(ListBlogRevisionsRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
+		return
+	}
+
+	handler := s.BlogService.ListBlogRevisions
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*ListBlogRevisionsRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRevisionsRequest) when calling interceptor")
+					}
+					return s.BlogService.ListBlogRevisions(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*ListBlogRevisionsResponse)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogRevisionsResponse) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *ListBlogRevisionsResponse
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListBlogRevisionsResponse and nil error while calling ListBlogRevisions. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
+		return
 	}
 
-	return &blogServiceServer{
-		BlogService:      svc,
-		hooks:            serverOpts.Hooks,
-		interceptor:      twirp.ChainInterceptors(serverOpts.Interceptors...),
-		pathPrefix:       pathPrefix,
-		jsonSkipDefaults: jsonSkipDefaults,
-		jsonCamelCase:    jsonCamelCase,
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
 	}
+	callResponseSent(ctx, s.hooks)
 }
 
-// writeError writes an HTTP response with a valid Twirp error format, and triggers hooks.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func (s *blogServiceServer) writeError(ctx context.Context, resp http.ResponseWriter, err error) {
-	writeError(ctx, resp, err, s.hooks)
+func (s *blogServiceServer) serveGetBlogRevision(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveGetBlogRevisionJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveGetBlogRevisionProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
 }
 
-// handleRequestBodyError is used to handle error when the twirp server cannot read request
-func (s *blogServiceServer) handleRequestBodyError(ctx context.Context, resp http.ResponseWriter, msg string, err error) {
-	if context.Canceled == ctx.Err() {
-		s.writeError(ctx, resp, twirp.NewError(twirp.Canceled, "failed to read request: context canceled"))
+func (s *blogServiceServer) serveGetBlogRevisionJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogRevision")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
 		return
 	}
-	if context.DeadlineExceeded == ctx.Err() {
-		s.writeError(ctx, resp, twirp.NewError(twirp.DeadlineExceeded, "failed to read request: deadline exceeded"))
+
+	d := json.NewDecoder(req.Body)
+	rawReqBody := json.RawMessage{}
+	if err := d.Decode(&rawReqBody); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
+		return
+	}
+	reqContent := new(GetBlogRevisionRequest)
+	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
+		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	s.writeError(ctx, resp, twirp.WrapError(malformedRequestError(msg), err))
-}
 
-// BlogServicePathPrefix is a convenience constant that may identify URL paths.
-// Should be used with caution, it only matches routes generated by Twirp Go clients,
-// with the default "/twirp" prefix and default CamelCase service and method names.
-// More info: https://twitchtv.github.io/twirp/docs/routing.html
-const BlogServicePathPrefix = "/twirp/service.BlogService/"
+	handler := s.BlogService.GetBlogRevision
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBlogRevisionRequest) (*BlogRevision, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRevisionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRevisionRequest) when calling interceptor")
+					}
+					return s.BlogService.GetBlogRevision(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BlogRevision)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BlogRevision) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
 
-func (s *blogServiceServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
-	ctx := req.Context()
-	ctx = ctxsetters.WithPackageName(ctx, "service")
-	ctx = ctxsetters.WithServiceName(ctx, "BlogService")
-	ctx = ctxsetters.WithResponseWriter(ctx, resp)
+	// Call service method
+	var respContent *BlogRevision
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
 
-	var err error
-	ctx, err = callRequestReceived(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
 		return
 	}
-
-	if req.Method != "POST" {
-		msg := fmt.Sprintf("unsupported method %q (only POST is allowed)", req.Method)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlogRevision and nil error while calling GetBlogRevision. nil responses are not supported"))
 		return
 	}
 
-	// Verify path format: [<prefix>]/<package>.<Service>/<Method>
-	prefix, pkgService, method := parseTwirpPath(req.URL.Path)
-	if pkgService != "service.BlogService" {
-		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	marshaler := &protojson.MarshalOptions{UseProtoNames: !s.jsonCamelCase, EmitUnpopulated: !s.jsonSkipDefaults}
+	respBytes, err := marshaler.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal json response"))
 		return
 	}
-	if prefix != s.pathPrefix {
-		msg := fmt.Sprintf("invalid path prefix %q, expected %q, on path %q", prefix, s.pathPrefix, req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
-		return
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
 	}
+	callResponseSent(ctx, s.hooks)
+}
 
-	switch method {
-	case "CreateBlog":
-		s.serveCreateBlog(ctx, resp, req)
+func (s *blogServiceServer) serveGetBlogRevisionProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "GetBlogRevision")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
 		return
-	case "GetBlog":
-		s.serveGetBlog(ctx, resp, req)
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
-	case "UpdateBlog":
-		s.serveUpdateBlog(ctx, resp, req)
+	}
+	reqContent := new(GetBlogRevisionRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
-	case "DeleteBlog":
-		s.serveDeleteBlog(ctx, resp, req)
+	}
+
+	handler := s.BlogService.GetBlogRevision
+	if s.interceptor != nil {
+		handler = func(ctx context.Context, req *GetBlogRevisionRequest) (*BlogRevision, error) {
+			resp, err := s.interceptor(
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					typedReq, ok := req.(*GetBlogRevisionRequest)
+					if !ok {
+						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRevisionRequest) when calling interceptor")
+					}
+					return s.BlogService.GetBlogRevision(ctx, typedReq)
+				},
+			)(ctx, req)
+			if resp != nil {
+				typedResp, ok := resp.(*BlogRevision)
+				if !ok {
+					return nil, twirp.InternalError("failed type assertion resp.(*BlogRevision) when calling interceptor")
+				}
+				return typedResp, err
+			}
+			return nil, err
+		}
+	}
+
+	// Call service method
+	var respContent *BlogRevision
+	func() {
+		defer ensurePanicResponses(ctx, resp, s.hooks)
+		respContent, err = handler(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
 		return
-	case "ListBlog":
-		s.serveListBlog(ctx, resp, req)
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BlogRevision and nil error while calling GetBlogRevision. nil responses are not supported"))
 		return
-	default:
-		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
-		s.writeError(ctx, resp, badRouteError(msg, req.Method, req.URL.Path))
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		s.writeError(ctx, resp, wrapInternal(err, "failed to marshal proto response"))
 		return
 	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.Header().Set("Content-Length", strconv.Itoa(len(respBytes)))
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		ctx = callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveCreateBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveDiffBlogRevisions(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -731,9 +4640,9 @@ func (s *blogServiceServer) serveCreateBlog(ctx context.Context, resp http.Respo
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveCreateBlogJSON(ctx, resp, req)
+		s.serveDiffBlogRevisionsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveCreateBlogProtobuf(ctx, resp, req)
+		s.serveDiffBlogRevisionsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -741,9 +4650,9 @@ func (s *blogServiceServer) serveCreateBlog(ctx context.Context, resp http.Respo
 	}
 }
 
-func (s *blogServiceServer) serveCreateBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveDiffBlogRevisionsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "CreateBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "DiffBlogRevisions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -756,29 +4665,29 @@ func (s *blogServiceServer) serveCreateBlogJSON(ctx context.Context, resp http.R
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(CreateBlogRequest)
+	reqContent := new(DiffBlogRevisionsRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.BlogService.CreateBlog
+	handler := s.BlogService.DiffBlogRevisions
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+		handler = func(ctx context.Context, req *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*CreateBlogRequest)
+					typedReq, ok := req.(*DiffBlogRevisionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*CreateBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*DiffBlogRevisionsRequest) when calling interceptor")
 					}
-					return s.BlogService.CreateBlog(ctx, typedReq)
+					return s.BlogService.DiffBlogRevisions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*CreateBlogResponse)
+				typedResp, ok := resp.(*DiffBlogRevisionsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*CreateBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*DiffBlogRevisionsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -787,7 +4696,7 @@ func (s *blogServiceServer) serveCreateBlogJSON(ctx context.Context, resp http.R
 	}
 
 	// Call service method
-	var respContent *CreateBlogResponse
+	var respContent *DiffBlogRevisionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -798,7 +4707,7 @@ func (s *blogServiceServer) serveCreateBlogJSON(ctx context.Context, resp http.R
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *CreateBlogResponse and nil error while calling CreateBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DiffBlogRevisionsResponse and nil error while calling DiffBlogRevisions. nil responses are not supported"))
 		return
 	}
 
@@ -824,9 +4733,9 @@ func (s *blogServiceServer) serveCreateBlogJSON(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveCreateBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveDiffBlogRevisionsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "CreateBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "DiffBlogRevisions")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -838,28 +4747,28 @@ func (s *blogServiceServer) serveCreateBlogProtobuf(ctx context.Context, resp ht
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(CreateBlogRequest)
+	reqContent := new(DiffBlogRevisionsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.BlogService.CreateBlog
+	handler := s.BlogService.DiffBlogRevisions
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *CreateBlogRequest) (*CreateBlogResponse, error) {
+		handler = func(ctx context.Context, req *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*CreateBlogRequest)
+					typedReq, ok := req.(*DiffBlogRevisionsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*CreateBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*DiffBlogRevisionsRequest) when calling interceptor")
 					}
-					return s.BlogService.CreateBlog(ctx, typedReq)
+					return s.BlogService.DiffBlogRevisions(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*CreateBlogResponse)
+				typedResp, ok := resp.(*DiffBlogRevisionsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*CreateBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*DiffBlogRevisionsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -868,7 +4777,7 @@ func (s *blogServiceServer) serveCreateBlogProtobuf(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *CreateBlogResponse
+	var respContent *DiffBlogRevisionsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -879,7 +4788,7 @@ func (s *blogServiceServer) serveCreateBlogProtobuf(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *CreateBlogResponse and nil error while calling CreateBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *DiffBlogRevisionsResponse and nil error while calling DiffBlogRevisions. nil responses are not supported"))
 		return
 	}
 
@@ -903,7 +4812,7 @@ func (s *blogServiceServer) serveCreateBlogProtobuf(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveGetBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveRevertBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -911,9 +4820,9 @@ func (s *blogServiceServer) serveGetBlog(ctx context.Context, resp http.Response
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveGetBlogJSON(ctx, resp, req)
+		s.serveRevertBlogJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveGetBlogProtobuf(ctx, resp, req)
+		s.serveRevertBlogProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -921,9 +4830,9 @@ func (s *blogServiceServer) serveGetBlog(ctx context.Context, resp http.Response
 	}
 }
 
-func (s *blogServiceServer) serveGetBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveRevertBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "GetBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "RevertBlog")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -936,29 +4845,29 @@ func (s *blogServiceServer) serveGetBlogJSON(ctx context.Context, resp http.Resp
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(GetBlogRequest)
+	reqContent := new(RevertBlogRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.BlogService.GetBlog
+	handler := s.BlogService.RevertBlog
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *GetBlogRequest) (*GetBlogResponse, error) {
+		handler = func(ctx context.Context, req *RevertBlogRequest) (*UpdateBlogResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*GetBlogRequest)
+					typedReq, ok := req.(*RevertBlogRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RevertBlogRequest) when calling interceptor")
 					}
-					return s.BlogService.GetBlog(ctx, typedReq)
+					return s.BlogService.RevertBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*GetBlogResponse)
+				typedResp, ok := resp.(*UpdateBlogResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -967,7 +4876,7 @@ func (s *blogServiceServer) serveGetBlogJSON(ctx context.Context, resp http.Resp
 	}
 
 	// Call service method
-	var respContent *GetBlogResponse
+	var respContent *UpdateBlogResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -978,7 +4887,7 @@ func (s *blogServiceServer) serveGetBlogJSON(ctx context.Context, resp http.Resp
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBlogResponse and nil error while calling GetBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling RevertBlog. nil responses are not supported"))
 		return
 	}
 
@@ -1004,9 +4913,9 @@ func (s *blogServiceServer) serveGetBlogJSON(ctx context.Context, resp http.Resp
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveGetBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveRevertBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "GetBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "RevertBlog")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1018,28 +4927,28 @@ func (s *blogServiceServer) serveGetBlogProtobuf(ctx context.Context, resp http.
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(GetBlogRequest)
+	reqContent := new(RevertBlogRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.BlogService.GetBlog
+	handler := s.BlogService.RevertBlog
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *GetBlogRequest) (*GetBlogResponse, error) {
+		handler = func(ctx context.Context, req *RevertBlogRequest) (*UpdateBlogResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*GetBlogRequest)
+					typedReq, ok := req.(*RevertBlogRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*GetBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*RevertBlogRequest) when calling interceptor")
 					}
-					return s.BlogService.GetBlog(ctx, typedReq)
+					return s.BlogService.RevertBlog(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*GetBlogResponse)
+				typedResp, ok := resp.(*UpdateBlogResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*GetBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1048,7 +4957,7 @@ func (s *blogServiceServer) serveGetBlogProtobuf(ctx context.Context, resp http.
 	}
 
 	// Call service method
-	var respContent *GetBlogResponse
+	var respContent *UpdateBlogResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1059,7 +4968,7 @@ func (s *blogServiceServer) serveGetBlogProtobuf(ctx context.Context, resp http.
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *GetBlogResponse and nil error while calling GetBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling RevertBlog. nil responses are not supported"))
 		return
 	}
 
@@ -1083,7 +4992,7 @@ func (s *blogServiceServer) serveGetBlogProtobuf(ctx context.Context, resp http.
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveUpdateBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchCreateBlogs(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1091,9 +5000,9 @@ func (s *blogServiceServer) serveUpdateBlog(ctx context.Context, resp http.Respo
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveUpdateBlogJSON(ctx, resp, req)
+		s.serveBatchCreateBlogsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveUpdateBlogProtobuf(ctx, resp, req)
+		s.serveBatchCreateBlogsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1101,9 +5010,9 @@ func (s *blogServiceServer) serveUpdateBlog(ctx context.Context, resp http.Respo
 	}
 }
 
-func (s *blogServiceServer) serveUpdateBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchCreateBlogsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "UpdateBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchCreateBlogs")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1116,29 +5025,29 @@ func (s *blogServiceServer) serveUpdateBlogJSON(ctx context.Context, resp http.R
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(UpdateBlogRequest)
+	reqContent := new(BatchCreateBlogsRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.BlogService.UpdateBlog
+	handler := s.BlogService.BatchCreateBlogs
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+		handler = func(ctx context.Context, req *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*UpdateBlogRequest)
+					typedReq, ok := req.(*BatchCreateBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*UpdateBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BatchCreateBlogsRequest) when calling interceptor")
 					}
-					return s.BlogService.UpdateBlog(ctx, typedReq)
+					return s.BlogService.BatchCreateBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*UpdateBlogResponse)
+				typedResp, ok := resp.(*BatchCreateBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchCreateBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1147,7 +5056,7 @@ func (s *blogServiceServer) serveUpdateBlogJSON(ctx context.Context, resp http.R
 	}
 
 	// Call service method
-	var respContent *UpdateBlogResponse
+	var respContent *BatchCreateBlogsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1158,7 +5067,7 @@ func (s *blogServiceServer) serveUpdateBlogJSON(ctx context.Context, resp http.R
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling UpdateBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchCreateBlogsResponse and nil error while calling BatchCreateBlogs. nil responses are not supported"))
 		return
 	}
 
@@ -1184,9 +5093,9 @@ func (s *blogServiceServer) serveUpdateBlogJSON(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveUpdateBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchCreateBlogsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "UpdateBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchCreateBlogs")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1198,28 +5107,28 @@ func (s *blogServiceServer) serveUpdateBlogProtobuf(ctx context.Context, resp ht
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(UpdateBlogRequest)
+	reqContent := new(BatchCreateBlogsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.BlogService.UpdateBlog
+	handler := s.BlogService.BatchCreateBlogs
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *UpdateBlogRequest) (*UpdateBlogResponse, error) {
+		handler = func(ctx context.Context, req *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*UpdateBlogRequest)
+					typedReq, ok := req.(*BatchCreateBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*UpdateBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BatchCreateBlogsRequest) when calling interceptor")
 					}
-					return s.BlogService.UpdateBlog(ctx, typedReq)
+					return s.BlogService.BatchCreateBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*UpdateBlogResponse)
+				typedResp, ok := resp.(*BatchCreateBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*UpdateBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchCreateBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1228,7 +5137,7 @@ func (s *blogServiceServer) serveUpdateBlogProtobuf(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *UpdateBlogResponse
+	var respContent *BatchCreateBlogsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1239,7 +5148,7 @@ func (s *blogServiceServer) serveUpdateBlogProtobuf(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *UpdateBlogResponse and nil error while calling UpdateBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchCreateBlogsResponse and nil error while calling BatchCreateBlogs. nil responses are not supported"))
 		return
 	}
 
@@ -1263,7 +5172,7 @@ func (s *blogServiceServer) serveUpdateBlogProtobuf(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveDeleteBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchGetBlogs(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1271,9 +5180,9 @@ func (s *blogServiceServer) serveDeleteBlog(ctx context.Context, resp http.Respo
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveDeleteBlogJSON(ctx, resp, req)
+		s.serveBatchGetBlogsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveDeleteBlogProtobuf(ctx, resp, req)
+		s.serveBatchGetBlogsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1281,9 +5190,9 @@ func (s *blogServiceServer) serveDeleteBlog(ctx context.Context, resp http.Respo
 	}
 }
 
-func (s *blogServiceServer) serveDeleteBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchGetBlogsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "DeleteBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchGetBlogs")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1296,29 +5205,29 @@ func (s *blogServiceServer) serveDeleteBlogJSON(ctx context.Context, resp http.R
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(DeleteBlogRequest)
+	reqContent := new(BatchGetBlogsRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.BlogService.DeleteBlog
+	handler := s.BlogService.BatchGetBlogs
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+		handler = func(ctx context.Context, req *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*DeleteBlogRequest)
+					typedReq, ok := req.(*BatchGetBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*DeleteBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BatchGetBlogsRequest) when calling interceptor")
 					}
-					return s.BlogService.DeleteBlog(ctx, typedReq)
+					return s.BlogService.BatchGetBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*DeleteBlogResponse)
+				typedResp, ok := resp.(*BatchGetBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*DeleteBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchGetBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1327,7 +5236,7 @@ func (s *blogServiceServer) serveDeleteBlogJSON(ctx context.Context, resp http.R
 	}
 
 	// Call service method
-	var respContent *DeleteBlogResponse
+	var respContent *BatchGetBlogsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1338,7 +5247,7 @@ func (s *blogServiceServer) serveDeleteBlogJSON(ctx context.Context, resp http.R
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteBlogResponse and nil error while calling DeleteBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchGetBlogsResponse and nil error while calling BatchGetBlogs. nil responses are not supported"))
 		return
 	}
 
@@ -1364,9 +5273,9 @@ func (s *blogServiceServer) serveDeleteBlogJSON(ctx context.Context, resp http.R
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveDeleteBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchGetBlogsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "DeleteBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchGetBlogs")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1378,28 +5287,28 @@ func (s *blogServiceServer) serveDeleteBlogProtobuf(ctx context.Context, resp ht
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(DeleteBlogRequest)
+	reqContent := new(BatchGetBlogsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.BlogService.DeleteBlog
+	handler := s.BlogService.BatchGetBlogs
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *DeleteBlogRequest) (*DeleteBlogResponse, error) {
+		handler = func(ctx context.Context, req *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*DeleteBlogRequest)
+					typedReq, ok := req.(*BatchGetBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*DeleteBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BatchGetBlogsRequest) when calling interceptor")
 					}
-					return s.BlogService.DeleteBlog(ctx, typedReq)
+					return s.BlogService.BatchGetBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*DeleteBlogResponse)
+				typedResp, ok := resp.(*BatchGetBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*DeleteBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchGetBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1408,7 +5317,7 @@ func (s *blogServiceServer) serveDeleteBlogProtobuf(ctx context.Context, resp ht
 	}
 
 	// Call service method
-	var respContent *DeleteBlogResponse
+	var respContent *BatchGetBlogsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1419,7 +5328,7 @@ func (s *blogServiceServer) serveDeleteBlogProtobuf(ctx context.Context, resp ht
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *DeleteBlogResponse and nil error while calling DeleteBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchGetBlogsResponse and nil error while calling BatchGetBlogs. nil responses are not supported"))
 		return
 	}
 
@@ -1443,7 +5352,7 @@ func (s *blogServiceServer) serveDeleteBlogProtobuf(ctx context.Context, resp ht
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveListBlog(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchDeleteBlogs(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	header := req.Header.Get("Content-Type")
 	i := strings.Index(header, ";")
 	if i == -1 {
@@ -1451,9 +5360,9 @@ func (s *blogServiceServer) serveListBlog(ctx context.Context, resp http.Respons
 	}
 	switch strings.TrimSpace(strings.ToLower(header[:i])) {
 	case "application/json":
-		s.serveListBlogJSON(ctx, resp, req)
+		s.serveBatchDeleteBlogsJSON(ctx, resp, req)
 	case "application/protobuf":
-		s.serveListBlogProtobuf(ctx, resp, req)
+		s.serveBatchDeleteBlogsProtobuf(ctx, resp, req)
 	default:
 		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
 		twerr := badRouteError(msg, req.Method, req.URL.Path)
@@ -1461,9 +5370,9 @@ func (s *blogServiceServer) serveListBlog(ctx context.Context, resp http.Respons
 	}
 }
 
-func (s *blogServiceServer) serveListBlogJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchDeleteBlogsJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchDeleteBlogs")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1476,29 +5385,29 @@ func (s *blogServiceServer) serveListBlogJSON(ctx context.Context, resp http.Res
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
-	reqContent := new(ListBlogRequest)
+	reqContent := new(BatchDeleteBlogsRequest)
 	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
 	if err = unmarshaler.Unmarshal(rawReqBody, reqContent); err != nil {
 		s.handleRequestBodyError(ctx, resp, "the json request could not be decoded", err)
 		return
 	}
 
-	handler := s.BlogService.ListBlog
+	handler := s.BlogService.BatchDeleteBlogs
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+		handler = func(ctx context.Context, req *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListBlogRequest)
+					typedReq, ok := req.(*BatchDeleteBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BatchDeleteBlogsRequest) when calling interceptor")
 					}
-					return s.BlogService.ListBlog(ctx, typedReq)
+					return s.BlogService.BatchDeleteBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListBlogResponse)
+				typedResp, ok := resp.(*BatchDeleteBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchDeleteBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1507,7 +5416,7 @@ func (s *blogServiceServer) serveListBlogJSON(ctx context.Context, resp http.Res
 	}
 
 	// Call service method
-	var respContent *ListBlogResponse
+	var respContent *BatchDeleteBlogsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1518,7 +5427,7 @@ func (s *blogServiceServer) serveListBlogJSON(ctx context.Context, resp http.Res
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListBlogResponse and nil error while calling ListBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchDeleteBlogsResponse and nil error while calling BatchDeleteBlogs. nil responses are not supported"))
 		return
 	}
 
@@ -1544,9 +5453,9 @@ func (s *blogServiceServer) serveListBlogJSON(ctx context.Context, resp http.Res
 	callResponseSent(ctx, s.hooks)
 }
 
-func (s *blogServiceServer) serveListBlogProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+func (s *blogServiceServer) serveBatchDeleteBlogsProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
 	var err error
-	ctx = ctxsetters.WithMethodName(ctx, "ListBlog")
+	ctx = ctxsetters.WithMethodName(ctx, "BatchDeleteBlogs")
 	ctx, err = callRequestRouted(ctx, s.hooks)
 	if err != nil {
 		s.writeError(ctx, resp, err)
@@ -1558,28 +5467,28 @@ func (s *blogServiceServer) serveListBlogProtobuf(ctx context.Context, resp http
 		s.handleRequestBodyError(ctx, resp, "failed to read request body", err)
 		return
 	}
-	reqContent := new(ListBlogRequest)
+	reqContent := new(BatchDeleteBlogsRequest)
 	if err = proto.Unmarshal(buf, reqContent); err != nil {
 		s.writeError(ctx, resp, malformedRequestError("the protobuf request could not be decoded"))
 		return
 	}
 
-	handler := s.BlogService.ListBlog
+	handler := s.BlogService.BatchDeleteBlogs
 	if s.interceptor != nil {
-		handler = func(ctx context.Context, req *ListBlogRequest) (*ListBlogResponse, error) {
+		handler = func(ctx context.Context, req *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
 			resp, err := s.interceptor(
 				func(ctx context.Context, req interface{}) (interface{}, error) {
-					typedReq, ok := req.(*ListBlogRequest)
+					typedReq, ok := req.(*BatchDeleteBlogsRequest)
 					if !ok {
-						return nil, twirp.InternalError("failed type assertion req.(*ListBlogRequest) when calling interceptor")
+						return nil, twirp.InternalError("failed type assertion req.(*BatchDeleteBlogsRequest) when calling interceptor")
 					}
-					return s.BlogService.ListBlog(ctx, typedReq)
+					return s.BlogService.BatchDeleteBlogs(ctx, typedReq)
 				},
 			)(ctx, req)
 			if resp != nil {
-				typedResp, ok := resp.(*ListBlogResponse)
+				typedResp, ok := resp.(*BatchDeleteBlogsResponse)
 				if !ok {
-					return nil, twirp.InternalError("failed type assertion resp.(*ListBlogResponse) when calling interceptor")
+					return nil, twirp.InternalError("failed type assertion resp.(*BatchDeleteBlogsResponse) when calling interceptor")
 				}
 				return typedResp, err
 			}
@@ -1588,7 +5497,7 @@ func (s *blogServiceServer) serveListBlogProtobuf(ctx context.Context, resp http
 	}
 
 	// Call service method
-	var respContent *ListBlogResponse
+	var respContent *BatchDeleteBlogsResponse
 	func() {
 		defer ensurePanicResponses(ctx, resp, s.hooks)
 		respContent, err = handler(ctx, reqContent)
@@ -1599,7 +5508,7 @@ func (s *blogServiceServer) serveListBlogProtobuf(ctx context.Context, resp http
 		return
 	}
 	if respContent == nil {
-		s.writeError(ctx, resp, twirp.InternalError("received a nil *ListBlogResponse and nil error while calling ListBlog. nil responses are not supported"))
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *BatchDeleteBlogsResponse and nil error while calling BatchDeleteBlogs. nil responses are not supported"))
 		return
 	}
 
@@ -1624,7 +5533,7 @@ func (s *blogServiceServer) serveListBlogProtobuf(ctx context.Context, resp http
 }
 
 func (s *blogServiceServer) ServiceDescriptor() ([]byte, int) {
-	return twirpFileDescriptor0, 0
+	return twirpFileDescriptor1, 0
 }
 
 func (s *blogServiceServer) ProtocGenTwirpVersion() string {
@@ -1638,595 +5547,133 @@ func (s *blogServiceServer) PathPrefix() string {
 	return baseServicePath(s.pathPrefix, "service", "BlogService")
 }
 
-// =====
-// Utils
-// =====
-
-// HTTPClient is the interface used by generated clients to send HTTP requests.
-// It is fulfilled by *(net/http).Client, which is sufficient for most users.
-// Users can provide their own implementation for special retry policies.
-//
-// HTTPClient implementations should not follow redirects. Redirects are
-// automatically disabled if *(net/http).Client is passed to client
-// constructors. See the withoutRedirects function in this file for more
-// details.
-type HTTPClient interface {
-	Do(req *http.Request) (*http.Response, error)
-}
-
-// TwirpServer is the interface generated server structs will support: they're
-// HTTP handlers with additional methods for accessing metadata about the
-// service. Those accessors are a low-level API for building reflection tools.
-// Most people can think of TwirpServers as just http.Handlers.
-type TwirpServer interface {
-	http.Handler
-
-	// ServiceDescriptor returns gzipped bytes describing the .proto file that
-	// this service was generated from. Once unzipped, the bytes can be
-	// unmarshalled as a
-	// google.golang.org/protobuf/types/descriptorpb.FileDescriptorProto.
-	//
-	// The returned integer is the index of this particular service within that
-	// FileDescriptorProto's 'Service' slice of ServiceDescriptorProtos. This is a
-	// low-level field, expected to be used for reflection.
-	ServiceDescriptor() ([]byte, int)
-
-	// ProtocGenTwirpVersion is the semantic version string of the version of
-	// twirp used to generate this file.
-	ProtocGenTwirpVersion() string
-
-	// PathPrefix returns the HTTP URL path prefix for all methods handled by this
-	// service. This can be used with an HTTP mux to route Twirp requests.
-	// The path prefix is in the form: "/<prefix>/<package>.<Service>/"
-	// that is, everything in a Twirp route except for the <Method> at the end.
-	PathPrefix() string
-}
-
-func newServerOpts(opts []interface{}) *twirp.ServerOptions {
-	serverOpts := &twirp.ServerOptions{}
-	for _, opt := range opts {
-		switch o := opt.(type) {
-		case twirp.ServerOption:
-			o(serverOpts)
-		case *twirp.ServerHooks: // backwards compatibility, allow to specify hooks as an argument
-			twirp.WithServerHooks(o)(serverOpts)
-		case nil: // backwards compatibility, allow nil value for the argument
-			continue
-		default:
-			panic(fmt.Sprintf("Invalid option type %T, please use a twirp.ServerOption", o))
-		}
-	}
-	return serverOpts
-}
-
-// WriteError writes an HTTP response with a valid Twirp error format (code, msg, meta).
-// Useful outside of the Twirp server (e.g. http middleware), but does not trigger hooks.
-// If err is not a twirp.Error, it will get wrapped with twirp.InternalErrorWith(err)
-func WriteError(resp http.ResponseWriter, err error) {
-	writeError(context.Background(), resp, err, nil)
-}
-
-// writeError writes Twirp errors in the response and triggers hooks.
-func writeError(ctx context.Context, resp http.ResponseWriter, err error, hooks *twirp.ServerHooks) {
-	// Convert to a twirp.Error. Non-twirp errors are converted to internal errors.
-	var twerr twirp.Error
-	if !errors.As(err, &twerr) {
-		twerr = twirp.InternalErrorWith(err)
-	}
-
-	statusCode := twirp.ServerHTTPStatusFromErrorCode(twerr.Code())
-	ctx = ctxsetters.WithStatusCode(ctx, statusCode)
-	ctx = callError(ctx, hooks, twerr)
-
-	respBody := marshalErrorToJSON(twerr)
-
-	resp.Header().Set("Content-Type", "application/json") // Error responses are always JSON
-	resp.Header().Set("Content-Length", strconv.Itoa(len(respBody)))
-	resp.WriteHeader(statusCode) // set HTTP status code and send response
-
-	_, writeErr := resp.Write(respBody)
-	if writeErr != nil {
-		// We have three options here. We could log the error, call the Error
-		// hook, or just silently ignore the error.
-		//
-		// Logging is unacceptable because we don't have a user-controlled
-		// logger; writing out to stderr without permission is too rude.
-		//
-		// Calling the Error hook would confuse users: it would mean the Error
-		// hook got called twice for one request, which is likely to lead to
-		// duplicated log messages and metrics, no matter how well we document
-		// the behavior.
-		//
-		// Silently ignoring the error is our least-bad option. It's highly
-		// likely that the connection is broken and the original 'err' says
-		// so anyway.
-		_ = writeErr
-	}
-
-	callResponseSent(ctx, hooks)
-}
-
-// sanitizeBaseURL parses the the baseURL, and adds the "http" scheme if needed.
-// If the URL is unparsable, the baseURL is returned unchaged.
-func sanitizeBaseURL(baseURL string) string {
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		return baseURL // invalid URL will fail later when making requests
-	}
-	if u.Scheme == "" {
-		u.Scheme = "http"
-	}
-	return u.String()
-}
-
-// baseServicePath composes the path prefix for the service (without <Method>).
-// e.g.: baseServicePath("/twirp", "my.pkg", "MyService")
-//       returns => "/twirp/my.pkg.MyService/"
-// e.g.: baseServicePath("", "", "MyService")
-//       returns => "/MyService/"
-func baseServicePath(prefix, pkg, service string) string {
-	fullServiceName := service
-	if pkg != "" {
-		fullServiceName = pkg + "." + service
-	}
-	return path.Join("/", prefix, fullServiceName) + "/"
-}
-
-// parseTwirpPath extracts path components form a valid Twirp route.
-// Expected format: "[<prefix>]/<package>.<Service>/<Method>"
-// e.g.: prefix, pkgService, method := parseTwirpPath("/twirp/pkg.Svc/MakeHat")
-func parseTwirpPath(path string) (string, string, string) {
-	parts := strings.Split(path, "/")
-	if len(parts) < 2 {
-		return "", "", ""
-	}
-	method := parts[len(parts)-1]
-	pkgService := parts[len(parts)-2]
-	prefix := strings.Join(parts[0:len(parts)-2], "/")
-	return prefix, pkgService, method
-}
-
-// getCustomHTTPReqHeaders retrieves a copy of any headers that are set in
-// a context through the twirp.WithHTTPRequestHeaders function.
-// If there are no headers set, or if they have the wrong type, nil is returned.
-func getCustomHTTPReqHeaders(ctx context.Context) http.Header {
-	header, ok := twirp.HTTPRequestHeaders(ctx)
-	if !ok || header == nil {
-		return nil
-	}
-	copied := make(http.Header)
-	for k, vv := range header {
-		if vv == nil {
-			copied[k] = nil
-			continue
-		}
-		copied[k] = make([]string, len(vv))
-		copy(copied[k], vv)
-	}
-	return copied
-}
-
-// newRequest makes an http.Request from a client, adding common headers.
-func newRequest(ctx context.Context, url string, reqBody io.Reader, contentType string) (*http.Request, error) {
-	req, err := http.NewRequest("POST", url, reqBody)
-	if err != nil {
-		return nil, err
-	}
-	req = req.WithContext(ctx)
-	if customHeader := getCustomHTTPReqHeaders(ctx); customHeader != nil {
-		req.Header = customHeader
-	}
-	req.Header.Set("Accept", contentType)
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set("Twirp-Version", "v8.1.0")
-	return req, nil
-}
-
-// JSON serialization for errors
-type twerrJSON struct {
-	Code string            `json:"code"`
-	Msg  string            `json:"msg"`
-	Meta map[string]string `json:"meta,omitempty"`
-}
-
-// marshalErrorToJSON returns JSON from a twirp.Error, that can be used as HTTP error response body.
-// If serialization fails, it will use a descriptive Internal error instead.
-func marshalErrorToJSON(twerr twirp.Error) []byte {
-	// make sure that msg is not too large
-	msg := twerr.Msg()
-	if len(msg) > 1e6 {
-		msg = msg[:1e6]
-	}
-
-	tj := twerrJSON{
-		Code: string(twerr.Code()),
-		Msg:  msg,
-		Meta: twerr.MetaMap(),
-	}
-
-	buf, err := json.Marshal(&tj)
-	if err != nil {
-		buf = []byte("{\"type\": \"" + twirp.Internal + "\", \"msg\": \"There was an error but it could not be serialized into JSON\"}") // fallback
-	}
-
-	return buf
-}
-
-// errorFromResponse builds a twirp.Error from a non-200 HTTP response.
-// If the response has a valid serialized Twirp error, then it's returned.
-// If not, the response status code is used to generate a similar twirp
-// error. See twirpErrorFromIntermediary for more info on intermediary errors.
-func errorFromResponse(resp *http.Response) twirp.Error {
-	statusCode := resp.StatusCode
-	statusText := http.StatusText(statusCode)
-
-	if isHTTPRedirect(statusCode) {
-		// Unexpected redirect: it must be an error from an intermediary.
-		// Twirp clients don't follow redirects automatically, Twirp only handles
-		// POST requests, redirects should only happen on GET and HEAD requests.
-		location := resp.Header.Get("Location")
-		msg := fmt.Sprintf("unexpected HTTP status code %d %q received, Location=%q", statusCode, statusText, location)
-		return twirpErrorFromIntermediary(statusCode, msg, location)
-	}
-
-	respBodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return wrapInternal(err, "failed to read server error response body")
-	}
-
-	var tj twerrJSON
-	dec := json.NewDecoder(bytes.NewReader(respBodyBytes))
-	dec.DisallowUnknownFields()
-	if err := dec.Decode(&tj); err != nil || tj.Code == "" {
-		// Invalid JSON response; it must be an error from an intermediary.
-		msg := fmt.Sprintf("Error from intermediary with HTTP status code %d %q", statusCode, statusText)
-		return twirpErrorFromIntermediary(statusCode, msg, string(respBodyBytes))
-	}
-
-	errorCode := twirp.ErrorCode(tj.Code)
-	if !twirp.IsValidErrorCode(errorCode) {
-		msg := "invalid type returned from server error response: " + tj.Code
-		return twirp.InternalError(msg).WithMeta("body", string(respBodyBytes))
-	}
-
-	twerr := twirp.NewError(errorCode, tj.Msg)
-	for k, v := range tj.Meta {
-		twerr = twerr.WithMeta(k, v)
-	}
-	return twerr
-}
-
-// twirpErrorFromIntermediary maps HTTP errors from non-twirp sources to twirp errors.
-// The mapping is similar to gRPC: https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md.
-// Returned twirp Errors have some additional metadata for inspection.
-func twirpErrorFromIntermediary(status int, msg string, bodyOrLocation string) twirp.Error {
-	var code twirp.ErrorCode
-	if isHTTPRedirect(status) { // 3xx
-		code = twirp.Internal
-	} else {
-		switch status {
-		case 400: // Bad Request
-			code = twirp.Internal
-		case 401: // Unauthorized
-			code = twirp.Unauthenticated
-		case 403: // Forbidden
-			code = twirp.PermissionDenied
-		case 404: // Not Found
-			code = twirp.BadRoute
-		case 429: // Too Many Requests
-			code = twirp.ResourceExhausted
-		case 502, 503, 504: // Bad Gateway, Service Unavailable, Gateway Timeout
-			code = twirp.Unavailable
-		default: // All other codes
-			code = twirp.Unknown
-		}
-	}
-
-	twerr := twirp.NewError(code, msg)
-	twerr = twerr.WithMeta("http_error_from_intermediary", "true") // to easily know if this error was from intermediary
-	twerr = twerr.WithMeta("status_code", strconv.Itoa(status))
-	if isHTTPRedirect(status) {
-		twerr = twerr.WithMeta("location", bodyOrLocation)
-	} else {
-		twerr = twerr.WithMeta("body", bodyOrLocation)
-	}
-	return twerr
-}
-
-func isHTTPRedirect(status int) bool {
-	return status >= 300 && status <= 399
-}
-
-// wrapInternal wraps an error with a prefix as an Internal error.
-// The original error cause is accessible by github.com/pkg/errors.Cause.
-func wrapInternal(err error, prefix string) twirp.Error {
-	return twirp.InternalErrorWith(&wrappedError{prefix: prefix, cause: err})
-}
-
-type wrappedError struct {
-	prefix string
-	cause  error
-}
-
-func (e *wrappedError) Error() string { return e.prefix + ": " + e.cause.Error() }
-func (e *wrappedError) Unwrap() error { return e.cause } // for go1.13 + errors.Is/As
-func (e *wrappedError) Cause() error  { return e.cause } // for github.com/pkg/errors
-
-// ensurePanicResponses makes sure that rpc methods causing a panic still result in a Twirp Internal
-// error response (status 500), and error hooks are properly called with the panic wrapped as an error.
-// The panic is re-raised so it can be handled normally with middleware.
-func ensurePanicResponses(ctx context.Context, resp http.ResponseWriter, hooks *twirp.ServerHooks) {
-	if r := recover(); r != nil {
-		// Wrap the panic as an error so it can be passed to error hooks.
-		// The original error is accessible from error hooks, but not visible in the response.
-		err := errFromPanic(r)
-		twerr := &internalWithCause{msg: "Internal service panic", cause: err}
-		// Actually write the error
-		writeError(ctx, resp, twerr, hooks)
-		// If possible, flush the error to the wire.
-		f, ok := resp.(http.Flusher)
-		if ok {
-			f.Flush()
-		}
-
-		panic(r)
-	}
-}
-
-// errFromPanic returns the typed error if the recovered panic is an error, otherwise formats as error.
-func errFromPanic(p interface{}) error {
-	if err, ok := p.(error); ok {
-		return err
-	}
-	return fmt.Errorf("panic: %v", p)
-}
-
-// internalWithCause is a Twirp Internal error wrapping an original error cause,
-// but the original error message is not exposed on Msg(). The original error
-// can be checked with go1.13+ errors.Is/As, and also by (github.com/pkg/errors).Unwrap
-type internalWithCause struct {
-	msg   string
-	cause error
-}
-
-func (e *internalWithCause) Unwrap() error                               { return e.cause } // for go1.13 + errors.Is/As
-func (e *internalWithCause) Cause() error                                { return e.cause } // for github.com/pkg/errors
-func (e *internalWithCause) Error() string                               { return e.msg + ": " + e.cause.Error() }
-func (e *internalWithCause) Code() twirp.ErrorCode                       { return twirp.Internal }
-func (e *internalWithCause) Msg() string                                 { return e.msg }
-func (e *internalWithCause) Meta(key string) string                      { return "" }
-func (e *internalWithCause) MetaMap() map[string]string                  { return nil }
-func (e *internalWithCause) WithMeta(key string, val string) twirp.Error { return e }
-
-// malformedRequestError is used when the twirp server cannot unmarshal a request
-func malformedRequestError(msg string) twirp.Error {
-	return twirp.NewError(twirp.Malformed, msg)
-}
-
-// badRouteError is used when the twirp server cannot route a request
-func badRouteError(msg string, method, url string) twirp.Error {
-	err := twirp.NewError(twirp.BadRoute, msg)
-	err = err.WithMeta("twirp_invalid_route", method+" "+url)
-	return err
-}
-
-// withoutRedirects makes sure that the POST request can not be redirected.
-// The standard library will, by default, redirect requests (including POSTs) if it gets a 302 or
-// 303 response, and also 301s in go1.8. It redirects by making a second request, changing the
-// method to GET and removing the body. This produces very confusing error messages, so instead we
-// set a redirect policy that always errors. This stops Go from executing the redirect.
-//
-// We have to be a little careful in case the user-provided http.Client has its own CheckRedirect
-// policy - if so, we'll run through that policy first.
-//
-// Because this requires modifying the http.Client, we make a new copy of the client and return it.
-func withoutRedirects(in *http.Client) *http.Client {
-	copy := *in
-	copy.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		if in.CheckRedirect != nil {
-			// Run the input's redirect if it exists, in case it has side effects, but ignore any error it
-			// returns, since we want to use ErrUseLastResponse.
-			err := in.CheckRedirect(req, via)
-			_ = err // Silly, but this makes sure generated code passes errcheck -blank, which some people use.
-		}
-		return http.ErrUseLastResponse
-	}
-	return &copy
-}
-
-// doProtobufRequest makes a Protobuf request to the remote Twirp service.
-func doProtobufRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
-	reqBodyBytes, err := proto.Marshal(in)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to marshal proto request")
-	}
-	reqBody := bytes.NewBuffer(reqBodyBytes)
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	req, err := newRequest(ctx, url, reqBody, "application/protobuf")
-	if err != nil {
-		return ctx, wrapInternal(err, "could not build request")
-	}
-	ctx, err = callClientRequestPrepared(ctx, hooks, req)
-	if err != nil {
-		return ctx, err
-	}
-
-	req = req.WithContext(ctx)
-	resp, err := client.Do(req)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to do request")
-	}
-
-	defer func() {
-		cerr := resp.Body.Close()
-		if err == nil && cerr != nil {
-			err = wrapInternal(cerr, "failed to close response body")
-		}
-	}()
-
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	if resp.StatusCode != 200 {
-		return ctx, errorFromResponse(resp)
-	}
-
-	respBodyBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to read response body")
-	}
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	if err = proto.Unmarshal(respBodyBytes, out); err != nil {
-		return ctx, wrapInternal(err, "failed to unmarshal proto response")
-	}
-	return ctx, nil
-}
-
-// doJSONRequest makes a JSON request to the remote Twirp service.
-func doJSONRequest(ctx context.Context, client HTTPClient, hooks *twirp.ClientHooks, url string, in, out proto.Message) (_ context.Context, err error) {
-	marshaler := &protojson.MarshalOptions{UseProtoNames: true}
-	reqBytes, err := marshaler.Marshal(in)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to marshal json request")
-	}
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	req, err := newRequest(ctx, url, bytes.NewReader(reqBytes), "application/json")
-	if err != nil {
-		return ctx, wrapInternal(err, "could not build request")
-	}
-	ctx, err = callClientRequestPrepared(ctx, hooks, req)
-	if err != nil {
-		return ctx, err
-	}
-
-	req = req.WithContext(ctx)
-	resp, err := client.Do(req)
-	if err != nil {
-		return ctx, wrapInternal(err, "failed to do request")
-	}
-
-	defer func() {
-		cerr := resp.Body.Close()
-		if err == nil && cerr != nil {
-			err = wrapInternal(cerr, "failed to close response body")
-		}
-	}()
-
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-
-	if resp.StatusCode != 200 {
-		return ctx, errorFromResponse(resp)
-	}
-
-	d := json.NewDecoder(resp.Body)
-	rawRespBody := json.RawMessage{}
-	if err := d.Decode(&rawRespBody); err != nil {
-		return ctx, wrapInternal(err, "failed to unmarshal json response")
-	}
-	unmarshaler := protojson.UnmarshalOptions{DiscardUnknown: true}
-	if err = unmarshaler.Unmarshal(rawRespBody, out); err != nil {
-		return ctx, wrapInternal(err, "failed to unmarshal json response")
-	}
-	if err = ctx.Err(); err != nil {
-		return ctx, wrapInternal(err, "aborted because context was done")
-	}
-	return ctx, nil
-}
-
-// Call twirp.ServerHooks.RequestReceived if the hook is available
-func callRequestReceived(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
-	if h == nil || h.RequestReceived == nil {
-		return ctx, nil
-	}
-	return h.RequestReceived(ctx)
-}
-
-// Call twirp.ServerHooks.RequestRouted if the hook is available
-func callRequestRouted(ctx context.Context, h *twirp.ServerHooks) (context.Context, error) {
-	if h == nil || h.RequestRouted == nil {
-		return ctx, nil
-	}
-	return h.RequestRouted(ctx)
-}
-
-// Call twirp.ServerHooks.ResponsePrepared if the hook is available
-func callResponsePrepared(ctx context.Context, h *twirp.ServerHooks) context.Context {
-	if h == nil || h.ResponsePrepared == nil {
-		return ctx
-	}
-	return h.ResponsePrepared(ctx)
-}
-
-// Call twirp.ServerHooks.ResponseSent if the hook is available
-func callResponseSent(ctx context.Context, h *twirp.ServerHooks) {
-	if h == nil || h.ResponseSent == nil {
-		return
-	}
-	h.ResponseSent(ctx)
-}
-
-// Call twirp.ServerHooks.Error if the hook is available
-func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) context.Context {
-	if h == nil || h.Error == nil {
-		return ctx
-	}
-	return h.Error(ctx, err)
-}
-
-func callClientResponseReceived(ctx context.Context, h *twirp.ClientHooks) {
-	if h == nil || h.ResponseReceived == nil {
-		return
-	}
-	h.ResponseReceived(ctx)
-}
-
-func callClientRequestPrepared(ctx context.Context, h *twirp.ClientHooks, req *http.Request) (context.Context, error) {
-	if h == nil || h.RequestPrepared == nil {
-		return ctx, nil
-	}
-	return h.RequestPrepared(ctx, req)
-}
-
-func callClientError(ctx context.Context, h *twirp.ClientHooks, err twirp.Error) {
-	if h == nil || h.Error == nil {
-		return
-	}
-	h.Error(ctx, err)
-}
-
-var twirpFileDescriptor0 = []byte{
-	// 335 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0x41, 0x4f, 0xfa, 0x40,
-	0x14, 0xc4, 0x43, 0x09, 0x7f, 0xf8, 0x0f, 0x09, 0xc8, 0xd3, 0xc4, 0x5a, 0x2f, 0xa4, 0x9a, 0xc8,
-	0x09, 0x22, 0x5e, 0x4d, 0x4c, 0xc0, 0xc6, 0x8b, 0x17, 0x0b, 0x5e, 0xbc, 0x09, 0xbc, 0x90, 0x4d,
-	0x2a, 0xad, 0xdd, 0xd5, 0xef, 0xeb, 0x37, 0x31, 0x6c, 0x5b, 0xba, 0xb6, 0x0d, 0x89, 0x91, 0xe3,
-	0xdb, 0x9d, 0xfe, 0x76, 0xf2, 0x66, 0x8a, 0xe3, 0x28, 0x0e, 0x55, 0x38, 0x92, 0x1c, 0x7f, 0x8a,
-	0x25, 0x0f, 0xf5, 0x44, 0xcd, 0x74, 0x74, 0xa7, 0xe8, 0x4d, 0x63, 0x7e, 0x55, 0x3c, 0x09, 0xc2,
-	0xb5, 0xcf, 0xef, 0x1f, 0x2c, 0x15, 0x9d, 0xa0, 0xa1, 0x84, 0x0a, 0xd8, 0xb6, 0xfa, 0xb5, 0xc1,
-	0x7f, 0x3f, 0x19, 0xc8, 0x46, 0x73, 0x19, 0x6e, 0x14, 0x6f, 0x94, 0x5d, 0xd7, 0xe7, 0xd9, 0xe8,
-	0xce, 0x41, 0x26, 0x44, 0x46, 0xe1, 0x46, 0x32, 0x75, 0x60, 0x89, 0x95, 0x5d, 0xd3, 0x52, 0x4b,
-	0xac, 0x7e, 0x4d, 0xed, 0xa3, 0xf3, 0xc0, 0xca, 0xf4, 0x55, 0x20, 0xba, 0x4f, 0xe8, 0xee, 0x14,
-	0x07, 0x7a, 0x74, 0x86, 0xde, 0x73, 0xb4, 0x2a, 0xec, 0xe3, 0xaf, 0xd0, 0x39, 0xc8, 0x84, 0x1e,
-	0xc8, 0xea, 0x05, 0x7a, 0xf7, 0x1c, 0xf0, 0x5e, 0xab, 0xee, 0x25, 0xc8, 0x14, 0x55, 0x3f, 0xed,
-	0x5e, 0xa1, 0xfb, 0x28, 0xa4, 0x2a, 0x74, 0x20, 0x10, 0x6f, 0x42, 0x69, 0x55, 0xdd, 0x4f, 0x06,
-	0xd7, 0xc3, 0x51, 0x2e, 0x4c, 0x61, 0xd7, 0x68, 0x2c, 0x82, 0x70, 0x2d, 0xed, 0x5a, 0xbf, 0x3e,
-	0x68, 0x8f, 0xcf, 0x87, 0x59, 0xd5, 0xca, 0x9d, 0xf0, 0x13, 0xe5, 0xf8, 0xcb, 0x42, 0x7b, 0x7b,
-	0x3e, 0x4b, 0x94, 0xe4, 0x01, 0xb9, 0x98, 0x9c, 0x4a, 0x82, 0xb6, 0xe5, 0xec, 0xa3, 0xd3, 0x2d,
-	0x9a, 0x69, 0x1f, 0xe8, 0x74, 0xa7, 0xfb, 0xd9, 0x21, 0xc7, 0x2e, 0x5f, 0xa4, 0x5f, 0x7b, 0x40,
-	0x9e, 0x92, 0x61, 0xa2, 0xd4, 0x07, 0xc3, 0x44, 0x45, 0xac, 0x1e, 0x90, 0x6f, 0xdc, 0xc0, 0x94,
-	0xb2, 0x32, 0x30, 0x15, 0x11, 0xdd, 0xa1, 0x95, 0x6d, 0x9a, 0x72, 0xcf, 0x85, 0x94, 0x9c, 0xb3,
-	0x8a, 0x9b, 0x04, 0x30, 0xc1, 0x4b, 0x2b, 0x8e, 0x96, 0xa3, 0xed, 0xc2, 0x17, 0xff, 0xf4, 0x5f,
-	0x7f, 0xf3, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x36, 0x68, 0x0a, 0x15, 0x0c, 0x04, 0x00, 0x00,
+var twirpFileDescriptor1 = []byte{
+	// 2030 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0xdd, 0x6e, 0x23, 0x49,
+	0x15, 0x5e, 0xff, 0x24, 0xb6, 0x8f, 0xe3, 0xa4, 0x5d, 0x93, 0xd9, 0x74, 0x3a, 0x9b, 0xd9, 0xa4,
+	0xd9, 0x85, 0xec, 0x80, 0x12, 0x76, 0x56, 0xc0, 0x02, 0xbb, 0x5a, 0xfc, 0x97, 0x49, 0x98, 0xc4,
+	0x89, 0x3a, 0xce, 0x8e, 0x98, 0x9b, 0x56, 0xc7, 0x2e, 0x3b, 0xad, 0x69, 0xbb, 0x3d, 0xdd, 0xe5,
+	0xec, 0x44, 0x02, 0x71, 0xcf, 0x05, 0x42, 0x5c, 0x70, 0xc9, 0x1b, 0x70, 0xc1, 0x0b, 0xf0, 0x12,
+	0x3c, 0x00, 0xf7, 0x3c, 0x00, 0xd7, 0xa8, 0x7e, 0xfa, 0xbf, 0xdd, 0x8e, 0x05, 0x48, 0x48, 0x3b,
+	0x77, 0x5d, 0xa7, 0x4e, 0x9d, 0x3a, 0x3f, 0xdf, 0xa9, 0x3a, 0xa7, 0x1a, 0x1e, 0x4d, 0x1d, 0x9b,
+	0xd8, 0x47, 0x2e, 0x76, 0xee, 0xcc, 0x3e, 0x3e, 0x64, 0x23, 0x54, 0x12, 0x43, 0x65, 0x6f, 0x64,
+	0xdb, 0x23, 0x0b, 0x1f, 0x31, 0xf2, 0xcd, 0x6c, 0x78, 0x34, 0x34, 0xb1, 0x35, 0xd0, 0xc7, 0x86,
+	0xfb, 0x9a, 0xb3, 0x2a, 0x1f, 0xc6, 0x39, 0x88, 0x39, 0xc6, 0x2e, 0x31, 0xc6, 0x53, 0xce, 0xa0,
+	0xfe, 0x23, 0x07, 0xf5, 0x96, 0x83, 0x0d, 0x82, 0x9b, 0x96, 0x3d, 0xd2, 0xf0, 0x9b, 0x19, 0x76,
+	0x09, 0xda, 0x84, 0x15, 0x62, 0x12, 0x0b, 0xcb, 0xf9, 0xbd, 0xdc, 0x41, 0x45, 0xe3, 0x03, 0x24,
+	0x43, 0xa9, 0x6f, 0x4f, 0x08, 0x9e, 0x10, 0xb9, 0xc0, 0xe8, 0xde, 0x10, 0x21, 0x28, 0x12, 0x63,
+	0xe4, 0xca, 0xc5, 0xbd, 0xc2, 0x41, 0x45, 0x63, 0xdf, 0x48, 0x81, 0x72, 0xdf, 0x20, 0x78, 0x64,
+	0x3b, 0xf7, 0xf2, 0x0a, 0x63, 0xf7, 0xc7, 0xe8, 0xfb, 0xb0, 0xea, 0x12, 0x83, 0xcc, 0x5c, 0x79,
+	0x75, 0x2f, 0x77, 0xb0, 0xfe, 0xec, 0xd1, 0xa1, 0x67, 0x21, 0xd5, 0xe2, 0x8a, 0x4d, 0x69, 0x82,
+	0x05, 0x7d, 0x09, 0xeb, 0x62, 0x1f, 0x7d, 0x68, 0x3b, 0x63, 0x83, 0xc8, 0x25, 0xb6, 0xe8, 0x7d,
+	0x7f, 0x51, 0x8b, 0x4f, 0x1f, 0xb3, 0x59, 0xad, 0xd6, 0x0f, 0x0f, 0xd5, 0xbf, 0x16, 0x01, 0x85,
+	0x2d, 0x74, 0xa7, 0xf6, 0xc4, 0xc5, 0x68, 0x1d, 0xf2, 0xe6, 0x40, 0xce, 0x31, 0xc5, 0xf2, 0xe6,
+	0x60, 0x69, 0x93, 0x7f, 0x0e, 0xd5, 0x3e, 0x93, 0xaa, 0x53, 0x97, 0xca, 0xc5, 0xbd, 0xdc, 0x41,
+	0xf5, 0x99, 0x72, 0xc8, 0xfd, 0x7d, 0xe8, 0xf9, 0xfb, 0xb0, 0xe7, 0xf9, 0x5b, 0x03, 0xce, 0x4e,
+	0x09, 0x74, 0xf1, 0x6c, 0x3a, 0xf0, 0x17, 0xaf, 0x2c, 0x5e, 0xcc, 0xd9, 0xd9, 0xe2, 0x1d, 0xa8,
+	0x18, 0x33, 0x72, 0x6b, 0x3b, 0xba, 0x39, 0x60, 0xfe, 0xab, 0x68, 0x65, 0x4e, 0x38, 0x1d, 0x50,
+	0x85, 0xef, 0xb0, 0xe3, 0x9a, 0xf6, 0x84, 0x79, 0xa9, 0xa0, 0x79, 0x43, 0xba, 0xe7, 0x00, 0x5b,
+	0xd8, 0xdb, 0xb3, 0xbc, 0x78, 0x4f, 0xce, 0xce, 0xf6, 0xf4, 0x02, 0x5c, 0x99, 0x13, 0x60, 0x98,
+	0x1b, 0xe0, 0xea, 0x43, 0x02, 0xbc, 0x36, 0x9d, 0xdd, 0x58, 0xa6, 0x7b, 0xcb, 0x55, 0x5b, 0x5b,
+	0xa8, 0x5a, 0x55, 0xf0, 0x7b, 0xba, 0xb9, 0xd6, 0x6c, 0x24, 0xd7, 0x98, 0x0e, 0xec, 0x3b, 0x05,
+	0x33, 0xeb, 0xcb, 0x60, 0xa6, 0x05, 0xeb, 0xcf, 0x31, 0x09, 0x67, 0x44, 0x1c, 0x2e, 0xfb, 0xb0,
+	0xe6, 0xde, 0xda, 0xdf, 0xe8, 0xdc, 0x47, 0x03, 0x86, 0x9a, 0xb2, 0x56, 0xa5, 0xb4, 0x36, 0x27,
+	0xa9, 0x4f, 0x61, 0x53, 0x08, 0x69, 0xde, 0x5f, 0x59, 0x33, 0x5f, 0x94, 0xa7, 0x6f, 0x2e, 0xd0,
+	0x57, 0xfd, 0x7b, 0x11, 0x36, 0xfc, 0x1d, 0xdf, 0x21, 0xf4, 0x1d, 0x42, 0x17, 0x20, 0x14, 0x7d,
+	0x07, 0x6a, 0x0e, 0x9e, 0x0c, 0xb0, 0x83, 0x07, 0xfa, 0x2d, 0x19, 0x5b, 0xf2, 0x06, 0x93, 0xbd,
+	0xe6, 0x11, 0x4f, 0xc8, 0xd8, 0x52, 0xff, 0x92, 0x87, 0xfa, 0x35, 0x0b, 0x4b, 0x16, 0x94, 0x97,
+	0xc5, 0x55, 0x28, 0x80, 0xc5, 0x44, 0x00, 0x05, 0x68, 0xe8, 0x15, 0x34, 0x17, 0x34, 0xc7, 0xf4,
+	0x96, 0x3a, 0x37, 0xdc, 0xd7, 0x1e, 0x68, 0xe8, 0xb7, 0x1f, 0xc0, 0xd5, 0x39, 0x01, 0x2c, 0xc5,
+	0x02, 0xe8, 0x39, 0xb5, 0x9c, 0xe9, 0xd4, 0xca, 0xb2, 0x57, 0x45, 0xd8, 0x5f, 0xef, 0x12, 0xf1,
+	0x5d, 0x22, 0x2e, 0xc2, 0xcc, 0x97, 0x50, 0xe7, 0x07, 0x7e, 0x56, 0x8a, 0x85, 0x5c, 0x9d, 0x8f,
+	0xb8, 0x5a, 0xfd, 0x08, 0x50, 0x78, 0x79, 0x3a, 0xe2, 0xd4, 0xaf, 0xe0, 0xd1, 0xf5, 0x64, 0xf0,
+	0x1f, 0x6c, 0xf3, 0xaf, 0x02, 0x6c, 0x46, 0x25, 0x7c, 0x5b, 0xb1, 0xfd, 0x2d, 0x83, 0xe7, 0x1f,
+	0x0b, 0x00, 0x54, 0xd1, 0x63, 0xd3, 0x22, 0xd8, 0xa1, 0x65, 0x0b, 0x8b, 0xa8, 0x3e, 0x75, 0xf0,
+	0xd0, 0x7c, 0x2b, 0x02, 0x5f, 0x65, 0xb4, 0x4b, 0x46, 0x42, 0x1f, 0xc3, 0x3a, 0x67, 0xa1, 0x82,
+	0x0c, 0x73, 0xe2, 0x0a, 0x28, 0xd4, 0x18, 0xb5, 0x25, 0x88, 0xe8, 0x18, 0xea, 0xa1, 0xc0, 0xeb,
+	0xc6, 0x90, 0x60, 0x47, 0x2e, 0x2c, 0xb4, 0x77, 0x23, 0x08, 0x7f, 0x83, 0x2e, 0x41, 0x27, 0x80,
+	0xc2, 0x72, 0x6e, 0xf0, 0xd0, 0x76, 0x1e, 0x82, 0x23, 0x29, 0x10, 0xd4, 0x64, 0x6b, 0xa2, 0x80,
+	0x58, 0x89, 0x01, 0x22, 0xed, 0x76, 0x39, 0x84, 0x0a, 0x31, 0x46, 0xfa, 0xd8, 0x20, 0xfd, 0x5b,
+	0xd1, 0x53, 0xd4, 0x7d, 0xaf, 0xf6, 0x8c, 0xd1, 0x39, 0x9d, 0xd0, 0xca, 0x44, 0x7c, 0x45, 0x60,
+	0x52, 0x9e, 0x0b, 0x93, 0xca, 0x42, 0x98, 0xa8, 0xbf, 0x01, 0x74, 0xc9, 0xc3, 0x9e, 0x95, 0xcd,
+	0x71, 0x30, 0xe5, 0x97, 0x03, 0x53, 0x28, 0x05, 0x0a, 0xd1, 0xc3, 0xe0, 0x15, 0x3d, 0x0b, 0xa6,
+	0x8b, 0x15, 0x90, 0xa1, 0x64, 0x38, 0xfd, 0x5b, 0xf3, 0x0e, 0x8b, 0xf2, 0xd6, 0x1b, 0x66, 0xc8,
+	0xfe, 0x1e, 0x6c, 0x9c, 0x99, 0x2e, 0xe9, 0x19, 0x23, 0x37, 0xd4, 0x4c, 0x5a, 0xe6, 0xd8, 0x24,
+	0x4c, 0x72, 0x41, 0xe3, 0x03, 0xf5, 0x19, 0x94, 0x7b, 0xc6, 0xa8, 0x65, 0xcf, 0x26, 0x04, 0x49,
+	0x50, 0x20, 0x86, 0x57, 0x10, 0xd3, 0x4f, 0xba, 0xa6, 0x4f, 0xa7, 0xc4, 0x39, 0xc6, 0x07, 0xea,
+	0x4f, 0x41, 0x0a, 0x84, 0x8b, 0x03, 0xec, 0x63, 0x11, 0xd8, 0xdc, 0x5e, 0xe1, 0xa0, 0x1a, 0x8d,
+	0x1f, 0x13, 0xce, 0x63, 0xad, 0xfe, 0x3e, 0xc7, 0x15, 0x8b, 0x75, 0xb9, 0x49, 0xc5, 0xd0, 0x2e,
+	0xc0, 0xd4, 0x18, 0x61, 0x9d, 0xd8, 0xaf, 0xf1, 0x44, 0x60, 0xbf, 0x42, 0x29, 0x3d, 0x4a, 0xa0,
+	0x81, 0x1e, 0xb2, 0x5c, 0x12, 0x60, 0x8f, 0x06, 0x9a, 0xa7, 0x99, 0x26, 0x58, 0xd0, 0x36, 0x94,
+	0x6d, 0x67, 0x80, 0x1d, 0xfd, 0xe6, 0x9e, 0x41, 0xba, 0xa2, 0x95, 0xd8, 0xb8, 0x79, 0xaf, 0x8e,
+	0xb9, 0x2d, 0x91, 0xc3, 0xf8, 0x53, 0x58, 0xb9, 0xb1, 0x6c, 0xdf, 0x98, 0x9d, 0x20, 0xc5, 0x13,
+	0xfd, 0xab, 0xc6, 0x39, 0xd1, 0x77, 0x61, 0x63, 0x82, 0xdf, 0x12, 0x3d, 0xa1, 0x72, 0x8d, 0x92,
+	0x2f, 0x3d, 0xb5, 0xd5, 0x3f, 0xe5, 0x60, 0x8b, 0xee, 0x27, 0x9a, 0x13, 0x2a, 0xca, 0xfd, 0xbf,
+	0xf0, 0xc3, 0x0c, 0xe4, 0xa4, 0x5e, 0xff, 0x7b, 0x7f, 0xfc, 0x2e, 0x0f, 0x6b, 0x7c, 0xfd, 0x9d,
+	0xc9, 0xee, 0x85, 0x2d, 0x28, 0x51, 0x09, 0xba, 0x9f, 0x01, 0xab, 0x74, 0x78, 0x9a, 0x71, 0xa9,
+	0x06, 0x77, 0x65, 0x61, 0xce, 0x5d, 0x59, 0xcc, 0xbc, 0x2b, 0x57, 0x96, 0xbd, 0x2b, 0x1d, 0xaa,
+	0xab, 0x58, 0xbc, 0xba, 0x78, 0x31, 0x67, 0x67, 0x8b, 0x77, 0x41, 0x8c, 0x06, 0x34, 0x02, 0xbc,
+	0x92, 0xae, 0x08, 0x4a, 0xf3, 0x5e, 0xbd, 0xe5, 0x31, 0x08, 0xfb, 0xc3, 0x07, 0xc7, 0x5c, 0xbf,
+	0xf8, 0xa8, 0xc9, 0xcf, 0x47, 0x4d, 0x21, 0x86, 0x1a, 0xf5, 0x2d, 0x6c, 0xa7, 0xec, 0x24, 0xc2,
+	0xfd, 0x19, 0x54, 0x1c, 0x8f, 0x28, 0x42, 0xfe, 0x38, 0x82, 0x2a, 0x6f, 0x89, 0x16, 0xf0, 0x3d,
+	0x38, 0xe0, 0x2f, 0xe0, 0x7d, 0xbf, 0xc1, 0x16, 0x52, 0x16, 0x59, 0x38, 0xbf, 0x9c, 0x9a, 0x81,
+	0xdc, 0x36, 0x87, 0xc3, 0xe5, 0x1c, 0xb6, 0x0f, 0x6b, 0x43, 0xc7, 0x1e, 0xeb, 0x51, 0x99, 0x55,
+	0x4a, 0xfb, 0x9a, 0x93, 0xa8, 0xf7, 0x88, 0xad, 0x47, 0x8f, 0xd6, 0x0a, 0xb1, 0xc5, 0xb4, 0x6a,
+	0xc2, 0x76, 0xca, 0xb6, 0xc2, 0x7b, 0x08, 0x8a, 0x03, 0x73, 0x38, 0xf4, 0x9e, 0x15, 0xe8, 0x37,
+	0x95, 0xc7, 0xb6, 0x0c, 0x97, 0x74, 0x15, 0x4a, 0xe9, 0x51, 0x02, 0x4d, 0x4b, 0x62, 0xeb, 0x61,
+	0x0c, 0x97, 0x88, 0xcd, 0xa6, 0xd4, 0x37, 0x50, 0xd7, 0xf0, 0x1d, 0x76, 0x22, 0x07, 0xe6, 0x5c,
+	0xd3, 0x3e, 0x01, 0xc9, 0x8b, 0x48, 0xcc, 0xbc, 0x0d, 0x8f, 0xee, 0x99, 0x38, 0xff, 0xea, 0xf8,
+	0x05, 0xa0, 0x2b, 0x4c, 0x6f, 0x98, 0xf8, 0xe1, 0xf4, 0x66, 0x86, 0x9d, 0x7b, 0xb1, 0x23, 0x1f,
+	0xa4, 0x83, 0x4f, 0xfd, 0x35, 0x48, 0x81, 0x04, 0x0d, 0xbb, 0x33, 0x6b, 0x89, 0x6e, 0xd7, 0x9d,
+	0x98, 0xd3, 0x29, 0xf6, 0x0b, 0x5c, 0x31, 0xa4, 0xfc, 0x6e, 0xdf, 0x2b, 0x49, 0x72, 0x1a, 0x1f,
+	0xf8, 0x95, 0xda, 0x4a, 0xe8, 0x0d, 0xe7, 0x97, 0xf0, 0x28, 0xa2, 0xbf, 0x8f, 0xea, 0x92, 0xc3,
+	0x54, 0xf1, 0x30, 0xbd, 0xed, 0x63, 0x3a, 0xae, 0xac, 0xe6, 0x71, 0xaa, 0x7f, 0xce, 0x01, 0x34,
+	0x69, 0xd1, 0xd1, 0x71, 0x1c, 0xdb, 0xa1, 0xdb, 0xf5, 0xed, 0x01, 0xf6, 0x62, 0x4b, 0xbf, 0xe9,
+	0xa5, 0x39, 0x76, 0x47, 0xc2, 0x0c, 0xfa, 0x89, 0x3e, 0x85, 0xe2, 0x18, 0x13, 0x43, 0x2e, 0xb0,
+	0x6d, 0x76, 0x83, 0xd4, 0xf1, 0x05, 0x1d, 0x9e, 0x63, 0x62, 0x74, 0x26, 0xc4, 0xb9, 0xd7, 0x18,
+	0xab, 0xf2, 0x13, 0xa8, 0xf8, 0x24, 0x2a, 0xf1, 0x35, 0xf6, 0x1c, 0x4d, 0x3f, 0xa9, 0xf1, 0x77,
+	0x86, 0x35, 0xf3, 0x9d, 0xc5, 0x06, 0x3f, 0xcb, 0x7f, 0x9e, 0x53, 0xbf, 0x81, 0x2d, 0x26, 0x36,
+	0x38, 0x89, 0xfd, 0x88, 0xfd, 0x18, 0xca, 0x0e, 0xff, 0xf4, 0x2c, 0x56, 0x52, 0x0f, 0x6e, 0xc6,
+	0xa2, 0xf9, 0xbc, 0xe8, 0x23, 0x58, 0x37, 0x2c, 0x4b, 0xb7, 0x1d, 0x7d, 0x62, 0x93, 0x5b, 0x73,
+	0x32, 0x12, 0x55, 0xc7, 0x9a, 0x61, 0x59, 0x17, 0x4e, 0x97, 0xd3, 0x54, 0x17, 0x1e, 0xc7, 0x36,
+	0x16, 0x81, 0x3e, 0x82, 0x22, 0x45, 0x23, 0x53, 0x7f, 0xc1, 0x5d, 0xc1, 0x18, 0xd1, 0x27, 0xb0,
+	0x82, 0xa9, 0x53, 0xe4, 0x7c, 0xfc, 0x02, 0xf3, 0xfd, 0xa5, 0x71, 0x0e, 0xb5, 0x07, 0x72, 0xd2,
+	0x5a, 0x11, 0xdf, 0xcf, 0xe3, 0xf1, 0x7d, 0x12, 0x15, 0x14, 0x57, 0x34, 0x08, 0xf2, 0x1b, 0xd8,
+	0x64, 0x1c, 0xe2, 0x5c, 0xf2, 0x1d, 0x28, 0x41, 0xc1, 0x1c, 0x70, 0x69, 0x15, 0x8d, 0x7e, 0x3e,
+	0xe0, 0xb5, 0x31, 0xc5, 0x7b, 0x85, 0x14, 0xef, 0x8d, 0x01, 0x85, 0xb7, 0x14, 0xae, 0xfb, 0x41,
+	0xc4, 0x75, 0xb2, 0xaf, 0x7f, 0xec, 0x45, 0x72, 0x79, 0xbf, 0x75, 0x45, 0xb0, 0x02, 0x0b, 0x85,
+	0xd3, 0x7e, 0x14, 0x77, 0xda, 0x4e, 0x54, 0x4a, 0x44, 0xbf, 0xc0, 0x63, 0x1e, 0xea, 0x82, 0x96,
+	0xf9, 0x41, 0xa8, 0x4b, 0x34, 0xe8, 0x4b, 0xa3, 0xee, 0x9f, 0x39, 0x61, 0x49, 0x58, 0x54, 0x26,
+	0xec, 0x92, 0x5d, 0xfd, 0xd2, 0xee, 0x43, 0x5f, 0x44, 0x12, 0xfa, 0x20, 0xca, 0x19, 0xd7, 0xe4,
+	0xbf, 0x97, 0xdb, 0x1e, 0xda, 0x23, 0x5e, 0x7e, 0x20, 0xda, 0xe3, 0x5a, 0xf9, 0xb1, 0x7b, 0xfa,
+	0x87, 0x1c, 0x40, 0xd0, 0x0b, 0xa1, 0x1d, 0xd8, 0x6a, 0x9e, 0x5d, 0x3c, 0xd7, 0xaf, 0x7a, 0x8d,
+	0xde, 0xf5, 0x95, 0x7e, 0xdd, 0xbd, 0xba, 0xec, 0xb4, 0x4e, 0x8f, 0x4f, 0x3b, 0x6d, 0xe9, 0x3d,
+	0xf4, 0x18, 0xea, 0xe1, 0xc9, 0xb6, 0xd6, 0x38, 0xee, 0x49, 0x39, 0xb4, 0x0d, 0x8f, 0xc3, 0xe4,
+	0xab, 0xd6, 0x49, 0xa7, 0x7d, 0x7d, 0xd6, 0x69, 0x4b, 0xf9, 0xf8, 0xd4, 0xe5, 0x75, 0xf3, 0xec,
+	0xf4, 0xea, 0xa4, 0xd3, 0x96, 0x0a, 0x48, 0x86, 0xcd, 0xf0, 0x54, 0x43, 0x6b, 0x9d, 0x9c, 0x7e,
+	0xdd, 0x69, 0x4b, 0xc5, 0xa7, 0xbf, 0x85, 0x5a, 0xa4, 0x79, 0x46, 0x4f, 0x40, 0x69, 0x5d, 0x74,
+	0x7b, 0x9d, 0x6e, 0x4f, 0x3f, 0xbe, 0xd0, 0xce, 0x1b, 0xbd, 0x98, 0x5e, 0x32, 0x6c, 0xc6, 0xe6,
+	0x2f, 0xcf, 0x1a, 0xa7, 0x5d, 0x29, 0x47, 0xcd, 0x89, 0xcd, 0x9c, 0x37, 0xb4, 0x17, 0xed, 0x8b,
+	0x97, 0x5d, 0x29, 0x8f, 0xb6, 0xe0, 0x51, 0x6c, 0xf2, 0xa4, 0x77, 0x7e, 0x26, 0x15, 0x9e, 0xfe,
+	0x90, 0x35, 0x41, 0xbc, 0xbb, 0xac, 0x43, 0xad, 0xd7, 0x78, 0xae, 0x9f, 0x37, 0x7a, 0xad, 0x13,
+	0xbd, 0xd1, 0xfd, 0x95, 0xf4, 0x5e, 0x8c, 0x74, 0x76, 0x26, 0xe5, 0x9e, 0xfd, 0xad, 0x0a, 0x55,
+	0xe6, 0x45, 0xee, 0x74, 0xd4, 0x01, 0x08, 0x0e, 0x18, 0x94, 0x71, 0xd0, 0x2a, 0x59, 0x27, 0x22,
+	0xfa, 0x02, 0x4a, 0x22, 0xe5, 0xd0, 0x56, 0x32, 0xfd, 0xb9, 0x80, 0xb9, 0xe7, 0x02, 0x3a, 0x81,
+	0x5a, 0xe4, 0x4f, 0x07, 0xda, 0x8d, 0xb3, 0x46, 0xfe, 0x80, 0x64, 0x48, 0xea, 0x00, 0x04, 0x0f,
+	0xb0, 0x21, 0x73, 0x12, 0xaf, 0xd8, 0xca, 0x4e, 0xea, 0x5c, 0x20, 0x26, 0x00, 0x22, 0xca, 0x38,
+	0x08, 0x94, 0xac, 0x84, 0x45, 0x2f, 0x60, 0x2d, 0xfc, 0x68, 0x86, 0x3e, 0x08, 0xf6, 0x4c, 0xbe,
+	0xc6, 0x29, 0xbb, 0x73, 0x66, 0x85, 0xb0, 0xe7, 0x50, 0x0d, 0x35, 0xfd, 0x28, 0xd8, 0x38, 0xf9,
+	0x14, 0x90, 0x6d, 0xdc, 0x0b, 0xa8, 0x45, 0xda, 0x77, 0x14, 0xde, 0x78, 0xba, 0xa4, 0xb0, 0xaf,
+	0xa0, 0xec, 0x15, 0xe4, 0x28, 0x08, 0x4b, 0xac, 0x53, 0x56, 0xb6, 0x53, 0x66, 0x84, 0x80, 0x97,
+	0xbc, 0x8f, 0x0d, 0xf7, 0x6f, 0x68, 0x2f, 0xc2, 0x9e, 0xd2, 0x72, 0x2a, 0xfb, 0x19, 0x1c, 0x3e,
+	0xa8, 0xaa, 0xa1, 0x72, 0x2a, 0xe4, 0xaf, 0x64, 0x91, 0xa8, 0x7c, 0x90, 0x3e, 0x19, 0xb5, 0x91,
+	0x3e, 0x1b, 0xc4, 0x6c, 0x0c, 0x3d, 0x53, 0x28, 0xdb, 0x29, 0x33, 0x42, 0xc0, 0x2b, 0xa8, 0x27,
+	0xba, 0x16, 0xb4, 0x9f, 0xe2, 0x93, 0x68, 0x2b, 0xa0, 0xa8, 0x59, 0x2c, 0x42, 0xf6, 0x69, 0xe8,
+	0xc7, 0x1f, 0x9f, 0x43, 0x1f, 0x26, 0xd3, 0x23, 0xd2, 0xb1, 0x28, 0xe9, 0x5d, 0x11, 0x55, 0x33,
+	0xd1, 0x1e, 0x84, 0xd4, 0x9c, 0xd7, 0xb1, 0x28, 0x6a, 0x16, 0x4b, 0x90, 0x51, 0x41, 0x3f, 0x10,
+	0xca, 0xa8, 0x44, 0x93, 0x90, 0x0d, 0xb7, 0x97, 0x20, 0xc5, 0x0b, 0xa9, 0x10, 0x5a, 0xe6, 0x54,
+	0x94, 0xca, 0x7e, 0x06, 0x87, 0x10, 0xdc, 0x85, 0x5a, 0xa4, 0xd2, 0x40, 0xbb, 0xa9, 0x05, 0x85,
+	0x2f, 0xf2, 0xc9, 0xbc, 0xe9, 0x98, 0xa2, 0xa1, 0x3b, 0x30, 0xae, 0x68, 0xb2, 0x08, 0x51, 0xf6,
+	0x33, 0x38, 0xb8, 0xe0, 0x26, 0xbc, 0x2a, 0x3b, 0xd3, 0xfe, 0x11, 0x2d, 0x05, 0x6e, 0x56, 0x59,
+	0xdb, 0xfe, 0xd9, 0xbf, 0x07, 0x00, 0xc2, 0x91, 0x9f, 0x03, 0xe6, 0x21, 0x00, 0x00,
 }
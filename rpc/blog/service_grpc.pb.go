@@ -20,9 +20,32 @@ const _ = grpc.SupportPackageIsVersion7
 type BlogServiceClient interface {
 	CreateBlog(ctx context.Context, in *CreateBlogRequest, opts ...grpc.CallOption) (*CreateBlogResponse, error)
 	GetBlog(ctx context.Context, in *GetBlogRequest, opts ...grpc.CallOption) (*GetBlogResponse, error)
+	// returns the blog by its current slug or one of its previous slugs, in which case the slug of the
+	// response differs from the request and callers should redirect to it
+	GetBlogBySlug(ctx context.Context, in *GetBlogBySlugRequest, opts ...grpc.CallOption) (*GetBlogResponse, error)
 	UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	// moves the blog to the trash, where it is hidden from GetBlog, ListBlog and SearchBlogs
+	// until it is restored with UndeleteBlog or purged after the configured retention
 	DeleteBlog(ctx context.Context, in *DeleteBlogRequest, opts ...grpc.CallOption) (*DeleteBlogResponse, error)
+	UndeleteBlog(ctx context.Context, in *UndeleteBlogRequest, opts ...grpc.CallOption) (*UndeleteBlogResponse, error)
+	// publishes or schedules a blog, blogs are created as drafts unless CreateBlog asks otherwise
+	PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	// turns a published or scheduled blog back into a draft, or archives it
+	UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
 	ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (*ListBlogResponse, error)
+	ListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest, opts ...grpc.CallOption) (*ListDeletedBlogsResponse, error)
+	SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (*SearchBlogsResponse, error)
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (*ListBlogRevisionsResponse, error)
+	GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*BlogRevision, error)
+	DiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest, opts ...grpc.CallOption) (*DiffBlogRevisionsResponse, error)
+	// restores the title and content of a revision with an update, so the reverted state becomes a revision too
+	RevertBlog(ctx context.Context, in *RevertBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error)
+	// create, get or delete up to 100 blogs in one call, reporting the outcome of each item separately
+	// unless all_or_nothing is set
+	BatchCreateBlogs(ctx context.Context, in *BatchCreateBlogsRequest, opts ...grpc.CallOption) (*BatchCreateBlogsResponse, error)
+	BatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest, opts ...grpc.CallOption) (*BatchGetBlogsResponse, error)
+	BatchDeleteBlogs(ctx context.Context, in *BatchDeleteBlogsRequest, opts ...grpc.CallOption) (*BatchDeleteBlogsResponse, error)
 }
 
 type blogServiceClient struct {
@@ -51,6 +74,15 @@ func (c *blogServiceClient) GetBlog(ctx context.Context, in *GetBlogRequest, opt
 	return out, nil
 }
 
+func (c *blogServiceClient) GetBlogBySlug(ctx context.Context, in *GetBlogBySlugRequest, opts ...grpc.CallOption) (*GetBlogResponse, error) {
+	out := new(GetBlogResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/GetBlogBySlug", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blogServiceClient) UpdateBlog(ctx context.Context, in *UpdateBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
 	out := new(UpdateBlogResponse)
 	err := c.cc.Invoke(ctx, "/service.BlogService/UpdateBlog", in, out, opts...)
@@ -69,6 +101,33 @@ func (c *blogServiceClient) DeleteBlog(ctx context.Context, in *DeleteBlogReques
 	return out, nil
 }
 
+func (c *blogServiceClient) UndeleteBlog(ctx context.Context, in *UndeleteBlogRequest, opts ...grpc.CallOption) (*UndeleteBlogResponse, error) {
+	out := new(UndeleteBlogResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/UndeleteBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) PublishBlog(ctx context.Context, in *PublishBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/PublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) UnpublishBlog(ctx context.Context, in *UnpublishBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/UnpublishBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, opts ...grpc.CallOption) (*ListBlogResponse, error) {
 	out := new(ListBlogResponse)
 	err := c.cc.Invoke(ctx, "/service.BlogService/ListBlog", in, out, opts...)
@@ -78,15 +137,128 @@ func (c *blogServiceClient) ListBlog(ctx context.Context, in *ListBlogRequest, o
 	return out, nil
 }
 
+func (c *blogServiceClient) ListDeletedBlogs(ctx context.Context, in *ListDeletedBlogsRequest, opts ...grpc.CallOption) (*ListDeletedBlogsResponse, error) {
+	out := new(ListDeletedBlogsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/ListDeletedBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) SearchBlogs(ctx context.Context, in *SearchBlogsRequest, opts ...grpc.CallOption) (*SearchBlogsResponse, error) {
+	out := new(SearchBlogsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/SearchBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/ListTags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) ListBlogRevisions(ctx context.Context, in *ListBlogRevisionsRequest, opts ...grpc.CallOption) (*ListBlogRevisionsResponse, error) {
+	out := new(ListBlogRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/ListBlogRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) GetBlogRevision(ctx context.Context, in *GetBlogRevisionRequest, opts ...grpc.CallOption) (*BlogRevision, error) {
+	out := new(BlogRevision)
+	err := c.cc.Invoke(ctx, "/service.BlogService/GetBlogRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) DiffBlogRevisions(ctx context.Context, in *DiffBlogRevisionsRequest, opts ...grpc.CallOption) (*DiffBlogRevisionsResponse, error) {
+	out := new(DiffBlogRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/DiffBlogRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) RevertBlog(ctx context.Context, in *RevertBlogRequest, opts ...grpc.CallOption) (*UpdateBlogResponse, error) {
+	out := new(UpdateBlogResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/RevertBlog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) BatchCreateBlogs(ctx context.Context, in *BatchCreateBlogsRequest, opts ...grpc.CallOption) (*BatchCreateBlogsResponse, error) {
+	out := new(BatchCreateBlogsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/BatchCreateBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) BatchGetBlogs(ctx context.Context, in *BatchGetBlogsRequest, opts ...grpc.CallOption) (*BatchGetBlogsResponse, error) {
+	out := new(BatchGetBlogsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/BatchGetBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blogServiceClient) BatchDeleteBlogs(ctx context.Context, in *BatchDeleteBlogsRequest, opts ...grpc.CallOption) (*BatchDeleteBlogsResponse, error) {
+	out := new(BatchDeleteBlogsResponse)
+	err := c.cc.Invoke(ctx, "/service.BlogService/BatchDeleteBlogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BlogServiceServer is the server API for BlogService service.
 // All implementations must embed UnimplementedBlogServiceServer
 // for forward compatibility
 type BlogServiceServer interface {
 	CreateBlog(context.Context, *CreateBlogRequest) (*CreateBlogResponse, error)
 	GetBlog(context.Context, *GetBlogRequest) (*GetBlogResponse, error)
+	// returns the blog by its current slug or one of its previous slugs, in which case the slug of the
+	// response differs from the request and callers should redirect to it
+	GetBlogBySlug(context.Context, *GetBlogBySlugRequest) (*GetBlogResponse, error)
 	UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error)
+	// moves the blog to the trash, where it is hidden from GetBlog, ListBlog and SearchBlogs
+	// until it is restored with UndeleteBlog or purged after the configured retention
 	DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error)
+	UndeleteBlog(context.Context, *UndeleteBlogRequest) (*UndeleteBlogResponse, error)
+	// publishes or schedules a blog, blogs are created as drafts unless CreateBlog asks otherwise
+	PublishBlog(context.Context, *PublishBlogRequest) (*UpdateBlogResponse, error)
+	// turns a published or scheduled blog back into a draft, or archives it
+	UnpublishBlog(context.Context, *UnpublishBlogRequest) (*UpdateBlogResponse, error)
 	ListBlog(context.Context, *ListBlogRequest) (*ListBlogResponse, error)
+	ListDeletedBlogs(context.Context, *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error)
+	SearchBlogs(context.Context, *SearchBlogsRequest) (*SearchBlogsResponse, error)
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	ListBlogRevisions(context.Context, *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error)
+	GetBlogRevision(context.Context, *GetBlogRevisionRequest) (*BlogRevision, error)
+	DiffBlogRevisions(context.Context, *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error)
+	// restores the title and content of a revision with an update, so the reverted state becomes a revision too
+	RevertBlog(context.Context, *RevertBlogRequest) (*UpdateBlogResponse, error)
+	// create, get or delete up to 100 blogs in one call, reporting the outcome of each item separately
+	// unless all_or_nothing is set
+	BatchCreateBlogs(context.Context, *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error)
+	BatchGetBlogs(context.Context, *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error)
+	BatchDeleteBlogs(context.Context, *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error)
 	mustEmbedUnimplementedBlogServiceServer()
 }
 
@@ -100,15 +272,57 @@ func (UnimplementedBlogServiceServer) CreateBlog(context.Context, *CreateBlogReq
 func (UnimplementedBlogServiceServer) GetBlog(context.Context, *GetBlogRequest) (*GetBlogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlog not implemented")
 }
+func (UnimplementedBlogServiceServer) GetBlogBySlug(context.Context, *GetBlogBySlugRequest) (*GetBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlogBySlug not implemented")
+}
 func (UnimplementedBlogServiceServer) UpdateBlog(context.Context, *UpdateBlogRequest) (*UpdateBlogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBlog not implemented")
 }
 func (UnimplementedBlogServiceServer) DeleteBlog(context.Context, *DeleteBlogRequest) (*DeleteBlogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBlog not implemented")
 }
+func (UnimplementedBlogServiceServer) UndeleteBlog(context.Context, *UndeleteBlogRequest) (*UndeleteBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteBlog not implemented")
+}
+func (UnimplementedBlogServiceServer) PublishBlog(context.Context, *PublishBlogRequest) (*UpdateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishBlog not implemented")
+}
+func (UnimplementedBlogServiceServer) UnpublishBlog(context.Context, *UnpublishBlogRequest) (*UpdateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnpublishBlog not implemented")
+}
 func (UnimplementedBlogServiceServer) ListBlog(context.Context, *ListBlogRequest) (*ListBlogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBlog not implemented")
 }
+func (UnimplementedBlogServiceServer) ListDeletedBlogs(context.Context, *ListDeletedBlogsRequest) (*ListDeletedBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedBlogs not implemented")
+}
+func (UnimplementedBlogServiceServer) SearchBlogs(context.Context, *SearchBlogsRequest) (*SearchBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchBlogs not implemented")
+}
+func (UnimplementedBlogServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedBlogServiceServer) ListBlogRevisions(context.Context, *ListBlogRevisionsRequest) (*ListBlogRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlogRevisions not implemented")
+}
+func (UnimplementedBlogServiceServer) GetBlogRevision(context.Context, *GetBlogRevisionRequest) (*BlogRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlogRevision not implemented")
+}
+func (UnimplementedBlogServiceServer) DiffBlogRevisions(context.Context, *DiffBlogRevisionsRequest) (*DiffBlogRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffBlogRevisions not implemented")
+}
+func (UnimplementedBlogServiceServer) RevertBlog(context.Context, *RevertBlogRequest) (*UpdateBlogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertBlog not implemented")
+}
+func (UnimplementedBlogServiceServer) BatchCreateBlogs(context.Context, *BatchCreateBlogsRequest) (*BatchCreateBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateBlogs not implemented")
+}
+func (UnimplementedBlogServiceServer) BatchGetBlogs(context.Context, *BatchGetBlogsRequest) (*BatchGetBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetBlogs not implemented")
+}
+func (UnimplementedBlogServiceServer) BatchDeleteBlogs(context.Context, *BatchDeleteBlogsRequest) (*BatchDeleteBlogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteBlogs not implemented")
+}
 func (UnimplementedBlogServiceServer) mustEmbedUnimplementedBlogServiceServer() {}
 
 // UnsafeBlogServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -158,6 +372,24 @@ func _BlogService_GetBlog_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_GetBlogBySlug_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogBySlugRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetBlogBySlug(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/GetBlogBySlug",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetBlogBySlug(ctx, req.(*GetBlogBySlugRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlogService_UpdateBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateBlogRequest)
 	if err := dec(in); err != nil {
@@ -194,6 +426,60 @@ func _BlogService_DeleteBlog_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_UndeleteBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UndeleteBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/UndeleteBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UndeleteBlog(ctx, req.(*UndeleteBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_PublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).PublishBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/PublishBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).PublishBlog(ctx, req.(*PublishBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_UnpublishBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/UnpublishBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).UnpublishBlog(ctx, req.(*UnpublishBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlogService_ListBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListBlogRequest)
 	if err := dec(in); err != nil {
@@ -212,6 +498,186 @@ func _BlogService_ListBlog_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlogService_ListDeletedBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListDeletedBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/ListDeletedBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListDeletedBlogs(ctx, req.(*ListDeletedBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_SearchBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).SearchBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/SearchBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).SearchBlogs(ctx, req.(*SearchBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/ListTags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_ListBlogRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlogRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).ListBlogRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/ListBlogRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).ListBlogRevisions(ctx, req.(*ListBlogRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_GetBlogRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlogRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/GetBlogRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).GetBlogRevision(ctx, req.(*GetBlogRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_DiffBlogRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffBlogRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).DiffBlogRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/DiffBlogRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).DiffBlogRevisions(ctx, req.(*DiffBlogRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_RevertBlog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertBlogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).RevertBlog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/RevertBlog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).RevertBlog(ctx, req.(*RevertBlogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_BatchCreateBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).BatchCreateBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/BatchCreateBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).BatchCreateBlogs(ctx, req.(*BatchCreateBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_BatchGetBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).BatchGetBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/BatchGetBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).BatchGetBlogs(ctx, req.(*BatchGetBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlogService_BatchDeleteBlogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteBlogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlogServiceServer).BatchDeleteBlogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/service.BlogService/BatchDeleteBlogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlogServiceServer).BatchDeleteBlogs(ctx, req.(*BatchDeleteBlogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BlogService_ServiceDesc is the grpc.ServiceDesc for BlogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +693,10 @@ var BlogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlog",
 			Handler:    _BlogService_GetBlog_Handler,
 		},
+		{
+			MethodName: "GetBlogBySlug",
+			Handler:    _BlogService_GetBlogBySlug_Handler,
+		},
 		{
 			MethodName: "UpdateBlog",
 			Handler:    _BlogService_UpdateBlog_Handler,
@@ -235,10 +705,62 @@ var BlogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBlog",
 			Handler:    _BlogService_DeleteBlog_Handler,
 		},
+		{
+			MethodName: "UndeleteBlog",
+			Handler:    _BlogService_UndeleteBlog_Handler,
+		},
+		{
+			MethodName: "PublishBlog",
+			Handler:    _BlogService_PublishBlog_Handler,
+		},
+		{
+			MethodName: "UnpublishBlog",
+			Handler:    _BlogService_UnpublishBlog_Handler,
+		},
 		{
 			MethodName: "ListBlog",
 			Handler:    _BlogService_ListBlog_Handler,
 		},
+		{
+			MethodName: "ListDeletedBlogs",
+			Handler:    _BlogService_ListDeletedBlogs_Handler,
+		},
+		{
+			MethodName: "SearchBlogs",
+			Handler:    _BlogService_SearchBlogs_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _BlogService_ListTags_Handler,
+		},
+		{
+			MethodName: "ListBlogRevisions",
+			Handler:    _BlogService_ListBlogRevisions_Handler,
+		},
+		{
+			MethodName: "GetBlogRevision",
+			Handler:    _BlogService_GetBlogRevision_Handler,
+		},
+		{
+			MethodName: "DiffBlogRevisions",
+			Handler:    _BlogService_DiffBlogRevisions_Handler,
+		},
+		{
+			MethodName: "RevertBlog",
+			Handler:    _BlogService_RevertBlog_Handler,
+		},
+		{
+			MethodName: "BatchCreateBlogs",
+			Handler:    _BlogService_BatchCreateBlogs_Handler,
+		},
+		{
+			MethodName: "BatchGetBlogs",
+			Handler:    _BlogService_BatchGetBlogs_Handler,
+		},
+		{
+			MethodName: "BatchDeleteBlogs",
+			Handler:    _BlogService_BatchDeleteBlogs_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/service.proto",
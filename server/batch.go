@@ -0,0 +1,141 @@
+package server
+
+import (
+	"blog-service/auth"
+	config "blog-service/config"
+	"blog-service/db"
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/twitchtv/twirp"
+)
+
+// the batch methods apply the checks of their single-item methods to each item, and report the items that
+// fail them in their results, or with the error of the first when all_or_nothing is set
+
+func (*Server) BatchCreateBlogs(ctx context.Context, req *blogProto.BatchCreateBlogsRequest) (*blogProto.BatchCreateBlogsResponse, error) {
+	data := &blogProto.BatchCreateBlogsRequest{AllOrNothing: req.GetAllOrNothing()}
+	for _, request := range req.GetRequests() {
+		data.Requests = append(data.Requests, &blogProto.CreateBlogRequest{
+			Title:         request.GetTitle(),
+			Content:       request.GetContent(),
+			Tags:          request.GetTags(),
+			Category:      request.GetCategory(),
+			Status:        request.GetStatus(),
+			ContentFormat: request.GetContentFormat(),
+		})
+	}
+
+	return config.DB.BatchCreateBlogs(ctx, data)
+}
+
+func (*Server) BatchGetBlogs(ctx context.Context, req *blogProto.BatchGetBlogsRequest) (*blogProto.BatchGetBlogsResponse, error) {
+	data := &blogProto.BatchGetBlogsRequest{
+		Ids:          req.GetIds(),
+		ShowDeleted:  req.GetShowDeleted(),
+		AllOrNothing: req.GetAllOrNothing(),
+	}
+
+	res, err := config.DB.BatchGetBlogs(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range res.Results {
+		blog := result.Blog
+		if blog == nil {
+			continue
+		}
+		if !isPublic(blog) && canChange(ctx, blog.AuthorId) != nil {
+			// as for GetBlog, blogs the caller could not change are only visible once published
+			notFound := twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", data.Ids[i]))
+			if data.AllOrNothing {
+				return nil, db.BatchFailure(i, notFound)
+			}
+			res.Results[i] = &blogProto.BatchGetBlogResult{Error: db.BatchError(notFound)}
+			continue
+		}
+		blog.RenderedHtml = renderedHtml(blog.Id, blog.Version, blog.ContentFormat, blog.Content)
+	}
+	return res, nil
+}
+
+func (*Server) BatchDeleteBlogs(ctx context.Context, req *blogProto.BatchDeleteBlogsRequest) (*blogProto.BatchDeleteBlogsResponse, error) {
+	requests := []*blogProto.DeleteBlogRequest{}
+	for _, request := range req.GetRequests() {
+		requests = append(requests, &blogProto.DeleteBlogRequest{
+			Id:      request.GetId(),
+			Version: request.GetVersion(),
+		})
+	}
+	if err := db.CheckBatchSize(len(requests), "requests"); err != nil {
+		return nil, err
+	}
+	denied, err := batchOwners(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*blogProto.BatchDeleteBlogResult, len(requests))
+	// the requests that passed the ownership checks, and the position in the batch of each
+	data := &blogProto.BatchDeleteBlogsRequest{AllOrNothing: req.GetAllOrNothing()}
+	positions := []int{}
+	for i, err := range denied {
+		if err == nil {
+			data.Requests = append(data.Requests, requests[i])
+			positions = append(positions, i)
+			continue
+		}
+		if data.AllOrNothing {
+			return nil, db.BatchFailure(i, err)
+		}
+		results[i] = &blogProto.BatchDeleteBlogResult{Error: db.BatchError(err)}
+	}
+	if len(data.Requests) == 0 {
+		return &blogProto.BatchDeleteBlogsResponse{Results: results}, nil
+	}
+
+	// with all_or_nothing nothing was denied, so positions in the error of a failed batch are those of the request
+	res, err := config.DB.BatchDeleteBlogs(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	for j, result := range res.Results {
+		results[positions[j]] = result
+		if result.Blog == nil {
+			continue
+		}
+		// the comments go to the trash with the blog, as for DeleteBlog, but the blog is deleted whether or
+		// not they do, so the item still succeeds
+		if err := config.Comments.OrphanComments(ctx, result.Blog.Id, true); err != nil {
+			log.Printf("Blog %v was deleted but its comments could not be hidden: %v", result.Blog.Id, err)
+			result.Meta = map[string]string{"comments_error": fmt.Sprintf("the comments could not be hidden: %v", err)}
+		}
+	}
+	return &blogProto.BatchDeleteBlogsResponse{Results: results}, nil
+}
+
+// batchOwners applies checkOwner to the blog of each of requests, reading them all at once
+func batchOwners(ctx context.Context, requests []*blogProto.DeleteBlogRequest) ([]error, error) {
+	denied := make([]error, len(requests))
+	if _, ok := auth.FromContext(ctx); !ok && !auth.Enabled(ctx) {
+		return denied, nil
+	}
+
+	ids := make([]string, len(requests))
+	for i, request := range requests {
+		ids[i] = request.Id
+	}
+	res, err := config.DB.BatchGetBlogs(ctx, &blogProto.BatchGetBlogsRequest{Ids: ids, ShowDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range res.Results {
+		// missing blogs are left to the delete to report, as checkOwner does
+		if result.Blog != nil {
+			denied[i] = canChange(ctx, result.Blog.AuthorId)
+		}
+	}
+	return denied, nil
+}
@@ -0,0 +1,116 @@
+package server_test
+
+import (
+	"blog-service/auth"
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+)
+
+func TestServer_Batch(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("batch tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	scopes := []string{"blog:write", "blog:delete"}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "batch-alice", Scopes: scopes})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "batch-bob", Scopes: scopes})
+
+	created, err := s.BatchCreateBlogs(alice, &blogProto.BatchCreateBlogsRequest{Requests: []*blogProto.CreateBlogRequest{
+		{Title: "Batch published", Content: "*hi*", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED, ContentFormat: blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN},
+		{Title: "Batch draft", Content: "draft"},
+	}})
+	require.NoError(t, err)
+	published, draft := created.Results[0].Blog, created.Results[1].Blog
+	require.Equal(t, "batch-alice", published.AuthorId)
+
+	// drafts are hidden from other authors, item by item or for the whole batch
+	ids := []string{published.Id, draft.Id}
+	got, err := s.BatchGetBlogs(bob, &blogProto.BatchGetBlogsRequest{Ids: ids})
+	require.NoError(t, err)
+	require.Equal(t, "<p><em>hi</em></p>", got.Results[0].Blog.RenderedHtml)
+	require.Nil(t, got.Results[1].Blog)
+	require.Equal(t, string(twirp.NotFound), got.Results[1].Error.Code)
+	_, err = s.BatchGetBlogs(bob, &blogProto.BatchGetBlogsRequest{Ids: ids, AllOrNothing: true})
+	requireTwirpCode(t, err, twirp.NotFound)
+	got, err = s.BatchGetBlogs(alice, &blogProto.BatchGetBlogsRequest{Ids: ids, AllOrNothing: true})
+	require.NoError(t, err)
+	require.Equal(t, "draft", got.Results[1].Blog.Content)
+
+	// only the author or an admin can delete each blog
+	_, err = s.BatchDeleteBlogs(bob, &blogProto.BatchDeleteBlogsRequest{
+		Requests:     []*blogProto.DeleteBlogRequest{{Id: published.Id}},
+		AllOrNothing: true,
+	})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	own, err := s.CreateBlog(bob, &blogProto.CreateBlogRequest{Title: "Bob's", Content: "post"})
+	require.NoError(t, err)
+	deleted, err := s.BatchDeleteBlogs(bob, &blogProto.BatchDeleteBlogsRequest{Requests: []*blogProto.DeleteBlogRequest{
+		{Id: published.Id}, {Id: own.Id}, {Id: "999999"},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, string(twirp.PermissionDenied), deleted.Results[0].Error.Code)
+	require.Equal(t, own.Id, deleted.Results[1].Blog.Id)
+	require.Equal(t, string(twirp.InvalidArgument), deleted.Results[2].Error.Code)
+
+	deleted, err = s.BatchDeleteBlogs(alice, &blogProto.BatchDeleteBlogsRequest{
+		Requests:     []*blogProto.DeleteBlogRequest{{Id: published.Id}, {Id: draft.Id}},
+		AllOrNothing: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, deleted.Results, 2)
+	_, err = s.GetBlog(alice, &blogProto.GetBlogRequest{Id: published.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	// a blog deleted without hiding its comments is still reported as deleted
+	comments := config.Comments
+	defer func() { config.Comments = comments }()
+	config.Comments = failingOrphans{comments}
+	other, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Batch comments", Content: "c"})
+	require.NoError(t, err)
+	deleted, err = s.BatchDeleteBlogs(alice, &blogProto.BatchDeleteBlogsRequest{Requests: []*blogProto.DeleteBlogRequest{{Id: other.Id}}})
+	require.NoError(t, err)
+	require.Nil(t, deleted.Results[0].Error)
+	require.Equal(t, other.Id, deleted.Results[0].Blog.Id)
+	require.Contains(t, deleted.Results[0].Meta["comments_error"], "unavailable")
+}
+
+func TestServer_DeleteWhenCommentsFail(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("comment store failures need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	ctx := auth.NewContext(context.Background(), &auth.Claims{Subject: "orphan-alice", Scopes: []string{"blog:write", "blog:delete"}})
+	blog, err := s.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Comments fail", Content: "c"})
+	require.NoError(t, err)
+
+	comments := config.Comments
+	defer func() { config.Comments = comments }()
+	config.Comments = failingOrphans{comments}
+
+	// the blog is written whether or not its comments follow, and the call reports that it was
+	_, err = s.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: blog.Id})
+	require.NoError(t, err)
+	_, err = s.GetBlog(ctx, &blogProto.GetBlogRequest{Id: blog.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	_, err = s.UndeleteBlog(ctx, &blogProto.UndeleteBlogRequest{Id: blog.Id})
+	require.NoError(t, err)
+	_, err = s.GetBlog(ctx, &blogProto.GetBlogRequest{Id: blog.Id})
+	require.NoError(t, err)
+}
+
+// failingOrphans is a comment store that cannot orphan comments
+type failingOrphans struct {
+	config.CommentStore
+}
+
+func (failingOrphans) OrphanComments(ctx context.Context, blogId string, orphaned bool) error {
+	return errors.New("comment store unavailable")
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"blog-service/auth"
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+
+	"github.com/twitchtv/twirp"
+)
+
+// CommentServer implements the CommentService interface generated in comment.twirp.go on top of config.Comments
+type CommentServer struct{}
+
+// canChangeComment allows changes to a comment by authorId only by that author, the author of its blog
+// (moderatorId, empty when moderation does not apply) or an admin
+func canChangeComment(ctx context.Context, authorId string, moderatorId string) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		if auth.Enabled(ctx) {
+			return twirp.NewError(twirp.Unauthenticated, "A bearer token is required")
+		}
+		return nil
+	}
+	if claims.HasScope(AdminScope) {
+		return nil
+	}
+
+	if authorId != "" && authorId == claims.Subject {
+		return nil
+	}
+	if moderatorId != "" && moderatorId == claims.Subject {
+		return nil
+	}
+	return twirp.NewError(twirp.PermissionDenied, "Only the author of the comment or an admin can change it")
+}
+
+// checkBlogVisible returns NotFound unless blog id exists outside the trash and the caller can read it,
+// comments are only read and written on blogs GetBlog would return
+func checkBlogVisible(ctx context.Context, id string) error {
+	blog, err := config.DB.GetBlog(ctx, &blogProto.GetBlogRequest{Id: id})
+	if err == nil && !isPublic(blog) && canChange(ctx, blog.AuthorId) != nil {
+		return twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", id))
+	}
+	return err
+}
+
+func (*CommentServer) CreateComment(ctx context.Context, req *blogProto.CreateCommentRequest) (*blogProto.Comment, error) {
+	if req.GetBlogId() == "" {
+		return nil, twirp.RequiredArgumentError("blog_id")
+	}
+	if err := checkBlogVisible(ctx, req.GetBlogId()); err != nil {
+		return nil, err
+	}
+
+	data := &blogProto.CreateCommentRequest{
+		BlogId:   req.GetBlogId(),
+		ParentId: req.GetParentId(),
+		Content:  req.GetContent(),
+	}
+
+	res, err := config.Comments.CreateComment(ctx, data)
+	return res, err
+}
+
+func (*CommentServer) GetComment(ctx context.Context, req *blogProto.GetCommentRequest) (*blogProto.Comment, error) {
+	data := &blogProto.GetCommentRequest{
+		Id: req.GetId(),
+	}
+
+	res, err := config.Comments.GetComment(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	// a comment on a blog the caller cannot see, e.g. one unpublished since, does not exist either
+	if err := checkBlogVisible(ctx, res.BlogId); err != nil {
+		if twerr, ok := err.(twirp.Error); ok && twerr.Code() == twirp.NotFound {
+			return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No comment was found for id: %v", data.Id))
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+func (*CommentServer) ListComments(ctx context.Context, req *blogProto.ListCommentsRequest) (*blogProto.ListCommentsResponse, error) {
+	if req.GetBlogId() == "" {
+		return nil, twirp.RequiredArgumentError("blog_id")
+	}
+	if err := checkBlogVisible(ctx, req.GetBlogId()); err != nil {
+		return nil, err
+	}
+
+	limit := int64(25)
+
+	if req.GetLimit() > 0 {
+		limit = req.GetLimit()
+	}
+
+	data := &blogProto.ListCommentsRequest{
+		BlogId:    req.GetBlogId(),
+		ParentId:  req.GetParentId(),
+		Limit:     limit,
+		PageToken: req.GetPageToken(),
+	}
+
+	res, err := config.Comments.ListComments(ctx, data)
+	return res, err
+}
+
+func (*CommentServer) UpdateComment(ctx context.Context, req *blogProto.UpdateCommentRequest) (*blogProto.Comment, error) {
+	comment, err := config.Comments.GetComment(ctx, &blogProto.GetCommentRequest{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	// only the author can put words in their own comment, the blog's author can only delete it
+	if err := canChangeComment(ctx, comment.AuthorId, ""); err != nil {
+		return nil, err
+	}
+
+	data := &blogProto.UpdateCommentRequest{
+		Id:      req.GetId(),
+		Content: req.GetContent(),
+	}
+
+	res, err := config.Comments.UpdateComment(ctx, data)
+	return res, err
+}
+
+func (*CommentServer) DeleteComment(ctx context.Context, req *blogProto.DeleteCommentRequest) (*blogProto.DeleteCommentResponse, error) {
+	comment, err := config.Comments.GetComment(ctx, &blogProto.GetCommentRequest{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	blog, err := config.DB.GetBlog(ctx, &blogProto.GetBlogRequest{Id: comment.BlogId})
+	if err != nil {
+		return nil, err
+	}
+	if err := canChangeComment(ctx, comment.AuthorId, blog.AuthorId); err != nil {
+		return nil, err
+	}
+
+	data := &blogProto.DeleteCommentRequest{
+		Id: req.GetId(),
+	}
+
+	res, err := config.Comments.DeleteComment(ctx, data)
+	return res, err
+}
@@ -0,0 +1,70 @@
+package server_test
+
+import (
+	"blog-service/auth"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+)
+
+func TestCommentServer(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("comment tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	comments := &server.CommentServer{}
+	scopes := []string{"blog:write", "blog:delete", "comment:write"}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "comment-alice", Scopes: scopes})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "comment-bob", Scopes: scopes})
+	carol := auth.NewContext(context.Background(), &auth.Claims{Subject: "comment-carol", Scopes: scopes})
+
+	blog, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Commented", Content: "post", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+
+	comment, err := comments.CreateComment(bob, &blogProto.CreateCommentRequest{BlogId: blog.Id, Content: "nice"})
+	require.NoError(t, err)
+	_, err = comments.CreateComment(bob, &blogProto.CreateCommentRequest{BlogId: "999999", Content: "nowhere"})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	// only the comment's author can edit it, the blog's author can also delete it
+	_, err = comments.UpdateComment(alice, &blogProto.UpdateCommentRequest{Id: comment.Id, Content: "edited by alice"})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = comments.UpdateComment(bob, &blogProto.UpdateCommentRequest{Id: comment.Id, Content: "very nice"})
+	require.NoError(t, err)
+	_, err = comments.DeleteComment(carol, &blogProto.DeleteCommentRequest{Id: comment.Id})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = comments.DeleteComment(alice, &blogProto.DeleteCommentRequest{Id: comment.Id})
+	require.NoError(t, err)
+
+	// comments go to the trash and come back with their blog
+	kept, err := comments.CreateComment(carol, &blogProto.CreateCommentRequest{BlogId: blog.Id, Content: "kept"})
+	require.NoError(t, err)
+	_, err = s.DeleteBlog(alice, &blogProto.DeleteBlogRequest{Id: blog.Id})
+	require.NoError(t, err)
+	_, err = comments.GetComment(carol, &blogProto.GetCommentRequest{Id: kept.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	_, err = comments.ListComments(carol, &blogProto.ListCommentsRequest{BlogId: blog.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	_, err = s.UndeleteBlog(alice, &blogProto.UndeleteBlogRequest{Id: blog.Id})
+	require.NoError(t, err)
+	list, err := comments.ListComments(carol, &blogProto.ListCommentsRequest{BlogId: blog.Id})
+	require.NoError(t, err)
+	require.Len(t, list.Comments, 2)
+	require.Empty(t, list.Comments[0].Content)
+	require.Equal(t, "kept", list.Comments[1].Content)
+
+	// once the blog is unpublished its comments are only visible to those who can see the draft
+	_, err = s.UnpublishBlog(alice, &blogProto.UnpublishBlogRequest{Id: blog.Id})
+	require.NoError(t, err)
+	_, err = comments.GetComment(carol, &blogProto.GetCommentRequest{Id: kept.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	got, err := comments.GetComment(alice, &blogProto.GetCommentRequest{Id: kept.Id})
+	require.NoError(t, err)
+	require.Equal(t, "kept", got.Content)
+}
@@ -0,0 +1,259 @@
+package server
+
+import (
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+/*
+The feeds are plain HTTP rather than Twirp, for feed readers. They hold the most recently published blogs,
+newest first, read straight from config.DB like the background jobs do, so no Policy applies.
+Entries are identified by tag URIs (RFC 4151) minted from the site's host, the blog's creation date and its id,
+which stay the same when the slug, and so the link, changes.
+*/
+
+// FeedHandler serves the RSS 2.0 feed at /feed.rss and the Atom feed at /feed.atom
+type FeedHandler struct {
+	Site config.SiteSettings
+	Feed config.FeedSettings
+}
+
+func (h FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var marshal func(blogs []*blogProto.CreateBlogResponse, updated time.Time) interface{}
+	var contentType string
+	switch r.URL.Path {
+	case "/feed.rss":
+		marshal, contentType = h.rss, "application/rss+xml; charset=utf-8"
+	case "/feed.atom":
+		marshal, contentType = h.atom, "application/atom+xml; charset=utf-8"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	blogs, err := h.recentBlogs(r.Context())
+	if err != nil {
+		log.Printf("Unable to list blogs for %v: %v", r.URL.Path, err)
+		http.Error(w, "unable to list blogs", http.StatusInternalServerError)
+		return
+	}
+	updated := lastModified(blogs)
+	body, err := xml.MarshalIndent(marshal(blogs, updated), "", "  ")
+	if err != nil {
+		log.Printf("Unable to encode %v: %v", r.URL.Path, err)
+		http.Error(w, "unable to encode feed", http.StatusInternalServerError)
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+
+	// unpublishing a blog changes neither update times nor publish times, so the ETag, which ServeContent
+	// checks before If-Modified-Since, is what catches every change
+	sum := sha256.Sum256(body)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Content-Type", contentType)
+	http.ServeContent(w, r, "", updated, bytes.NewReader(body))
+}
+
+func (h FeedHandler) recentBlogs(ctx context.Context) ([]*blogProto.CreateBlogResponse, error) {
+	res, err := config.DB.ListBlog(ctx, &blogProto.ListBlogRequest{
+		Limit:   int64(h.Feed.Size),
+		OrderBy: "publish_time desc",
+		Filter:  &blogProto.BlogFilter{Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Blogs, nil
+}
+
+// publishedAt is the publish_time of blog, or its create_time for blogs published before publish times were kept
+func publishedAt(blog *blogProto.CreateBlogResponse) time.Time {
+	if blog.PublishTime == nil {
+		return blog.CreateTime.AsTime()
+	}
+	return blog.PublishTime.AsTime()
+}
+
+// changedAt is when blog was last updated or, since publishing is not an update, published
+func changedAt(blog *blogProto.CreateBlogResponse) time.Time {
+	if published := publishedAt(blog); published.After(blog.UpdateTime.AsTime()) {
+		return published
+	}
+	return blog.UpdateTime.AsTime()
+}
+
+// lastModified is the latest change of blogs, or the zero time when there are none
+func lastModified(blogs []*blogProto.CreateBlogResponse) time.Time {
+	latest := time.Time{}
+	for _, blog := range blogs {
+		if changed := changedAt(blog); changed.After(latest) {
+			latest = changed
+		}
+	}
+	return latest
+}
+
+func (h FeedHandler) siteURL() string {
+	return strings.TrimSuffix(h.Site.URL, "/")
+}
+
+// postURL is the page of blog on the site
+func (h FeedHandler) postURL(blog *blogProto.CreateBlogResponse) string {
+	return h.siteURL() + "/posts/" + url.PathEscape(blog.Slug)
+}
+
+// entryId is the tag URI identifying blog in both feeds
+func (h FeedHandler) entryId(blog *blogProto.CreateBlogResponse) string {
+	host := h.Site.URL
+	if site, err := url.Parse(h.Site.URL); err == nil {
+		host = site.Hostname()
+	}
+	return "tag:" + host + "," + blog.CreateTime.AsTime().UTC().Format("2006-01-02") + ":blog/" + blog.Id
+}
+
+func feedHtml(blog *blogProto.CreateBlogResponse) string {
+	return renderedHtml(blog.Id, blog.Version, blog.ContentFormat, blog.Content)
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	// the feed's own URL, as recommended by the RSS Advisory Board
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Guid        rssGuid  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func (h FeedHandler) rss(blogs []*blogProto.CreateBlogResponse, updated time.Time) interface{} {
+	channel := rssChannel{
+		Title:       h.Site.Title,
+		Link:        h.siteURL() + "/",
+		Description: "The latest posts on " + h.Site.Title,
+		Self:        atomLink{Href: h.siteURL() + "/feed.rss", Rel: "self", Type: "application/rss+xml"},
+		Items:       []rssItem{},
+	}
+	if !updated.IsZero() {
+		channel.LastBuildDate = updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, blog := range blogs {
+		channel.Items = append(channel.Items, rssItem{
+			Title:       blog.Title,
+			Link:        h.postURL(blog),
+			Guid:        rssGuid{Value: h.entryId(blog)},
+			PubDate:     publishedAt(blog).UTC().Format(time.RFC1123Z),
+			Description: feedHtml(blog),
+			Categories:  blog.Tags,
+		})
+	}
+	return rssFeed{Version: "2.0", AtomNS: "http://www.w3.org/2005/Atom", Channel: channel}
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	Id      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	Id         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+func (h FeedHandler) atom(blogs []*blogProto.CreateBlogResponse, updated time.Time) interface{} {
+	if updated.IsZero() {
+		// updated is required, an empty feed has not changed since the epoch
+		updated = time.Unix(0, 0)
+	}
+	feed := atomFeed{
+		Title:   h.Site.Title,
+		Id:      h.siteURL() + "/",
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: h.siteURL() + "/"},
+			{Href: h.siteURL() + "/feed.atom", Rel: "self", Type: "application/atom+xml"},
+		},
+		// entries have no author of their own, author_id is a token subject rather than a name
+		Author:  atomPerson{Name: h.Site.Title},
+		Entries: []atomEntry{},
+	}
+	for _, blog := range blogs {
+		entry := atomEntry{
+			Title:     blog.Title,
+			Id:        h.entryId(blog),
+			Link:      atomLink{Href: h.postURL(blog)},
+			Published: publishedAt(blog).UTC().Format(time.RFC3339),
+			Updated:   changedAt(blog).UTC().Format(time.RFC3339),
+			Content:   atomContent{Type: "html", Body: feedHtml(blog)},
+		}
+		for _, tag := range blog.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
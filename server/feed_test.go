@@ -0,0 +1,146 @@
+package server_test
+
+import (
+	"blog-service/auth"
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedHandler(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("feed tests need the in-process in-memory backend")
+	}
+	ctx := context.Background()
+	published, err := config.DB.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Feed <test>", Content: "*hello*", Tags: []string{"feeds"},
+		ContentFormat: blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN, Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	_, err = config.DB.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Feed draft", Content: "secret"})
+	require.NoError(t, err)
+
+	handler := server.FeedHandler{
+		Site: config.SiteSettings{URL: "https://blog.example.com/", Title: "Example"},
+		Feed: config.FeedSettings{Size: 100},
+	}
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	guid := "tag:blog.example.com," + published.CreateTime.AsTime().UTC().Format("2006-01-02") + ":blog/" + published.Id
+
+	rss := get("/feed.rss", nil)
+	require.Equal(t, http.StatusOK, rss.Code)
+	require.Equal(t, "application/rss+xml; charset=utf-8", rss.Header().Get("Content-Type"))
+	var channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Link        string `xml:"link"`
+			Guid        string `xml:"guid"`
+			PubDate     string `xml:"pubDate"`
+			Description string `xml:"description"`
+		} `xml:"channel>item"`
+	}
+	require.NoError(t, xml.Unmarshal(rss.Body.Bytes(), &channel))
+	found := false
+	for _, item := range channel.Items {
+		require.NotEqual(t, "Feed draft", item.Title, "drafts are not in the feed")
+		if item.Guid == guid {
+			found = true
+			require.Equal(t, "Feed <test>", item.Title)
+			require.Equal(t, "https://blog.example.com/posts/"+published.Slug, item.Link)
+			require.Equal(t, "<p><em>hello</em></p>", item.Description)
+			_, err := time.Parse(time.RFC1123Z, item.PubDate)
+			require.NoError(t, err)
+		}
+	}
+	require.True(t, found)
+
+	atom := get("/feed.atom", nil)
+	require.Equal(t, http.StatusOK, atom.Code)
+	var feed struct {
+		Entries []struct {
+			Id      string `xml:"id"`
+			Content string `xml:"content"`
+		} `xml:"http://www.w3.org/2005/Atom entry"`
+	}
+	require.NoError(t, xml.Unmarshal(atom.Body.Bytes(), &feed))
+	require.NotEmpty(t, feed.Entries)
+	require.Contains(t, atom.Body.String(), "<id>"+guid+"</id>")
+
+	// conditional requests are answered without a body while the feed is unchanged
+	etag := rss.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	require.Equal(t, http.StatusNotModified, get("/feed.rss", http.Header{"If-None-Match": {etag}}).Code)
+	lastModified := rss.Header().Get("Last-Modified")
+	require.NotEmpty(t, lastModified)
+	require.Equal(t, http.StatusNotModified, get("/feed.rss", http.Header{"If-Modified-Since": {lastModified}}).Code)
+	require.NotEqual(t, etag, atom.Header().Get("ETag"))
+
+	_, err = config.DB.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: published.Id, Content: "*changed*"})
+	require.NoError(t, err)
+	changed := get("/feed.rss", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusOK, changed.Code)
+	require.Contains(t, changed.Body.String(), "changed")
+
+	// the feed holds the latest publications, even of blogs created long before the others
+	old, err := config.DB.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Feed old draft", Content: "c"})
+	require.NoError(t, err)
+	_, err = config.DB.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Feed newer", Content: "c", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	_, err = config.DB.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: old.Id, Version: old.Version})
+	require.NoError(t, err)
+	handler.Feed.Size = 1
+	channel.Items = nil
+	require.NoError(t, xml.Unmarshal(get("/feed.rss", nil).Body.Bytes(), &channel))
+	require.Len(t, channel.Items, 1)
+	require.Equal(t, "Feed old draft", channel.Items[0].Title)
+
+	require.Equal(t, http.StatusNotFound, get("/feed.json", nil).Code)
+	post := httptest.NewRecorder()
+	handler.ServeHTTP(post, httptest.NewRequest(http.MethodPost, "/feed.rss", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, post.Code)
+}
+
+func TestHTTPHandler_FeedsIgnoreBearerTokens(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("feed tests need the in-process in-memory backend")
+	}
+	// no token verifies against an empty key set, so every bearer token is invalid
+	verifier := auth.NewVerifier(auth.StaticKeySource{}, "https://issuer.example.com/", "blog-service")
+	settings := config.Settings{
+		Site: config.SiteSettings{URL: "https://blog.example.com/", Title: "Example"},
+		Feed: config.FeedSettings{Size: 20},
+	}
+	handler := server.NewHTTPHandler(&server.Server{}, &server.CommentServer{}, verifier, settings)
+	request := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/feed.rss", "/feed.atom", "/sitemap.xml"} {
+		require.Equal(t, http.StatusOK, request(http.MethodGet, path).Code, path)
+	}
+	// the API still rejects the token
+	res := request(http.MethodPost, blogProto.BlogServicePathPrefix+"ListBlog")
+	require.Equal(t, http.StatusUnauthorized, res.Code)
+	require.Contains(t, res.Body.String(), `"code":"unauthenticated"`)
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"context"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// GrpcServer adapts Server to the BlogServiceServer interface generated in service_grpc.pb.go,
+// so gRPC clients hit the same logic as Twirp clients
+type GrpcServer struct {
+	blogProto.UnimplementedBlogServiceServer
+	server *Server
+}
+
+// GrpcCommentServer adapts CommentServer to the CommentServiceServer interface generated in comment_grpc.pb.go
+type GrpcCommentServer struct {
+	blogProto.UnimplementedCommentServiceServer
+	server *CommentServer
+}
+
+// opts are passed to grpc.NewServer, e.g. the auth interceptor
+func NewGrpcServer(server *Server, comments *CommentServer, opts ...grpc.ServerOption) *grpc.Server {
+	grpcServer := grpc.NewServer(opts...)
+	blogProto.RegisterBlogServiceServer(grpcServer, &GrpcServer{server: server})
+	blogProto.RegisterCommentServiceServer(grpcServer, &GrpcCommentServer{server: comments})
+
+	// reflection lets tools like grpcurl discover the service without the .proto files
+	reflection.Register(grpcServer)
+
+	return grpcServer
+}
+
+func (g *GrpcServer) CreateBlog(ctx context.Context, req *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
+	res, err := g.server.CreateBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) GetBlog(ctx context.Context, req *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
+	res, err := g.server.GetBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) GetBlogBySlug(ctx context.Context, req *blogProto.GetBlogBySlugRequest) (*blogProto.GetBlogResponse, error) {
+	res, err := g.server.GetBlogBySlug(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) UpdateBlog(ctx context.Context, req *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	res, err := g.server.UpdateBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) DeleteBlog(ctx context.Context, req *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
+	res, err := g.server.DeleteBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) UndeleteBlog(ctx context.Context, req *blogProto.UndeleteBlogRequest) (*blogProto.UndeleteBlogResponse, error) {
+	res, err := g.server.UndeleteBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) ListBlog(ctx context.Context, req *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
+	res, err := g.server.ListBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) ListDeletedBlogs(ctx context.Context, req *blogProto.ListDeletedBlogsRequest) (*blogProto.ListDeletedBlogsResponse, error) {
+	res, err := g.server.ListDeletedBlogs(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) SearchBlogs(ctx context.Context, req *blogProto.SearchBlogsRequest) (*blogProto.SearchBlogsResponse, error) {
+	res, err := g.server.SearchBlogs(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) ListTags(ctx context.Context, req *blogProto.ListTagsRequest) (*blogProto.ListTagsResponse, error) {
+	res, err := g.server.ListTags(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) ListBlogRevisions(ctx context.Context, req *blogProto.ListBlogRevisionsRequest) (*blogProto.ListBlogRevisionsResponse, error) {
+	res, err := g.server.ListBlogRevisions(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) GetBlogRevision(ctx context.Context, req *blogProto.GetBlogRevisionRequest) (*blogProto.BlogRevision, error) {
+	res, err := g.server.GetBlogRevision(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) DiffBlogRevisions(ctx context.Context, req *blogProto.DiffBlogRevisionsRequest) (*blogProto.DiffBlogRevisionsResponse, error) {
+	res, err := g.server.DiffBlogRevisions(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) PublishBlog(ctx context.Context, req *blogProto.PublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	res, err := g.server.PublishBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) UnpublishBlog(ctx context.Context, req *blogProto.UnpublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	res, err := g.server.UnpublishBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) RevertBlog(ctx context.Context, req *blogProto.RevertBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	res, err := g.server.RevertBlog(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) BatchCreateBlogs(ctx context.Context, req *blogProto.BatchCreateBlogsRequest) (*blogProto.BatchCreateBlogsResponse, error) {
+	res, err := g.server.BatchCreateBlogs(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) BatchGetBlogs(ctx context.Context, req *blogProto.BatchGetBlogsRequest) (*blogProto.BatchGetBlogsResponse, error) {
+	res, err := g.server.BatchGetBlogs(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcServer) BatchDeleteBlogs(ctx context.Context, req *blogProto.BatchDeleteBlogsRequest) (*blogProto.BatchDeleteBlogsResponse, error) {
+	res, err := g.server.BatchDeleteBlogs(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcCommentServer) CreateComment(ctx context.Context, req *blogProto.CreateCommentRequest) (*blogProto.Comment, error) {
+	res, err := g.server.CreateComment(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcCommentServer) GetComment(ctx context.Context, req *blogProto.GetCommentRequest) (*blogProto.Comment, error) {
+	res, err := g.server.GetComment(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcCommentServer) ListComments(ctx context.Context, req *blogProto.ListCommentsRequest) (*blogProto.ListCommentsResponse, error) {
+	res, err := g.server.ListComments(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcCommentServer) UpdateComment(ctx context.Context, req *blogProto.UpdateCommentRequest) (*blogProto.Comment, error) {
+	res, err := g.server.UpdateComment(ctx, req)
+	return res, grpcError(err)
+}
+
+func (g *GrpcCommentServer) DeleteComment(ctx context.Context, req *blogProto.DeleteCommentRequest) (*blogProto.DeleteCommentResponse, error) {
+	res, err := g.server.DeleteComment(ctx, req)
+	return res, grpcError(err)
+}
+
+// twirp error codes are modelled on gRPC codes, so each one has a direct equivalent
+var grpcCodes = map[twirp.ErrorCode]codes.Code{
+	twirp.Canceled:           codes.Canceled,
+	twirp.Unknown:            codes.Unknown,
+	twirp.InvalidArgument:    codes.InvalidArgument,
+	twirp.Malformed:          codes.InvalidArgument,
+	twirp.DeadlineExceeded:   codes.DeadlineExceeded,
+	twirp.NotFound:           codes.NotFound,
+	twirp.BadRoute:           codes.Unimplemented,
+	twirp.AlreadyExists:      codes.AlreadyExists,
+	twirp.PermissionDenied:   codes.PermissionDenied,
+	twirp.Unauthenticated:    codes.Unauthenticated,
+	twirp.ResourceExhausted:  codes.ResourceExhausted,
+	twirp.FailedPrecondition: codes.FailedPrecondition,
+	twirp.Aborted:            codes.Aborted,
+	twirp.OutOfRange:         codes.OutOfRange,
+	twirp.Unimplemented:      codes.Unimplemented,
+	twirp.Internal:           codes.Internal,
+	twirp.Unavailable:        codes.Unavailable,
+	twirp.DataLoss:           codes.DataLoss,
+}
+
+func grpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	twerr, ok := err.(twirp.Error)
+	if !ok {
+		return status.Error(codes.Unknown, err.Error())
+	}
+	code, ok := grpcCodes[twerr.Code()]
+	if !ok {
+		code = codes.Unknown
+	}
+	return status.Error(code, twerr.Msg())
+}
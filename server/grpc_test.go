@@ -0,0 +1,52 @@
+package server_test
+
+import (
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newGrpcClient(t *testing.T) blogProto.BlogServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := server.NewGrpcServer(&server.Server{}, &server.CommentServer{})
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return listener.Dial() }
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return blogProto.NewBlogServiceClient(conn)
+}
+
+func TestGrpcServer_Create_Get_Delete(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("gRPC tests need the in-process in-memory backend")
+	}
+	client := newGrpcClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "gRPC title", Content: "gRPC content"})
+	require.NoError(t, err)
+
+	got, err := client.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "gRPC title", got.Title)
+
+	_, err = client.DeleteBlog(ctx, &blogProto.DeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+
+	// twirp errors are translated to the matching gRPC status code
+	_, err = client.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"blog-service/auth"
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"net/http"
+)
+
+// NewHTTPHandler serves both Twirp services, the feeds and the sitemaps on one port.
+// Only the Twirp services go through the verifier when it is not nil: feeds and sitemaps are public,
+// and feed readers and crawlers sending a stale or foreign bearer token must not get a 401 for them
+func NewHTTPHandler(server *Server, comments *CommentServer, verifier *auth.Verifier, settings config.Settings, twirpOptions ...interface{}) http.Handler {
+	// both Twirp services are served under their own path prefix, e.g. /twirp/service.BlogService/
+	var blogHandler http.Handler = blogProto.NewBlogServiceServer(server, twirpOptions...)
+	var commentHandler http.Handler = blogProto.NewCommentServiceServer(comments, twirpOptions...)
+	if verifier != nil {
+		// bearer tokens are verified before the policy interceptor runs
+		blogHandler = verifier.Middleware(blogHandler)
+		commentHandler = verifier.Middleware(commentHandler)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(blogProto.BlogServicePathPrefix, blogHandler)
+	mux.Handle(blogProto.CommentServicePathPrefix, commentHandler)
+	// feeds of the latest published blogs, for feed readers rather than API clients
+	feeds := FeedHandler{Site: settings.Site, Feed: settings.Feed}
+	mux.Handle("/feed.rss", feeds)
+	mux.Handle("/feed.atom", feeds)
+	sitemaps := SitemapHandler{Site: settings.Site}
+	mux.Handle("/sitemap.xml", sitemaps)
+	mux.Handle("/sitemaps/", sitemaps)
+	return mux
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"blog-service/auth"
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"context"
+	"fmt"
+
+	"github.com/twitchtv/twirp"
+)
+
+// AdminScope lets a caller change, restore and list the deleted blogs of any author
+const AdminScope = "blog:admin"
+
+// Policy lists the scopes each BlogService and CommentService method requires when authentication is enabled.
+// Methods with no scopes are public, and a method missing from the table is denied
+var Policy = auth.Policy{
+	"CreateBlog":       {"blog:write"},
+	"GetBlog":          {},
+	"GetBlogBySlug":    {},
+	"UpdateBlog":       {"blog:write"},
+	"DeleteBlog":       {"blog:delete"},
+	"UndeleteBlog":     {"blog:delete"},
+	"ListBlog":         {},
+	"ListDeletedBlogs": {"blog:delete"},
+	"SearchBlogs":      {},
+	"ListTags":         {},
+	"PublishBlog":      {"blog:write"},
+	"UnpublishBlog":    {"blog:write"},
+	// revisions may hold content the author has since removed, so like changes they are limited to
+	// the author or an admin
+	"ListBlogRevisions": {"blog:write"},
+	"GetBlogRevision":   {"blog:write"},
+	"DiffBlogRevisions": {"blog:write"},
+	"RevertBlog":        {"blog:write"},
+	// each item of a batch is checked as the single-item method would check it
+	"BatchCreateBlogs": {"blog:write"},
+	"BatchGetBlogs":    {},
+	"BatchDeleteBlogs": {"blog:delete"},
+
+	// CommentService
+	"CreateComment": {"comment:write"},
+	"GetComment":    {},
+	"ListComments":  {},
+	"UpdateComment": {"comment:write"},
+	"DeleteComment": {"comment:write"},
+}
+
+// canChange allows changes to a blog by authorId only by that author or an admin. Blogs without an author,
+// created before authors were recorded, can only be changed by an admin
+func canChange(ctx context.Context, authorId string) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		if auth.Enabled(ctx) {
+			return twirp.NewError(twirp.Unauthenticated, "A bearer token is required")
+		}
+		return nil
+	}
+	if claims.HasScope(AdminScope) {
+		return nil
+	}
+
+	if authorId == "" {
+		return twirp.NewError(twirp.PermissionDenied, "Blog has no author, only an admin can change it")
+	}
+	if authorId != claims.Subject {
+		return twirp.NewError(twirp.PermissionDenied, "Only the author or an admin can change this blog")
+	}
+	return nil
+}
+
+// isPublic reports whether everyone can read blog, which is published and not in the trash. Other blogs
+// are only visible to those canChange allows to change them
+func isPublic(blog *blogProto.GetBlogResponse) bool {
+	return blog.DeleteTime == nil && blog.Status == blogProto.BlogStatus_BLOG_STATUS_PUBLISHED
+}
+
+// canListUnpublished allows listing the drafts, scheduled and archived blogs of authorId to that author or an admin,
+// and those of every author, when authorId is empty, to an admin only
+func canListUnpublished(ctx context.Context, authorId string) error {
+	if authorId == "" {
+		if claims, ok := auth.FromContext(ctx); ok && !claims.HasScope(AdminScope) {
+			return twirp.NewError(twirp.PermissionDenied, "Only an admin can list the unpublished blogs of every author, filter by author_id")
+		}
+	}
+	return canChange(ctx, authorId)
+}
+
+// changeableBlog returns blog id, whether or not it is in the trash, if canChange allows the caller to change it.
+// A blog the caller cannot change or read is NotFound, as it is for GetBlog
+func changeableBlog(ctx context.Context, id string) (*blogProto.GetBlogResponse, error) {
+	blog, err := config.DB.GetBlog(ctx, &blogProto.GetBlogRequest{Id: id, ShowDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := canChange(ctx, blog.AuthorId); err != nil {
+		if !isPublic(blog) {
+			return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", id))
+		}
+		return nil, err
+	}
+	return blog, nil
+}
+
+// checkOwner applies canChange to blog id, whether or not it is in the trash. Authors never change once
+// a blog is created, so checking before the write cannot race with it
+func checkOwner(ctx context.Context, id string) error {
+	if _, ok := auth.FromContext(ctx); !ok && !auth.Enabled(ctx) {
+		return nil
+	}
+
+	blog, err := config.DB.GetBlog(ctx, &blogProto.GetBlogRequest{Id: id, ShowDeleted: true})
+	if err != nil {
+		if twerr, ok := err.(twirp.Error); ok && twerr.Code() == twirp.NotFound {
+			// let the write itself report the missing blog, as it does without authentication
+			return nil
+		}
+		return err
+	}
+	return canChange(ctx, blog.AuthorId)
+}
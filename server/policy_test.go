@@ -0,0 +1,255 @@
+package server_test
+
+import (
+	"blog-service/auth"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+)
+
+// every RPC must have an explicit entry, otherwise it is denied to everyone once auth is enabled
+func TestPolicy_CoversEveryMethod(t *testing.T) {
+	methods := 0
+	for _, service := range []reflect.Type{
+		reflect.TypeOf((*blogProto.BlogService)(nil)).Elem(),
+		reflect.TypeOf((*blogProto.CommentService)(nil)).Elem(),
+	} {
+		for i := 0; i < service.NumMethod(); i++ {
+			_, ok := server.Policy[service.Method(i).Name]
+			require.True(t, ok, "server.Policy has no entry for %s", service.Method(i).Name)
+		}
+		methods += service.NumMethod()
+	}
+	require.Len(t, server.Policy, methods)
+}
+
+func requireTwirpCode(t *testing.T, err error, code twirp.ErrorCode) {
+	t.Helper()
+	twerr, ok := err.(twirp.Error)
+	require.True(t, ok, "expected a twirp.Error, got %v", err)
+	require.Equal(t, code, twerr.Code())
+}
+
+func TestServer_Ownership(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("ownership tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "alice", Scopes: []string{"blog:write", "blog:delete"}})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "bob", Scopes: []string{"blog:write", "blog:delete"}})
+	admin := auth.NewContext(context.Background(), &auth.Claims{Subject: "carol", Scopes: []string{"blog:write", "blog:delete", server.AdminScope}})
+
+	created, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Alice's", Content: "post", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	require.Equal(t, "alice", created.AuthorId)
+
+	_, err = s.UpdateBlog(bob, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "Bob's now", Content: "post"})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = s.DeleteBlog(bob, &blogProto.DeleteBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+
+	_, err = s.UpdateBlog(alice, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "Alice's, edited", Content: "post"})
+	require.NoError(t, err)
+	updated, err := s.UpdateBlog(admin, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "Moderated", Content: "post"})
+	require.NoError(t, err)
+	require.Equal(t, "alice", updated.AuthorId)
+
+	// blogs without an author, e.g. created before authors were recorded, can only be changed by an admin
+	legacy, err := s.CreateBlog(context.Background(), &blogProto.CreateBlogRequest{Title: "Legacy", Content: "post", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	_, err = s.UpdateBlog(alice, &blogProto.UpdateBlogRequest{Id: legacy.Id, Title: "Mine now", Content: "post"})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = s.DeleteBlog(admin, &blogProto.DeleteBlogRequest{Id: legacy.Id})
+	require.NoError(t, err)
+
+	// blogs in the trash are still owned, missing blogs keep their usual error
+	_, err = s.UndeleteBlog(bob, &blogProto.UndeleteBlogRequest{Id: legacy.Id})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = s.DeleteBlog(bob, &blogProto.DeleteBlogRequest{Id: "999999"})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	_, err = s.DeleteBlog(alice, &blogProto.DeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+}
+
+func TestServer_Trash(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("trash tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	scopes := []string{"blog:write", "blog:delete"}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "trash-alice", Scopes: scopes})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "trash-bob", Scopes: scopes})
+	admin := auth.NewContext(context.Background(), &auth.Claims{Subject: "trash-carol", Scopes: append(scopes, server.AdminScope)})
+
+	created, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Trash me", Content: "post", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	_, err = s.DeleteBlog(alice, &blogProto.DeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+
+	_, err = s.GetBlog(alice, &blogProto.GetBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	got, err := s.GetBlog(alice, &blogProto.GetBlogRequest{Id: created.Id, ShowDeleted: true})
+	require.NoError(t, err)
+	require.NotNil(t, got.DeleteTime)
+	// to anyone who could not restore it, a deleted blog does not exist
+	_, err = s.GetBlog(bob, &blogProto.GetBlogRequest{Id: created.Id, ShowDeleted: true})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	// authors only see their own trash
+	trash, err := s.ListDeletedBlogs(bob, &blogProto.ListDeletedBlogsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, trash.Blogs)
+	_, err = s.ListDeletedBlogs(bob, &blogProto.ListDeletedBlogsRequest{Filter: &blogProto.BlogFilter{AuthorId: "trash-alice"}})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	trash, err = s.ListDeletedBlogs(admin, &blogProto.ListDeletedBlogsRequest{Filter: &blogProto.BlogFilter{AuthorId: "trash-alice"}})
+	require.NoError(t, err)
+	require.Len(t, trash.Blogs, 1)
+
+	_, err = s.UndeleteBlog(bob, &blogProto.UndeleteBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	restored, err := s.UndeleteBlog(alice, &blogProto.UndeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "Trash me", restored.Title)
+
+	_, err = s.DeleteBlog(alice, &blogProto.DeleteBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+}
+
+func TestServer_Revisions(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("revision tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "rev-alice", Scopes: []string{"blog:write"}})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "rev-bob", Scopes: []string{"blog:write"}})
+
+	created, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Draft", Content: "intro\nbody\nend\n", Status: blogProto.BlogStatus_BLOG_STATUS_PUBLISHED})
+	require.NoError(t, err)
+	_, err = s.UpdateBlog(alice, &blogProto.UpdateBlogRequest{Id: created.Id, Title: "Final", Content: "intro\nnew body\nend\n"})
+	require.NoError(t, err)
+
+	// the diff defaults to the current version
+	diffed, err := s.DiffBlogRevisions(alice, &blogProto.DiffBlogRevisionsRequest{BlogId: created.Id, FromVersion: 1})
+	require.NoError(t, err)
+	require.Equal(t, "--- version 1\n+++ version 2\n@@ -1,3 +1,3 @@\n intro\n-body\n+new body\n end\n", diffed.Diff)
+	require.Equal(t, "Draft", diffed.FromTitle)
+	require.Equal(t, "Final", diffed.ToTitle)
+	same, err := s.DiffBlogRevisions(alice, &blogProto.DiffBlogRevisionsRequest{BlogId: created.Id, FromVersion: 2, ToVersion: 2})
+	require.NoError(t, err)
+	require.Empty(t, same.Diff)
+	require.Empty(t, same.FromTitle)
+	_, err = s.DiffBlogRevisions(alice, &blogProto.DiffBlogRevisionsRequest{BlogId: created.Id, FromVersion: 7})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	current, err := s.GetBlogRevision(alice, &blogProto.GetBlogRevisionRequest{BlogId: created.Id, Version: 2})
+	require.NoError(t, err)
+	require.Equal(t, "Final", current.Title)
+
+	// only the author or an admin can see the history
+	_, err = s.ListBlogRevisions(bob, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = s.RevertBlog(bob, &blogProto.RevertBlogRequest{BlogId: created.Id, RevisionVersion: 1})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+
+	_, err = s.RevertBlog(alice, &blogProto.RevertBlogRequest{BlogId: created.Id, RevisionVersion: 2})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+	_, err = s.RevertBlog(alice, &blogProto.RevertBlogRequest{BlogId: created.Id, RevisionVersion: 1, Version: 1})
+	requireTwirpCode(t, err, twirp.Aborted)
+	reverted, err := s.RevertBlog(alice, &blogProto.RevertBlogRequest{BlogId: created.Id, RevisionVersion: 1, Version: 2})
+	require.NoError(t, err)
+	require.Equal(t, "Draft", reverted.Title)
+	require.Equal(t, "intro\nbody\nend\n", reverted.Content)
+	require.Equal(t, int64(3), reverted.Version)
+
+	// the revert is an update, so the state it replaced is kept as well
+	list, err := s.ListBlogRevisions(alice, &blogProto.ListBlogRevisionsRequest{BlogId: created.Id})
+	require.NoError(t, err)
+	require.Len(t, list.Revisions, 2)
+	require.Equal(t, "Final", list.Revisions[0].Title)
+}
+
+func TestServer_Drafts(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("draft tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "draft-alice", Scopes: []string{"blog:write"}})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "draft-bob", Scopes: []string{"blog:write"}})
+	admin := auth.NewContext(context.Background(), &auth.Claims{Subject: "draft-carol", Scopes: []string{"blog:write", server.AdminScope}})
+	mine := &blogProto.BlogFilter{AuthorId: "draft-alice"}
+
+	created, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Unfinished", Content: "post"})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_DRAFT, created.Status)
+
+	// to anyone but the author or an admin, a draft does not exist
+	_, err = s.GetBlog(alice, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	_, err = s.GetBlog(admin, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	_, err = s.GetBlog(bob, &blogProto.GetBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	_, err = s.PublishBlog(bob, &blogProto.PublishBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+
+	list, err := s.ListBlog(alice, &blogProto.ListBlogRequest{Filter: mine})
+	require.NoError(t, err)
+	require.Len(t, list.Blogs, 1)
+	list, err = s.ListBlog(bob, &blogProto.ListBlogRequest{Filter: mine})
+	require.NoError(t, err)
+	require.Empty(t, list.Blogs)
+	_, err = s.ListBlog(bob, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{AuthorId: "draft-alice", Status: blogProto.BlogStatus_BLOG_STATUS_DRAFT}})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+	_, err = s.ListBlog(alice, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{Status: blogProto.BlogStatus_BLOG_STATUS_DRAFT}})
+	requireTwirpCode(t, err, twirp.PermissionDenied)
+
+	published, err := s.PublishBlog(alice, &blogProto.PublishBlogRequest{Id: created.Id, Version: created.Version})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.BlogStatus_BLOG_STATUS_PUBLISHED, published.Status)
+	_, err = s.GetBlog(bob, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	list, err = s.ListBlog(bob, &blogProto.ListBlogRequest{Filter: mine})
+	require.NoError(t, err)
+	require.Len(t, list.Blogs, 1)
+
+	_, err = s.UnpublishBlog(alice, &blogProto.UnpublishBlogRequest{Id: created.Id, Archive: true})
+	require.NoError(t, err)
+	_, err = s.GetBlog(bob, &blogProto.GetBlogRequest{Id: created.Id})
+	requireTwirpCode(t, err, twirp.NotFound)
+	archived, err := s.ListBlog(admin, &blogProto.ListBlogRequest{Filter: &blogProto.BlogFilter{Status: blogProto.BlogStatus_BLOG_STATUS_ARCHIVED}})
+	require.NoError(t, err)
+	require.Len(t, archived.Blogs, 1)
+}
+
+func TestServer_GetBlogBySlug(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("slug tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	alice := auth.NewContext(context.Background(), &auth.Claims{Subject: "slug-alice", Scopes: []string{"blog:write"}})
+	bob := auth.NewContext(context.Background(), &auth.Claims{Subject: "slug-bob", Scopes: []string{"blog:write"}})
+
+	_, err := s.GetBlogBySlug(bob, &blogProto.GetBlogBySlugRequest{})
+	requireTwirpCode(t, err, twirp.InvalidArgument)
+
+	created, err := s.CreateBlog(alice, &blogProto.CreateBlogRequest{Title: "Slug server test", Content: "post"})
+	require.NoError(t, err)
+	got, err := s.GetBlogBySlug(alice, &blogProto.GetBlogBySlugRequest{Slug: created.Slug})
+	require.NoError(t, err)
+	require.Equal(t, created.Id, got.Id)
+	// drafts are as hidden by slug as they are by id
+	_, err = s.GetBlogBySlug(bob, &blogProto.GetBlogBySlugRequest{Slug: created.Slug})
+	requireTwirpCode(t, err, twirp.NotFound)
+
+	_, err = s.PublishBlog(alice, &blogProto.PublishBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	_, err = s.GetBlogBySlug(bob, &blogProto.GetBlogBySlugRequest{Slug: created.Slug})
+	require.NoError(t, err)
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	config "blog-service/config"
+	"blog-service/render"
+	blogProto "blog-service/rpc/blog"
+)
+
+// renderedHtml renders the content of blog id at version according to its content_format. Every format is
+// sanitized, HTML written by authors as much as the Markdown renderer's output, which passes raw HTML through
+func renderedHtml(id string, version int64, format blogProto.ContentFormat, content string) string {
+	if html, ok := config.RenderCache.Get(id, version); ok {
+		return html
+	}
+
+	var html string
+	switch format {
+	case blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN:
+		html = render.Sanitize(render.Markdown(content))
+	case blogProto.ContentFormat_CONTENT_FORMAT_HTML:
+		html = render.Sanitize(content)
+	default:
+		html = render.Sanitize(render.Plain(content))
+	}
+	config.RenderCache.Put(id, version, html)
+	return html
+}
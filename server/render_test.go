@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"blog-service/auth"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RenderedHtml(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("rendering tests need the in-process in-memory backend")
+	}
+	s := &server.Server{}
+	ctx := auth.NewContext(context.Background(), &auth.Claims{Subject: "render-alice", Scopes: []string{"blog:write"}})
+
+	created, err := s.CreateBlog(ctx, &blogProto.CreateBlogRequest{
+		Title:         "Rendered",
+		Content:       "# Hi\n\n*there* <script>alert(1)</script>",
+		ContentFormat: blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN,
+	})
+	require.NoError(t, err)
+	require.Equal(t, blogProto.ContentFormat_CONTENT_FORMAT_MARKDOWN, created.ContentFormat)
+	got, err := s.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "<h1>Hi</h1>\n<p><em>there</em> </p>", got.RenderedHtml)
+
+	// an update renders the new content, whether it changes the content or only its format
+	_, err = s.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Content: "**bold**"})
+	require.NoError(t, err)
+	got, err = s.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "<p><strong>bold</strong></p>", got.RenderedHtml)
+	_, err = s.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, ContentFormat: blogProto.ContentFormat_CONTENT_FORMAT_PLAIN})
+	require.NoError(t, err)
+	got, err = s.GetBlog(ctx, &blogProto.GetBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	require.Equal(t, "<p>**bold**</p>", got.RenderedHtml)
+
+	// HTML content is sanitized too
+	_, err = s.UpdateBlog(ctx, &blogProto.UpdateBlogRequest{Id: created.Id, Content: `<p onclick="x()">hi<img src="javascript:x"></p>`,
+		ContentFormat: blogProto.ContentFormat_CONTENT_FORMAT_HTML})
+	require.NoError(t, err)
+	_, err = s.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: created.Id})
+	require.NoError(t, err)
+	got, err = s.GetBlogBySlug(context.Background(), &blogProto.GetBlogBySlugRequest{Slug: created.Slug})
+	require.NoError(t, err)
+	require.Equal(t, `<p>hi</p>`, got.RenderedHtml)
+}
@@ -1,9 +1,18 @@
 package server
 
 import (
+	"blog-service/auth"
 	config "blog-service/config"
+	"blog-service/diff"
 	blogProto "blog-service/rpc/blog"
 	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/twitchtv/twirp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type Server struct{}
@@ -12,41 +21,119 @@ type Server struct{}
 
 func (*Server) CreateBlog(ctx context.Context, req *blogProto.CreateBlogRequest) (*blogProto.CreateBlogResponse, error) {
 	data := &blogProto.CreateBlogRequest{
-		Title:   req.GetTitle(),
-		Content: req.GetContent(),
+		Title:         req.GetTitle(),
+		Content:       req.GetContent(),
+		Tags:          req.GetTags(),
+		Category:      req.GetCategory(),
+		Status:        req.GetStatus(),
+		ContentFormat: req.GetContentFormat(),
 	}
 
-	res, err := config.DB.CreateBlog(data)
+	res, err := config.DB.CreateBlog(ctx, data)
 	return res, err
 }
 
 func (*Server) GetBlog(ctx context.Context, req *blogProto.GetBlogRequest) (*blogProto.GetBlogResponse, error) {
 	data := &blogProto.GetBlogRequest{
-		Id: req.GetId(),
+		Id:          req.GetId(),
+		ShowDeleted: req.GetShowDeleted(),
 	}
 
-	res, err := config.DB.GetBlog(data)
+	res, err := config.DB.GetBlog(ctx, data)
+	if err == nil && !isPublic(res) && canChange(ctx, res.AuthorId) != nil {
+		// unpublished blogs and the trash are only visible to those who could change the blog, to everyone else they do not exist
+		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for id: %v", data.Id))
+	}
+	if err == nil {
+		res.RenderedHtml = renderedHtml(res.Id, res.Version, res.ContentFormat, res.Content)
+	}
+	return res, err
+}
+
+func (*Server) GetBlogBySlug(ctx context.Context, req *blogProto.GetBlogBySlugRequest) (*blogProto.GetBlogResponse, error) {
+	if req.GetSlug() == "" {
+		return nil, twirp.RequiredArgumentError("slug")
+	}
+
+	data := &blogProto.GetBlogBySlugRequest{
+		Slug: req.GetSlug(),
+	}
+
+	res, err := config.DB.GetBlogBySlug(ctx, data)
+	if err == nil && !isPublic(res) && canChange(ctx, res.AuthorId) != nil {
+		return nil, twirp.NewError(twirp.NotFound, fmt.Sprintf("No documents were found for slug: %v", data.Slug))
+	}
+	if err == nil {
+		res.RenderedHtml = renderedHtml(res.Id, res.Version, res.ContentFormat, res.Content)
+	}
 	return res, err
 }
 
 func (*Server) UpdateBlog(ctx context.Context, req *blogProto.UpdateBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	if err := checkOwner(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
 	data := &blogProto.UpdateBlogRequest{
-		Id:      req.GetId(),
-		Title:   req.GetTitle(),
-		Content: req.GetContent(),
+		Id:            req.GetId(),
+		Title:         req.GetTitle(),
+		Content:       req.GetContent(),
+		Version:       req.GetVersion(),
+		UpdateMask:    req.GetUpdateMask(),
+		Tags:          req.GetTags(),
+		Category:      req.GetCategory(),
+		Slug:          req.GetSlug(),
+		ContentFormat: req.GetContentFormat(),
 	}
 
-	res, err := config.DB.UpdateBlog(data)
+	res, err := config.DB.UpdateBlog(ctx, data)
+	if err == nil {
+		// the new version would miss the cache anyway, this frees the HTML of the old one
+		config.RenderCache.Invalidate(res.Id)
+	}
 	return res, err
 }
 
 func (*Server) DeleteBlog(ctx context.Context, req *blogProto.DeleteBlogRequest) (*blogProto.DeleteBlogResponse, error) {
+	if err := checkOwner(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
 	data := &blogProto.DeleteBlogRequest{
-		Id: req.GetId(),
+		Id:      req.GetId(),
+		Version: req.GetVersion(),
 	}
 
-	res, err := config.DB.DeleteBlog(data)
-	return res, err
+	res, err := config.DB.DeleteBlog(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	// the comments go to the trash with the blog, and are purged or restored along with it. The blog is
+	// deleted either way, so as for BatchDeleteBlogs a failure here does not fail the call
+	if err := config.Comments.OrphanComments(ctx, data.Id, true); err != nil {
+		log.Printf("Blog %v was deleted but its comments could not be hidden: %v", data.Id, err)
+	}
+	return res, nil
+}
+
+func (*Server) UndeleteBlog(ctx context.Context, req *blogProto.UndeleteBlogRequest) (*blogProto.UndeleteBlogResponse, error) {
+	if err := checkOwner(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	data := &blogProto.UndeleteBlogRequest{
+		Id:      req.GetId(),
+		Version: req.GetVersion(),
+	}
+
+	res, err := config.DB.UndeleteBlog(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.Comments.OrphanComments(ctx, data.Id, false); err != nil {
+		log.Printf("Blog %v was restored but its comments could not be: %v", data.Id, err)
+	}
+	return res, nil
 }
 
 func (*Server) ListBlog(ctx context.Context, req *blogProto.ListBlogRequest) (*blogProto.ListBlogResponse, error) {
@@ -57,9 +144,238 @@ func (*Server) ListBlog(ctx context.Context, req *blogProto.ListBlogRequest) (*b
 	}
 
 	data := &blogProto.ListBlogRequest{
+		Limit:     limit,
+		PageToken: req.GetPageToken(),
+		Filter:    req.GetFilter(),
+		OrderBy:   req.GetOrderBy(),
+	}
+
+	// readers only list published blogs, authors also see their own drafts when they filter by their author_id
+	filter := proto.Clone(req.GetFilter()).(*blogProto.BlogFilter)
+	if filter == nil {
+		filter = &blogProto.BlogFilter{}
+	}
+	canList := canListUnpublished(ctx, filter.AuthorId)
+	if filter.Status == blogProto.BlogStatus_BLOG_STATUS_UNSPECIFIED && (filter.AuthorId == "" || canList != nil) {
+		filter.Status = blogProto.BlogStatus_BLOG_STATUS_PUBLISHED
+	}
+	if filter.Status != blogProto.BlogStatus_BLOG_STATUS_PUBLISHED && canList != nil {
+		return nil, canList
+	}
+	data.Filter = filter
+
+	res, err := config.DB.ListBlog(ctx, data)
+	return res, err
+}
+
+func (*Server) PublishBlog(ctx context.Context, req *blogProto.PublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	if err := checkOwner(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	data := &blogProto.PublishBlogRequest{
+		Id:          req.GetId(),
+		PublishTime: req.GetPublishTime(),
+		Version:     req.GetVersion(),
+	}
+
+	res, err := config.DB.PublishBlog(ctx, data)
+	return res, err
+}
+
+func (*Server) UnpublishBlog(ctx context.Context, req *blogProto.UnpublishBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	if err := checkOwner(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+
+	data := &blogProto.UnpublishBlogRequest{
+		Id:      req.GetId(),
+		Archive: req.GetArchive(),
+		Version: req.GetVersion(),
+	}
+
+	res, err := config.DB.UnpublishBlog(ctx, data)
+	return res, err
+}
+
+func (*Server) ListDeletedBlogs(ctx context.Context, req *blogProto.ListDeletedBlogsRequest) (*blogProto.ListDeletedBlogsResponse, error) {
+	limit := int64(25)
+
+	if req.GetLimit() > 0 {
+		limit = req.GetLimit()
+	}
+
+	data := &blogProto.ListDeletedBlogsRequest{
+		Limit:     limit,
+		PageToken: req.GetPageToken(),
+		Filter:    req.GetFilter(),
+		OrderBy:   req.GetOrderBy(),
+	}
+
+	// authors only see their own trash, admins see everyone's
+	if claims, ok := auth.FromContext(ctx); ok && !claims.HasScope(AdminScope) {
+		filter := proto.Clone(req.GetFilter()).(*blogProto.BlogFilter)
+		if filter == nil {
+			filter = &blogProto.BlogFilter{}
+		}
+		if filter.AuthorId != "" && filter.AuthorId != claims.Subject {
+			return nil, twirp.NewError(twirp.PermissionDenied, "Only an admin can list the deleted blogs of other authors")
+		}
+		filter.AuthorId = claims.Subject
+		data.Filter = filter
+	}
+
+	res, err := config.DB.ListDeletedBlogs(ctx, data)
+	return res, err
+}
+
+func (*Server) SearchBlogs(ctx context.Context, req *blogProto.SearchBlogsRequest) (*blogProto.SearchBlogsResponse, error) {
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		return nil, twirp.RequiredArgumentError("query")
+	}
+
+	limit := int64(25)
+
+	if req.GetLimit() > 0 {
+		limit = req.GetLimit()
+	}
+
+	data := &blogProto.SearchBlogsRequest{
+		Query: req.GetQuery(),
 		Limit: limit,
 	}
 
-	res, err := config.DB.ListBlog(data)
+	res, err := config.DB.SearchBlogs(ctx, data)
+	return res, err
+}
+
+func (*Server) ListTags(ctx context.Context, req *blogProto.ListTagsRequest) (*blogProto.ListTagsResponse, error) {
+	data := &blogProto.ListTagsRequest{
+		Limit: req.GetLimit(),
+	}
+
+	res, err := config.DB.ListTags(ctx, data)
 	return res, err
 }
+
+func (*Server) ListBlogRevisions(ctx context.Context, req *blogProto.ListBlogRevisionsRequest) (*blogProto.ListBlogRevisionsResponse, error) {
+	if req.GetBlogId() == "" {
+		return nil, twirp.RequiredArgumentError("blog_id")
+	}
+	if _, err := changeableBlog(ctx, req.GetBlogId()); err != nil {
+		return nil, err
+	}
+
+	limit := int64(25)
+
+	if req.GetLimit() > 0 {
+		limit = req.GetLimit()
+	}
+
+	data := &blogProto.ListBlogRevisionsRequest{
+		BlogId:    req.GetBlogId(),
+		Limit:     limit,
+		PageToken: req.GetPageToken(),
+	}
+
+	res, err := config.DB.ListBlogRevisions(ctx, data)
+	return res, err
+}
+
+func (*Server) GetBlogRevision(ctx context.Context, req *blogProto.GetBlogRevisionRequest) (*blogProto.BlogRevision, error) {
+	if req.GetBlogId() == "" {
+		return nil, twirp.RequiredArgumentError("blog_id")
+	}
+	blog, err := changeableBlog(ctx, req.GetBlogId())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := revisionAt(ctx, blog, req.GetVersion())
+	return res, err
+}
+
+func (*Server) DiffBlogRevisions(ctx context.Context, req *blogProto.DiffBlogRevisionsRequest) (*blogProto.DiffBlogRevisionsResponse, error) {
+	if req.GetBlogId() == "" {
+		return nil, twirp.RequiredArgumentError("blog_id")
+	}
+	if req.GetFromVersion() == 0 {
+		return nil, twirp.RequiredArgumentError("from_version")
+	}
+	blog, err := changeableBlog(ctx, req.GetBlogId())
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion := req.GetToVersion()
+	if toVersion == 0 {
+		toVersion = blog.Version
+	}
+	from, err := revisionAt(ctx, blog, req.GetFromVersion())
+	if err != nil {
+		return nil, err
+	}
+	to, err := revisionAt(ctx, blog, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &blogProto.DiffBlogRevisionsResponse{
+		Diff: diff.Unified(fmt.Sprintf("version %d", from.Version), fmt.Sprintf("version %d", to.Version), from.Content, to.Content),
+	}
+	if from.Title != to.Title {
+		res.FromTitle = from.Title
+		res.ToTitle = to.Title
+	}
+	return res, nil
+}
+
+func (*Server) RevertBlog(ctx context.Context, req *blogProto.RevertBlogRequest) (*blogProto.UpdateBlogResponse, error) {
+	if req.GetBlogId() == "" {
+		return nil, twirp.RequiredArgumentError("blog_id")
+	}
+	if req.GetRevisionVersion() == 0 {
+		return nil, twirp.RequiredArgumentError("revision_version")
+	}
+	blog, err := changeableBlog(ctx, req.GetBlogId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRevisionVersion() == blog.Version {
+		return nil, twirp.InvalidArgumentError("revision_version", "is the current version of the blog")
+	}
+	revision, err := revisionAt(ctx, blog, req.GetRevisionVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	// revisions hold the title and content only, both are restored even when empty and the tags and category are kept
+	data := &blogProto.UpdateBlogRequest{
+		Id:         req.GetBlogId(),
+		Title:      revision.Title,
+		Content:    revision.Content,
+		Version:    req.GetVersion(),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title", "content"}},
+	}
+
+	res, err := config.DB.UpdateBlog(ctx, data)
+	if err == nil {
+		config.RenderCache.Invalidate(res.Id)
+	}
+	return res, err
+}
+
+// revisionAt returns the title and content of blog at version, which may be its current version
+func revisionAt(ctx context.Context, blog *blogProto.GetBlogResponse, version int64) (*blogProto.BlogRevision, error) {
+	if version == blog.Version {
+		return &blogProto.BlogRevision{
+			BlogId:     blog.Id,
+			Version:    blog.Version,
+			Title:      blog.Title,
+			Content:    blog.Content,
+			CreateTime: blog.UpdateTime,
+		}, nil
+	}
+
+	return config.DB.GetBlogRevision(ctx, &blogProto.GetBlogRevisionRequest{BlogId: blog.Id, Version: version})
+}
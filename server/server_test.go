@@ -1,18 +1,24 @@
 package server_test
 
 import (
+	config "blog-service/config"
+	"blog-service/db"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
 type BlogResponse struct {
-	Id      string `json:"_id"`
+	Id      string `json:"id"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
 }
@@ -20,6 +26,24 @@ type BlogResponse struct {
 var SubjectId string
 var BaseURL string = "http://localhost:5050/twirp/service.BlogService"
 
+// by default the tests run against an in-memory backend so they need no database,
+// set BLOG_SERVICE_URL (e.g. http://localhost:5050) to run them against a live server instead
+func TestMain(m *testing.M) {
+	if url := os.Getenv("BLOG_SERVICE_URL"); url != "" {
+		BaseURL = fmt.Sprintf("%s/twirp/service.BlogService", url)
+		os.Exit(m.Run())
+	}
+
+	config.DB = db.NewMemoryClient()
+	config.Comments = db.NewMemoryCommentStore()
+	ts := httptest.NewServer(blogProto.NewBlogServiceServer(&server.Server{}))
+	BaseURL = fmt.Sprintf("%s/twirp/service.BlogService", ts.URL)
+
+	code := m.Run()
+	ts.Close()
+	os.Exit(code)
+}
+
 // note: NOT unit tests, more to demonstrate how to write tests in Golang
 func TestServer_Create_And_Delete(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,173 @@
+package server
+
+import (
+	config "blog-service/config"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/twitchtv/twirp"
+)
+
+// maxSitemapURLs is the most URLs a sitemap may hold, see https://www.sitemaps.org/protocol.html
+const maxSitemapURLs = 50000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapHandler serves /sitemap.xml, which lists every published blog or, when there are more than fit in one
+// sitemap, is an index of the sitemaps listing them. Each of those lists the blogs of a fixed range of ids, named
+// after its first id and the first id of the next, as in /sitemaps/<from>-<to>.xml, or /sitemaps/<from>.xml for
+// the last, so it is read from the database with a range on the id. Sitemaps are written as the blogs are read,
+// so they are never held in memory whole
+type SitemapHandler struct {
+	Site config.SiteSettings
+	// URLsPerSitemap defaults to the protocol's limit of 50,000
+	URLsPerSitemap int
+}
+
+type sitemapURL struct {
+	XMLName xml.Name `xml:"url"`
+	Loc     string   `xml:"loc"`
+	LastMod string   `xml:"lastmod"`
+}
+
+type sitemapRef struct {
+	XMLName xml.Name `xml:"sitemap"`
+	Loc     string   `xml:"loc"`
+}
+
+func (h SitemapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	perSitemap := int64(h.URLsPerSitemap)
+	if perSitemap <= 0 || perSitemap > maxSitemapURLs {
+		perSitemap = maxSitemapURLs
+	}
+
+	count, err := config.DB.CountPublishedBlogs(r.Context())
+	if err != nil {
+		log.Printf("Unable to count blogs for %v: %v", r.URL.Path, err)
+		http.Error(w, "unable to list blogs", http.StatusInternalServerError)
+		return
+	}
+	pages := (count + perSitemap - 1) / perSitemap
+
+	from, to := "", ""
+	switch path := r.URL.Path; {
+	case path == "/sitemap.xml" && pages > 1:
+		h.writeIndex(w, r, perSitemap)
+		return
+	case path == "/sitemap.xml":
+	case strings.HasPrefix(path, "/sitemaps/") && strings.HasSuffix(path, ".xml"):
+		// the range of ids of the sitemap, the last one is open ended
+		from = strings.TrimSuffix(strings.TrimPrefix(path, "/sitemaps/"), ".xml")
+		if i := strings.Index(from, "-"); i >= 0 {
+			from, to = from[:i], from[i+1:]
+			if to == "" {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		if from == "" {
+			http.NotFound(w, r)
+			return
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	// the sitemap is started with its first URL, so ranges that are not ids can still be a 404. Blogs published in
+	// the range since the index was read make the sitemap longer, up to the protocol's limit
+	var enc *xml.Encoder
+	start := func() {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		enc = newSitemapEncoder(w, "urlset")
+	}
+	site := strings.TrimSuffix(h.Site.URL, "/")
+	limit := perSitemap
+	if from != "" {
+		limit = maxSitemapURLs
+	}
+	err = config.DB.WalkPublishedBlogs(r.Context(), from, to, limit, func(id string, slug string, lastModified time.Time) error {
+		if enc == nil {
+			start()
+		}
+		return enc.Encode(sitemapURL{Loc: site + "/posts/" + slug, LastMod: lastModified.UTC().Format(time.RFC3339)})
+	})
+	if twerr, ok := err.(twirp.Error); ok && twerr.Code() == twirp.InvalidArgument {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		if enc == nil {
+			log.Printf("Unable to list blogs for %v: %v", r.URL.Path, err)
+			http.Error(w, "unable to list blogs", http.StatusInternalServerError)
+			return
+		}
+		// the status was sent with the first URL, crawlers reject the sitemap since it is not closed
+		log.Printf("Unable to write %v: %v", r.URL.Path, err)
+		return
+	}
+	if enc == nil {
+		// a site without published blogs, or a range whose blogs were all unpublished, has an empty sitemap
+		start()
+	}
+	closeSitemap(enc, "urlset")
+}
+
+// writeIndex points to a sitemap for every perSitemap published blogs, walking them once to find the ids each
+// sitemap's range starts at. A range ends where the next one starts, and the last one is open ended
+func (h SitemapHandler) writeIndex(w http.ResponseWriter, r *http.Request, perSitemap int64) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	enc := newSitemapEncoder(w, "sitemapindex")
+	site := strings.TrimSuffix(h.Site.URL, "/")
+	ref := func(name string) error {
+		return enc.Encode(sitemapRef{Loc: fmt.Sprintf("%s/sitemaps/%s.xml", site, name)})
+	}
+	walked := int64(0)
+	from := ""
+	err := config.DB.WalkPublishedBlogs(r.Context(), "", "", 0, func(id string, slug string, lastModified time.Time) error {
+		first := walked%perSitemap == 0
+		walked++
+		if !first {
+			return nil
+		}
+		if from != "" {
+			if err := ref(url.PathEscape(from) + "-" + url.PathEscape(id)); err != nil {
+				return err
+			}
+		}
+		from = id
+		return nil
+	})
+	if err == nil && from != "" {
+		err = ref(url.PathEscape(from))
+	}
+	if err != nil {
+		log.Printf("Unable to write %v: %v", r.URL.Path, err)
+		return
+	}
+	closeSitemap(enc, "sitemapindex")
+}
+
+// newSitemapEncoder writes the XML declaration and opens the root element
+func newSitemapEncoder(w http.ResponseWriter, root string) *xml.Encoder {
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: root}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: sitemapNS}}})
+	return enc
+}
+
+func closeSitemap(enc *xml.Encoder, root string) {
+	enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: root}})
+	enc.Flush()
+}
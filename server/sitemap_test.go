@@ -0,0 +1,106 @@
+package server_test
+
+import (
+	config "blog-service/config"
+	blogProto "blog-service/rpc/blog"
+	"blog-service/server"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemapHandler(t *testing.T) {
+	if os.Getenv("BLOG_SERVICE_URL") != "" {
+		t.Skip("sitemap tests need the in-process in-memory backend")
+	}
+	ctx := context.Background()
+	slugs := []string{}
+	var late *blogProto.CreateBlogResponse
+	for i := 0; i < 4; i++ {
+		status := blogProto.BlogStatus_BLOG_STATUS_PUBLISHED
+		if i == 1 {
+			// published once the index was read
+			status = blogProto.BlogStatus_BLOG_STATUS_DRAFT
+		}
+		blog, err := config.DB.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Sitemap test", Content: "c", Status: status})
+		require.NoError(t, err)
+		if i == 1 {
+			late = blog
+		}
+		slugs = append(slugs, blog.Slug)
+	}
+	draft, err := config.DB.CreateBlog(ctx, &blogProto.CreateBlogRequest{Title: "Sitemap draft", Content: "c"})
+	require.NoError(t, err)
+	count, err := config.DB.CountPublishedBlogs(ctx)
+	require.NoError(t, err)
+
+	site := config.SiteSettings{URL: "https://blog.example.com/", Title: "Example"}
+	get := func(handler server.SitemapHandler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	type urlset struct {
+		URLs []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 url"`
+	}
+
+	// a single sitemap lists every published blog
+	single := get(server.SitemapHandler{Site: site}, "/sitemap.xml")
+	require.Equal(t, http.StatusOK, single.Code)
+	require.Equal(t, "application/xml; charset=utf-8", single.Header().Get("Content-Type"))
+	listed := urlset{}
+	require.NoError(t, xml.Unmarshal(single.Body.Bytes(), &listed))
+	require.Len(t, listed.URLs, int(count))
+	for _, url := range listed.URLs {
+		_, err := time.Parse(time.RFC3339, url.LastMod)
+		require.NoError(t, err)
+	}
+	require.Equal(t, http.StatusNotFound, get(server.SitemapHandler{Site: site}, "/sitemaps/x.xml").Code)
+
+	// with more blogs than fit in one sitemap, /sitemap.xml is an index of sitemaps that together list each blog once
+	paged := server.SitemapHandler{Site: site, URLsPerSitemap: 2}
+	var index struct {
+		Sitemaps []string `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemap>loc"`
+	}
+	require.NoError(t, xml.Unmarshal(get(paged, "/sitemap.xml").Body.Bytes(), &index))
+	pages := int((count + 1) / 2)
+	require.Len(t, index.Sitemaps, pages)
+
+	// publishing moves no sitemap's range, so the sitemaps still list each blog once, the new one included
+	_, err = config.DB.PublishBlog(ctx, &blogProto.PublishBlogRequest{Id: late.Id, Version: late.Version})
+	require.NoError(t, err)
+	seen := map[string]bool{}
+	for _, loc := range index.Sitemaps {
+		require.True(t, strings.HasPrefix(loc, "https://blog.example.com/sitemaps/"), loc)
+		res := get(paged, strings.TrimPrefix(loc, "https://blog.example.com"))
+		require.Equal(t, http.StatusOK, res.Code)
+		page := urlset{}
+		require.NoError(t, xml.Unmarshal(res.Body.Bytes(), &page))
+		require.NotEmpty(t, page.URLs)
+		for _, url := range page.URLs {
+			require.False(t, seen[url.Loc], "listed twice: %v", url.Loc)
+			seen[url.Loc] = true
+		}
+	}
+	require.Len(t, seen, int(count)+1)
+	for _, slug := range slugs {
+		require.True(t, seen["https://blog.example.com/posts/"+slug])
+	}
+	require.False(t, seen["https://blog.example.com/posts/"+draft.Slug])
+	// sitemaps are named after ranges of blog ids, there is none for names that are not
+	require.Equal(t, http.StatusNotFound, get(paged, "/sitemaps/x.xml").Code)
+	require.Equal(t, http.StatusNotFound, get(paged, "/sitemaps/.xml").Code)
+	require.Equal(t, http.StatusNotFound, get(paged, fmt.Sprintf("/sitemaps/%s-.xml", draft.Id)).Code)
+	require.Equal(t, http.StatusNotFound, get(paged, fmt.Sprintf("/sitemaps/%s-x.xml", draft.Id)).Code)
+}